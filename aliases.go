@@ -4,7 +4,9 @@ package openai
 
 import (
 	"github.com/Nordlys-Labs/openai-go/v3/internal/apierror"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/respmeta"
 	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ratelimit"
 	"github.com/Nordlys-Labs/openai-go/v3/shared"
 )
 
@@ -16,6 +18,14 @@ type paramObj = param.APIObject
 
 type Error = apierror.Error
 
+// RateLimitInfo holds the rate limits reported by the x-ratelimit-* headers of a
+// response, see [option.WithRateLimitInto].
+type RateLimitInfo = ratelimit.Info
+
+// ResponseMeta holds the metadata of an API response, such as its request ID, see
+// [ResponseMetaOf] and [option.WithResponseMetaInto].
+type ResponseMeta = respmeta.Meta
+
 // This is an alias to an internal type.
 type ChatModel = shared.ChatModel
 
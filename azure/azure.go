@@ -173,6 +173,10 @@ var multipartRoutes = map[string]bool{
 	"/images/edits":         true,
 }
 
+// realtimeRoute is the WebSocket route for realtime sessions. Azure selects the
+// deployment with a query parameter instead of a path segment.
+const realtimeRoute = "/realtime"
+
 // getReplacementPathWithDeployment parses the request body to extract out the Model parameter (or equivalent)
 // (note, the req.Body is fully read as part of this, and is replaced with a bytes.Reader)
 func getReplacementPathWithDeployment(req *http.Request) (string, error) {
@@ -184,6 +188,10 @@ func getReplacementPathWithDeployment(req *http.Request) (string, error) {
 		return getMultipartRoute(req)
 	}
 
+	if req.URL.Path == realtimeRoute {
+		return getRealtimeRoute(req), nil
+	}
+
 	// If route doesn't require deployment ID substitution, just return path with prefix.
 	return path.Join("/openai/", req.URL.Path), nil
 }
@@ -260,6 +268,15 @@ func getMultipartRoute(req *http.Request) (string, error) {
 	}
 }
 
+func getRealtimeRoute(req *http.Request) string {
+	query := req.URL.Query()
+	if model := query.Get("model"); model != "" && query.Get("deployment") == "" {
+		query.Set("deployment", model)
+		req.URL.RawQuery = query.Encode()
+	}
+	return path.Join("/openai/", req.URL.Path)
+}
+
 type policyAdapter option.MiddlewareNext
 
 func (mp policyAdapter) Do(req *policy.Request) (*http.Response, error) {
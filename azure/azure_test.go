@@ -133,6 +133,21 @@ func TestModelWithSpecialCharsIsEscaped(t *testing.T) {
 	}
 }
 
+func TestRealtimeRoute(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/realtime?api-version=2025-04-01-preview&model=gpt-realtime", nil)
+	got, err := getReplacementPathWithDeployment(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "/openai/realtime" {
+		t.Errorf("got %q, expected %q", got, "/openai/realtime")
+	}
+	if deployment := req.URL.Query().Get("deployment"); deployment != "gpt-realtime" {
+		t.Errorf("deployment: got %q, expected %q", deployment, "gpt-realtime")
+	}
+}
+
 func TestWithEndpointBaseURL(t *testing.T) {
 	tests := map[string]struct {
 		endpoint        string
@@ -0,0 +1,394 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/responses"
+)
+
+const (
+	// BatchMaxRequests is the maximum number of requests in a batch input file.
+	BatchMaxRequests = 50_000
+	// BatchMaxFileBytes is the maximum size of a batch input file.
+	BatchMaxFileBytes = 200 << 20
+)
+
+// BatchBuilder builds the JSONL input files of batches whose requests have
+// params of type P, and reads their results as values of type R.
+//
+// Requests are split over several input files, and therefore several batches,
+// once the line-count or size limit of a file is reached.
+//
+//	builder := openai.NewChatCompletionBatchBuilder()
+//	builder.Add("request-1", openai.ChatCompletionNewParams{...})
+//	job, err := builder.Submit(ctx, &client, openai.BatchNewParams{})
+//	...
+//	err = job.Wait(ctx, 0)
+//	...
+//	results := job.Results(ctx)
+//	defer results.Close()
+//	for results.Next() {
+//		result := results.Current()
+//		...
+//	}
+type BatchBuilder[P any, R any] struct {
+	// The endpoint of every request of the batch.
+	Endpoint BatchNewParamsEndpoint
+	// The maximum number of requests per input file. Defaults to
+	// [BatchMaxRequests].
+	MaxRequests int
+	// The maximum size in bytes of an input file. Defaults to
+	// [BatchMaxFileBytes].
+	MaxBytes int
+
+	files []*batchInputFile
+	ids   map[string]bool
+}
+
+type batchInputFile struct {
+	buf      bytes.Buffer
+	requests int
+}
+
+// NewChatCompletionBatchBuilder returns a builder for batches of chat
+// completions.
+func NewChatCompletionBatchBuilder() *BatchBuilder[ChatCompletionNewParams, ChatCompletion] {
+	return &BatchBuilder[ChatCompletionNewParams, ChatCompletion]{Endpoint: BatchNewParamsEndpointV1ChatCompletions}
+}
+
+// NewResponseBatchBuilder returns a builder for batches of responses.
+func NewResponseBatchBuilder() *BatchBuilder[responses.ResponseNewParams, responses.Response] {
+	return &BatchBuilder[responses.ResponseNewParams, responses.Response]{Endpoint: BatchNewParamsEndpointV1Responses}
+}
+
+// NewEmbeddingBatchBuilder returns a builder for batches of embeddings.
+func NewEmbeddingBatchBuilder() *BatchBuilder[EmbeddingNewParams, CreateEmbeddingResponse] {
+	return &BatchBuilder[EmbeddingNewParams, CreateEmbeddingResponse]{Endpoint: BatchNewParamsEndpointV1Embeddings}
+}
+
+type batchRequestLine struct {
+	CustomID string          `json:"custom_id"`
+	Method   string          `json:"method"`
+	URL      string          `json:"url"`
+	Body     json.RawMessage `json:"body"`
+}
+
+// Add appends a request to the batch. The custom ID identifies the result of
+// the request and must be unique within the builder.
+func (b *BatchBuilder[P, R]) Add(customID string, body P) error {
+	if customID == "" {
+		return errors.New("batch: missing custom ID")
+	}
+	if b.ids[customID] {
+		return fmt.Errorf("batch: duplicate custom ID %q", customID)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("batch: could not marshal request %q: %w", customID, err)
+	}
+	line, err := json.Marshal(batchRequestLine{
+		CustomID: customID,
+		Method:   http.MethodPost,
+		URL:      string(b.Endpoint),
+		Body:     data,
+	})
+	if err != nil {
+		return fmt.Errorf("batch: could not marshal request %q: %w", customID, err)
+	}
+	line = append(line, '\n')
+
+	maxBytes := b.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = BatchMaxFileBytes
+	}
+	maxRequests := b.MaxRequests
+	if maxRequests <= 0 {
+		maxRequests = BatchMaxRequests
+	}
+	if len(line) > maxBytes {
+		return fmt.Errorf("batch: request %q is %d bytes, which exceeds the file limit of %d bytes", customID, len(line), maxBytes)
+	}
+
+	var file *batchInputFile
+	if n := len(b.files); n > 0 {
+		file = b.files[n-1]
+	}
+	if file == nil || file.requests >= maxRequests || file.buf.Len()+len(line) > maxBytes {
+		file = &batchInputFile{}
+		b.files = append(b.files, file)
+	}
+	file.buf.Write(line)
+	file.requests++
+
+	if b.ids == nil {
+		b.ids = map[string]bool{}
+	}
+	b.ids[customID] = true
+	return nil
+}
+
+// Len returns the number of requests added to the builder.
+func (b *BatchBuilder[P, R]) Len() int {
+	return len(b.ids)
+}
+
+// Files returns the JSONL input files, one per batch.
+func (b *BatchBuilder[P, R]) Files() [][]byte {
+	files := make([][]byte, len(b.files))
+	for i, file := range b.files {
+		files[i] = file.buf.Bytes()
+	}
+	return files
+}
+
+// Submit uploads the input files and creates one batch per file. The endpoint
+// and input file of params are set by the builder, and the completion window
+// defaults to 24 hours.
+//
+// When an upload or the creation of a batch fails, the job of the batches
+// created so far is returned along with the error.
+func (b *BatchBuilder[P, R]) Submit(ctx context.Context, client *Client, params BatchNewParams, opts ...option.RequestOption) (*BatchJob[R], error) {
+	if len(b.files) == 0 {
+		return nil, errors.New("batch: no requests to submit")
+	}
+	if params.CompletionWindow == "" {
+		params.CompletionWindow = BatchNewParamsCompletionWindow24h
+	}
+	params.Endpoint = b.Endpoint
+
+	job := &BatchJob[R]{client: client, opts: opts}
+	for i, file := range b.files {
+		name := fmt.Sprintf("batch_input_%d.jsonl", i+1)
+		uploaded, err := client.Files.New(ctx, FileNewParams{
+			File:    File(bytes.NewReader(file.buf.Bytes()), name, "application/jsonl"),
+			Purpose: FilePurposeBatch,
+		}, opts...)
+		if err != nil {
+			return job, fmt.Errorf("batch: could not upload input file %d: %w", i+1, err)
+		}
+
+		params.InputFileID = uploaded.ID
+		batch, err := client.Batches.New(ctx, params, opts...)
+		if err != nil {
+			return job, fmt.Errorf("batch: could not create batch for input file %s: %w", uploaded.ID, err)
+		}
+		job.Batches = append(job.Batches, *batch)
+	}
+	return job, nil
+}
+
+// BatchJob is a set of batches submitted by a [BatchBuilder], whose results
+// are of type R.
+type BatchJob[R any] struct {
+	// The batches of the job, as of their last update.
+	Batches []Batch
+
+	client *Client
+	opts   []option.RequestOption
+}
+
+// NewBatchJob returns the job of existing batches, for instance to read the
+// results of batches submitted by another process.
+func NewBatchJob[R any](client *Client, batches []Batch, opts ...option.RequestOption) *BatchJob[R] {
+	return &BatchJob[R]{Batches: batches, client: client, opts: opts}
+}
+
+// Wait polls every batch of the job until it reaches a terminal status and
+// updates Batches. Pass 0 as pollIntervalMs to use the polling interval
+// suggested by the API.
+func (j *BatchJob[R]) Wait(ctx context.Context, pollIntervalMs int, opts ...option.RequestOption) error {
+	opts = append(j.opts[:len(j.opts):len(j.opts)], opts...)
+	for i := range j.Batches {
+		batch, err := j.client.Batches.PollStatus(ctx, j.Batches[i].ID, pollIntervalMs, opts...)
+		if err != nil {
+			return err
+		}
+		j.Batches[i] = *batch
+	}
+	return nil
+}
+
+// Results streams the results of the output and error files of the batches.
+// Batches which have no output or error file yet are skipped.
+func (j *BatchJob[R]) Results(ctx context.Context, opts ...option.RequestOption) *BatchResultReader[R] {
+	r := &BatchResultReader[R]{ctx: ctx, files: &j.client.Files}
+	r.opts = append(j.opts[:len(j.opts):len(j.opts)], opts...)
+	for _, batch := range j.Batches {
+		if batch.OutputFileID != "" {
+			r.fileIDs = append(r.fileIDs, batch.OutputFileID)
+		}
+		if batch.ErrorFileID != "" {
+			r.fileIDs = append(r.fileIDs, batch.ErrorFileID)
+		}
+	}
+	return r
+}
+
+// ResultsByCustomID reads all the results of the batches, keyed by the custom
+// ID of their request.
+func (j *BatchJob[R]) ResultsByCustomID(ctx context.Context, opts ...option.RequestOption) (map[string]BatchResult[R], error) {
+	results := map[string]BatchResult[R]{}
+	r := j.Results(ctx, opts...)
+	defer r.Close()
+	for r.Next() {
+		result := r.Current()
+		results[result.CustomID] = result
+	}
+	return results, r.Err()
+}
+
+// BatchResult is the result of a single request of a batch.
+type BatchResult[R any] struct {
+	// The ID of the batch request.
+	ID string
+	// The custom ID of the request.
+	CustomID string
+	// The HTTP status code of the response, or zero when the request did not
+	// get a response.
+	StatusCode int
+	// The ID of the API request.
+	RequestID string
+	// The response to the request. It is only set when Error is nil.
+	Response R
+	// The error of the request, if it failed.
+	Error *BatchResultError
+
+	raw string
+}
+
+// RawJSON returns the unmodified output line of the result.
+func (r BatchResult[R]) RawJSON() string { return r.raw }
+
+// BatchResultError is the error of a failed batch request.
+type BatchResultError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *BatchResultError) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+type batchOutputLine struct {
+	ID       string `json:"id"`
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int             `json:"status_code"`
+		RequestID  string          `json:"request_id"`
+		Body       json.RawMessage `json:"body"`
+	} `json:"response"`
+	Error *BatchResultError `json:"error"`
+}
+
+// BatchResultReader streams the results of batches, one line of their output
+// and error files at a time.
+type BatchResultReader[R any] struct {
+	ctx     context.Context
+	files   *FileService
+	opts    []option.RequestOption
+	fileIDs []string
+
+	body   io.ReadCloser
+	reader *bufio.Reader
+	cur    BatchResult[R]
+	err    error
+}
+
+// Next advances to the next result. It returns false once every file has been
+// read or an error occurred.
+func (r *BatchResultReader[R]) Next() bool {
+	for r.err == nil {
+		if r.reader == nil {
+			if len(r.fileIDs) == 0 {
+				return false
+			}
+			var res *http.Response
+			res, r.err = r.files.Content(r.ctx, r.fileIDs[0], r.opts...)
+			if r.err != nil {
+				return false
+			}
+			r.fileIDs = r.fileIDs[1:]
+			r.body = res.Body
+			r.reader = bufio.NewReader(res.Body)
+		}
+
+		line, err := r.reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			r.cur, r.err = decodeBatchResult[R](line)
+			return r.err == nil
+		}
+		if err == io.EOF {
+			r.body.Close()
+			r.body, r.reader = nil, nil
+		} else if err != nil {
+			r.err = err
+		}
+	}
+	return false
+}
+
+func decodeBatchResult[R any](line []byte) (result BatchResult[R], err error) {
+	var out batchOutputLine
+	if err := json.Unmarshal(line, &out); err != nil {
+		return result, fmt.Errorf("batch: could not decode result: %w", err)
+	}
+	result.ID = out.ID
+	result.CustomID = out.CustomID
+	result.Error = out.Error
+	result.raw = string(bytes.TrimSpace(line))
+	if out.Response == nil {
+		return result, nil
+	}
+
+	result.StatusCode = out.Response.StatusCode
+	result.RequestID = out.Response.RequestID
+	if result.StatusCode >= 400 {
+		if result.Error == nil {
+			var body struct {
+				Error BatchResultError `json:"error"`
+			}
+			json.Unmarshal(out.Response.Body, &body)
+			result.Error = &body.Error
+		}
+		return result, nil
+	}
+	if result.Error == nil {
+		if err := json.Unmarshal(out.Response.Body, &result.Response); err != nil {
+			return result, fmt.Errorf("batch: could not decode response of %q: %w", out.CustomID, err)
+		}
+	}
+	return result, nil
+}
+
+// Current returns the result read by the last call to Next.
+func (r *BatchResultReader[R]) Current() BatchResult[R] {
+	return r.cur
+}
+
+// Err returns the error which stopped the reader, if any.
+func (r *BatchResultReader[R]) Err() error {
+	return r.err
+}
+
+// Close closes the file which is currently read.
+func (r *BatchResultReader[R]) Close() error {
+	r.fileIDs = nil
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body, r.reader = nil, nil
+	return err
+}
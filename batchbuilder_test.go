@@ -0,0 +1,194 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+func embeddingParams(input string) openai.EmbeddingNewParams {
+	return openai.EmbeddingNewParams{
+		Input: openai.EmbeddingNewParamsInputUnion{OfString: openai.String(input)},
+		Model: openai.EmbeddingModelTextEmbedding3Small,
+	}
+}
+
+func TestBatchBuilderSplitsFiles(t *testing.T) {
+	builder := openai.NewEmbeddingBatchBuilder()
+	builder.MaxRequests = 2
+	for _, id := range []string{"a", "b", "c"} {
+		if err := builder.Add(id, embeddingParams("hello")); err != nil {
+			t.Fatalf("err should be nil: %s", err.Error())
+		}
+	}
+	if err := builder.Add("a", embeddingParams("again")); err == nil {
+		t.Fatalf("expected an error for a duplicate custom ID")
+	}
+
+	files := builder.Files()
+	if builder.Len() != 3 || len(files) != 2 {
+		t.Fatalf("expected 3 requests in 2 files, got %d requests in %d files", builder.Len(), len(files))
+	}
+	lines := strings.Split(strings.TrimSpace(string(files[0])), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines in the first file, got %d", len(lines))
+	}
+	var line struct {
+		CustomID string         `json:"custom_id"`
+		Method   string         `json:"method"`
+		URL      string         `json:"url"`
+		Body     map[string]any `json:"body"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &line); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if line.CustomID != "a" || line.Method != "POST" || line.URL != "/v1/embeddings" || line.Body["input"] != "hello" {
+		t.Fatalf("unexpected line %s", lines[0])
+	}
+
+	builder = openai.NewEmbeddingBatchBuilder()
+	builder.MaxBytes = len(files[0]) - 1
+	for _, id := range []string{"a", "b"} {
+		if err := builder.Add(id, embeddingParams("hello")); err != nil {
+			t.Fatalf("err should be nil: %s", err.Error())
+		}
+	}
+	if len(builder.Files()) != 2 {
+		t.Fatalf("expected the size limit to split the requests over 2 files")
+	}
+}
+
+// batchServer fakes the files and batches endpoints. Every batch completes on
+// its second poll; its first request succeeds and the others fail.
+type batchServer struct {
+	mu      sync.Mutex
+	files   map[string]string
+	batches map[string]int
+}
+
+func (s *batchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("openai-poll-after-ms", "1")
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/files":
+		_, params, _ := strings.Cut(r.Header.Get("Content-Type"), "boundary=")
+		form, err := multipart.NewReader(r.Body, params).ReadForm(1 << 20)
+		if err != nil || form.Value["purpose"][0] != "batch" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f, _ := form.File["file"][0].Open()
+		data, _ := io.ReadAll(f)
+		id := fmt.Sprintf("file_%d", len(s.files))
+		s.files[id] = string(data)
+		fmt.Fprintf(w, `{"id":%q,"object":"file","purpose":"batch"}`, id)
+
+	case r.Method == http.MethodPost && r.URL.Path == "/batches":
+		var params struct {
+			InputFileID string `json:"input_file_id"`
+			Endpoint    string `json:"endpoint"`
+			Window      string `json:"completion_window"`
+		}
+		json.NewDecoder(r.Body).Decode(&params)
+		if params.Endpoint != "/v1/embeddings" || params.Window != "24h" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		id := "batch_" + params.InputFileID
+		s.batches[id] = 0
+		fmt.Fprintf(w, `{"id":%q,"status":"validating","input_file_id":%q}`, id, params.InputFileID)
+
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/batches/"):
+		id := strings.TrimPrefix(r.URL.Path, "/batches/")
+		s.batches[id]++
+		if s.batches[id] < 2 {
+			fmt.Fprintf(w, `{"id":%q,"status":"in_progress"}`, id)
+			return
+		}
+		input := s.files[strings.TrimPrefix(id, "batch_")]
+		var output, errors strings.Builder
+		for i, line := range strings.Split(strings.TrimSpace(input), "\n") {
+			var req struct {
+				CustomID string `json:"custom_id"`
+			}
+			json.Unmarshal([]byte(line), &req)
+			if i == 0 {
+				fmt.Fprintf(&output, `{"id":"req_%s","custom_id":%q,"response":{"status_code":200,"request_id":"r","body":{"object":"list","model":"m","data":[{"index":0,"object":"embedding","embedding":[0.5]}]}},"error":null}`+"\n", req.CustomID, req.CustomID)
+			} else {
+				fmt.Fprintf(&errors, `{"id":"req_%s","custom_id":%q,"response":{"status_code":400,"request_id":"r","body":{"error":{"code":"invalid_input","message":"bad input"}}},"error":null}`+"\n", req.CustomID, req.CustomID)
+			}
+		}
+		s.files[id+"_output"] = output.String()
+		s.files[id+"_errors"] = errors.String()
+		fmt.Fprintf(w, `{"id":%q,"status":"completed","output_file_id":%q,"error_file_id":%q}`, id, id+"_output", id+"_errors")
+
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/content"):
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/files/"), "/content")
+		w.Header().Set("Content-Type", "application/octet-stream")
+		io.WriteString(w, s.files[id])
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestBatchJob(t *testing.T) {
+	srv := httptest.NewServer(&batchServer{files: map[string]string{}, batches: map[string]int{}})
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	builder := openai.NewEmbeddingBatchBuilder()
+	builder.MaxRequests = 2
+	for _, id := range []string{"a", "b", "c"} {
+		if err := builder.Add(id, embeddingParams(id)); err != nil {
+			t.Fatalf("err should be nil: %s", err.Error())
+		}
+	}
+
+	ctx := context.Background()
+	job, err := builder.Submit(ctx, &client, openai.BatchNewParams{})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if len(job.Batches) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(job.Batches))
+	}
+	if err := job.Wait(ctx, 0); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	for _, batch := range job.Batches {
+		if batch.Status != openai.BatchStatusCompleted {
+			t.Fatalf("expected batch %s to be completed, got %s", batch.ID, batch.Status)
+		}
+	}
+
+	results, err := job.ResultsByCustomID(ctx)
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for _, id := range []string{"a", "c"} {
+		result := results[id]
+		if result.Error != nil || result.StatusCode != 200 || len(result.Response.Data) != 1 || result.Response.Data[0].Embedding[0] != 0.5 {
+			t.Fatalf("unexpected result for %s: %s", id, result.RawJSON())
+		}
+	}
+	if result := results["b"]; result.Error == nil || result.Error.Code != "invalid_input" || result.StatusCode != 400 {
+		t.Fatalf("unexpected result for b: %s", result.RawJSON())
+	}
+}
@@ -0,0 +1,407 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ssestream"
+)
+
+// AssistantStreamHandler handles the events of a streamed run with typed
+// callbacks, and accumulates snapshots of the messages and run steps of the run.
+// When the run requires action and ToolCall is set, it calls ToolCall for every
+// required tool call, submits the outputs with
+// [BetaThreadRunService.SubmitToolOutputsStreaming] and handles the events of the
+// continued run in the same loop.
+//
+//	handler := client.Beta.Threads.Runs.NewStreamHandler()
+//	handler.OnTextDelta = func(delta openai.TextDelta, snapshot openai.Text) error {
+//		fmt.Print(delta.Value)
+//		return nil
+//	}
+//	handler.ToolCall = func(ctx context.Context, call openai.RequiredActionFunctionToolCall) (string, error) {
+//		return getWeather(call.Function.Arguments), nil
+//	}
+//	run, err := handler.Handle(ctx, client.Beta.Threads.Runs.NewStreaming(ctx, threadID, params))
+//
+// The callbacks are optional. An error returned by a callback stops the handling
+// and is returned by [AssistantStreamHandler.Handle]. A handler with a ToolCall
+// must be created by [BetaThreadRunService.NewStreamHandler].
+type AssistantStreamHandler struct {
+	// OnEvent is called with every event, before the other callbacks.
+	OnEvent func(event AssistantStreamEventUnion) error
+	// OnRun is called when the status of the run changes.
+	OnRun func(run Run) error
+	// OnMessageCreated is called when a message is created.
+	OnMessageCreated func(message Message) error
+	// OnMessageDelta is called with every delta of a message, and the message
+	// accumulated so far.
+	OnMessageDelta func(delta MessageDelta, snapshot Message) error
+	// OnTextDelta is called with every delta of a text content of a message, and
+	// the text accumulated so far.
+	OnTextDelta func(delta TextDelta, snapshot Text) error
+	// OnMessageDone is called when a message is completed or incomplete.
+	OnMessageDone func(message Message) error
+	// OnRunStepCreated is called when a run step is created.
+	OnRunStepCreated func(step RunStep) error
+	// OnRunStepDelta is called with every delta of a run step, and the run step
+	// accumulated so far.
+	OnRunStepDelta func(delta RunStepDelta, snapshot RunStep) error
+	// OnToolCallDelta is called with every delta of a tool call of a run step, and
+	// the tool call accumulated so far.
+	OnToolCallDelta func(delta ToolCallDeltaUnion, snapshot ToolCallUnion) error
+	// OnRunStepDone is called when a run step is completed, failed, cancelled or
+	// expired.
+	OnRunStepDone func(step RunStep) error
+	// ToolCall returns the output of a function call required by the run. When
+	// nil, [AssistantStreamHandler.Handle] returns the run requiring action.
+	ToolCall func(ctx context.Context, call RequiredActionFunctionToolCall) (output string, err error)
+
+	service  *BetaThreadRunService
+	opts     []option.RequestOption
+	run      *Run
+	messages []assistantSnapshot
+	steps    []assistantSnapshot
+}
+
+// assistantSnapshot is a message or run step accumulated from its deltas, kept as
+// JSON so that any delta can be merged into it.
+type assistantSnapshot struct {
+	id   string
+	data map[string]any
+}
+
+// NewStreamHandler returns an [AssistantStreamHandler] submitting the tool
+// outputs with the service and opts.
+func (r *BetaThreadRunService) NewStreamHandler(opts ...option.RequestOption) *AssistantStreamHandler {
+	return &AssistantStreamHandler{service: r, opts: opts}
+}
+
+// errNoRunService is returned by the handlers with a ToolCall which were not
+// created by a [BetaThreadRunService], as they cannot submit the tool outputs.
+var errNoRunService = errors.New("openai: a stream handler with a ToolCall must be created with BetaThreadRunService.NewStreamHandler")
+
+// Handle handles the events of stream until the run is done, or requires action
+// and ToolCall is nil, and returns the last state of the run. The stream is
+// closed.
+func (h *AssistantStreamHandler) Handle(ctx context.Context, stream *ssestream.Stream[AssistantStreamEventUnion]) (*Run, error) {
+	if h.ToolCall != nil && h.service == nil {
+		stream.Close()
+		return nil, errNoRunService
+	}
+	for {
+		err := h.handleStream(stream)
+		if err != nil {
+			return h.run, err
+		}
+		if h.run == nil || h.run.Status != RunStatusRequiresAction || h.ToolCall == nil {
+			return h.run, nil
+		}
+
+		params := BetaThreadRunSubmitToolOutputsParams{}
+		for _, call := range h.run.RequiredAction.SubmitToolOutputs.ToolCalls {
+			output, err := h.ToolCall(ctx, call)
+			if err != nil {
+				return h.run, err
+			}
+			params.ToolOutputs = append(params.ToolOutputs, BetaThreadRunSubmitToolOutputsParamsToolOutput{
+				ToolCallID: String(call.ID),
+				Output:     String(output),
+			})
+		}
+		stream = h.service.SubmitToolOutputsStreaming(ctx, h.run.ThreadID, h.run.ID, params, h.opts...)
+	}
+}
+
+// Run returns the last state of the run, or nil before its first event.
+func (h *AssistantStreamHandler) Run() *Run {
+	return h.run
+}
+
+// Messages returns the snapshots of the messages of the run, in the order of
+// their creation.
+func (h *AssistantStreamHandler) Messages() []Message {
+	messages := make([]Message, 0, len(h.messages))
+	for _, snapshot := range h.messages {
+		var message Message
+		if decodeSnapshot(snapshot.data, &message) == nil {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// RunSteps returns the snapshots of the run steps of the run, in the order of
+// their creation.
+func (h *AssistantStreamHandler) RunSteps() []RunStep {
+	steps := make([]RunStep, 0, len(h.steps))
+	for _, snapshot := range h.steps {
+		var step RunStep
+		if decodeSnapshot(snapshot.data, &step) == nil {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+func (h *AssistantStreamHandler) handleStream(stream *ssestream.Stream[AssistantStreamEventUnion]) error {
+	defer stream.Close()
+	for stream.Next() {
+		if err := h.handleEvent(stream.Current()); err != nil {
+			return err
+		}
+	}
+	return stream.Err()
+}
+
+func (h *AssistantStreamHandler) handleEvent(event AssistantStreamEventUnion) error {
+	if h.OnEvent != nil {
+		if err := h.OnEvent(event); err != nil {
+			return err
+		}
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(event.RawJSON()), &envelope); err != nil {
+		return err
+	}
+
+	switch {
+	case event.Event == "error":
+		return fmt.Errorf("openai: error event in assistant stream: %s", event.Data.Message)
+
+	case strings.HasPrefix(event.Event, "thread.message."):
+		return h.handleMessage(event.Event, envelope.Data)
+
+	case strings.HasPrefix(event.Event, "thread.run.step."):
+		return h.handleRunStep(event.Event, envelope.Data)
+
+	case strings.HasPrefix(event.Event, "thread.run."):
+		run := &Run{}
+		if err := run.UnmarshalJSON(envelope.Data); err != nil {
+			return err
+		}
+		h.run = run
+		if h.OnRun != nil {
+			return h.OnRun(*run)
+		}
+	}
+	return nil
+}
+
+func (h *AssistantStreamHandler) handleMessage(event string, data []byte) error {
+	if event != "thread.message.delta" {
+		message := Message{}
+		if err := message.UnmarshalJSON(data); err != nil {
+			return err
+		}
+		if err := replaceSnapshot(&h.messages, message.ID, data); err != nil {
+			return err
+		}
+		switch {
+		case event == "thread.message.created" && h.OnMessageCreated != nil:
+			return h.OnMessageCreated(message)
+		case (event == "thread.message.completed" || event == "thread.message.incomplete") && h.OnMessageDone != nil:
+			return h.OnMessageDone(message)
+		}
+		return nil
+	}
+
+	delta := MessageDeltaEvent{}
+	if err := delta.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	snapshot, err := mergeSnapshot(&h.messages, delta.ID, data)
+	if err != nil || h.OnMessageDelta == nil && h.OnTextDelta == nil {
+		return err
+	}
+	var message Message
+	if err := decodeSnapshot(snapshot, &message); err != nil {
+		return err
+	}
+	if h.OnMessageDelta != nil {
+		if err := h.OnMessageDelta(delta.Delta, message); err != nil {
+			return err
+		}
+	}
+	if h.OnTextDelta != nil {
+		for _, content := range delta.Delta.Content {
+			if content.Type != "text" || int(content.Index) >= len(message.Content) {
+				continue
+			}
+			if err := h.OnTextDelta(content.Text, message.Content[content.Index].Text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *AssistantStreamHandler) handleRunStep(event string, data []byte) error {
+	if event != "thread.run.step.delta" {
+		step := RunStep{}
+		if err := step.UnmarshalJSON(data); err != nil {
+			return err
+		}
+		if err := replaceSnapshot(&h.steps, step.ID, data); err != nil {
+			return err
+		}
+		switch event {
+		case "thread.run.step.created":
+			if h.OnRunStepCreated != nil {
+				return h.OnRunStepCreated(step)
+			}
+		case "thread.run.step.completed", "thread.run.step.failed", "thread.run.step.cancelled", "thread.run.step.expired":
+			if h.OnRunStepDone != nil {
+				return h.OnRunStepDone(step)
+			}
+		}
+		return nil
+	}
+
+	delta := RunStepDeltaEvent{}
+	if err := delta.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	snapshot, err := mergeSnapshot(&h.steps, delta.ID, data)
+	if err != nil || h.OnRunStepDelta == nil && h.OnToolCallDelta == nil {
+		return err
+	}
+	var step RunStep
+	if err := decodeSnapshot(snapshot, &step); err != nil {
+		return err
+	}
+	if h.OnRunStepDelta != nil {
+		if err := h.OnRunStepDelta(delta.Delta, step); err != nil {
+			return err
+		}
+	}
+	if h.OnToolCallDelta != nil {
+		toolCalls := step.StepDetails.ToolCalls
+		for _, call := range delta.Delta.StepDetails.ToolCalls {
+			if int(call.Index) >= len(toolCalls) {
+				continue
+			}
+			if err := h.OnToolCallDelta(call, toolCalls[call.Index]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replaceSnapshot sets the snapshot of an object from its full JSON, adding it
+// when it is new.
+func replaceSnapshot(snapshots *[]assistantSnapshot, id string, data []byte) error {
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	i := slices.IndexFunc(*snapshots, func(s assistantSnapshot) bool { return s.id == id })
+	if i < 0 {
+		*snapshots = append(*snapshots, assistantSnapshot{id: id, data: fields})
+	} else {
+		(*snapshots)[i].data = fields
+	}
+	return nil
+}
+
+// mergeSnapshot merges the delta field of a delta event into the snapshot of its
+// object.
+func mergeSnapshot(snapshots *[]assistantSnapshot, id string, data []byte) (map[string]any, error) {
+	var event struct {
+		Delta map[string]any `json:"delta"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(*snapshots, func(s assistantSnapshot) bool { return s.id == id })
+	if i < 0 {
+		return nil, fmt.Errorf("openai: received a delta for %s before its creation", id)
+	}
+	if err := accumulateDelta((*snapshots)[i].data, event.Delta); err != nil {
+		return nil, err
+	}
+	return (*snapshots)[i].data, nil
+}
+
+// accumulateDelta merges a JSON delta into a JSON object: strings are appended,
+// objects are merged, and the elements of arrays are merged by their index field.
+// The identifying fields are replaced, and null values do not erase the object.
+func accumulateDelta(acc map[string]any, delta map[string]any) error {
+	for key, value := range delta {
+		current, ok := acc[key]
+		if !ok || current == nil || key == "index" || key == "type" || key == "id" || key == "role" {
+			acc[key] = value
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		switch value := value.(type) {
+		case string:
+			if s, ok := current.(string); ok {
+				acc[key] = s + value
+				continue
+			}
+		case map[string]any:
+			if m, ok := current.(map[string]any); ok {
+				if err := accumulateDelta(m, value); err != nil {
+					return err
+				}
+				continue
+			}
+		case []any:
+			if list, ok := current.([]any); ok {
+				merged, err := accumulateList(list, value)
+				if err != nil {
+					return err
+				}
+				acc[key] = merged
+				continue
+			}
+		}
+		acc[key] = value
+	}
+	return nil
+}
+
+func accumulateList(acc []any, delta []any) ([]any, error) {
+	for _, entry := range delta {
+		fields, ok := entry.(map[string]any)
+		if !ok {
+			acc = append(acc, entry)
+			continue
+		}
+		index, ok := fields["index"].(float64)
+		if !ok || index < 0 {
+			return nil, errors.New("openai: expected an index in the elements of a delta array")
+		}
+		for len(acc) <= int(index) {
+			acc = append(acc, nil)
+		}
+		current, ok := acc[int(index)].(map[string]any)
+		if !ok {
+			acc[int(index)] = fields
+			continue
+		}
+		if err := accumulateDelta(current, fields); err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}
+
+func decodeSnapshot(snapshot map[string]any, v json.Unmarshaler) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalJSON(data)
+}
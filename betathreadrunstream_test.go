@@ -0,0 +1,149 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+func writeAssistantEvents(w http.ResponseWriter, events ...string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for i := 0; i < len(events); i += 2 {
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", events[i], events[i+1])
+	}
+	fmt.Fprint(w, "event: done\ndata: [DONE]\n\n")
+}
+
+func assistantRun(status string, extra string) string {
+	return `{"id": "run_1", "object": "thread.run", "thread_id": "thread_1", "assistant_id": "asst_1", "status": "` + status + `"` + extra + `}`
+}
+
+func TestAssistantStreamHandler(t *testing.T) {
+	var submitted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/threads/thread_1/runs":
+			writeAssistantEvents(w,
+				"thread.run.created", assistantRun("queued", ""),
+				"thread.message.created", `{"id": "msg_1", "object": "thread.message", "thread_id": "thread_1", "role": "assistant", "status": "in_progress", "content": []}`,
+				"thread.message.delta", `{"id": "msg_1", "object": "thread.message.delta", "delta": {"content": [{"index": 0, "type": "text", "text": {"value": "Let me ", "annotations": []}}]}}`,
+				"thread.message.delta", `{"id": "msg_1", "object": "thread.message.delta", "delta": {"content": [{"index": 0, "type": "text", "text": {"value": "check."}}]}}`,
+				"thread.message.completed", `{"id": "msg_1", "object": "thread.message", "thread_id": "thread_1", "role": "assistant", "status": "completed", "content": [{"type": "text", "text": {"value": "Let me check.", "annotations": []}}]}`,
+				"thread.run.step.created", `{"id": "step_1", "object": "thread.run.step", "run_id": "run_1", "type": "tool_calls", "status": "in_progress", "step_details": {"type": "tool_calls", "tool_calls": []}}`,
+				"thread.run.step.delta", `{"id": "step_1", "object": "thread.run.step.delta", "delta": {"step_details": {"type": "tool_calls", "tool_calls": [{"index": 0, "id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":"}}]}}}`,
+				"thread.run.step.delta", `{"id": "step_1", "object": "thread.run.step.delta", "delta": {"step_details": {"type": "tool_calls", "tool_calls": [{"index": 0, "type": "function", "function": {"arguments": "\"Paris\"}"}}]}}}`,
+				"thread.run.requires_action", assistantRun("requires_action", `, "required_action": {"type": "submit_tool_outputs", "submit_tool_outputs": {"tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}}]}}`),
+			)
+		case "/threads/thread_1/runs/run_1/submit_tool_outputs":
+			body, _ := io.ReadAll(r.Body)
+			submitted = string(body)
+			writeAssistantEvents(w,
+				"thread.message.created", `{"id": "msg_2", "object": "thread.message", "thread_id": "thread_1", "role": "assistant", "status": "in_progress", "content": []}`,
+				"thread.message.delta", `{"id": "msg_2", "object": "thread.message.delta", "delta": {"content": [{"index": 0, "type": "text", "text": {"value": "It is sunny."}}]}}`,
+				"thread.run.completed", assistantRun("completed", ""),
+			)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+	ctx := context.Background()
+	handler := client.Beta.Threads.Runs.NewStreamHandler()
+
+	var text strings.Builder
+	var snapshots []string
+	handler.OnTextDelta = func(delta openai.TextDelta, snapshot openai.Text) error {
+		text.WriteString(delta.Value)
+		snapshots = append(snapshots, snapshot.Value)
+		return nil
+	}
+	var arguments []string
+	handler.OnToolCallDelta = func(delta openai.ToolCallDeltaUnion, snapshot openai.ToolCallUnion) error {
+		arguments = append(arguments, snapshot.Function.Arguments)
+		return nil
+	}
+	var statuses []openai.RunStatus
+	handler.OnRun = func(run openai.Run) error {
+		statuses = append(statuses, run.Status)
+		return nil
+	}
+	handler.ToolCall = func(ctx context.Context, call openai.RequiredActionFunctionToolCall) (string, error) {
+		if call.Function.Name != "get_weather" {
+			t.Errorf("unexpected tool call %s", call.Function.Name)
+		}
+		return "sunny", nil
+	}
+
+	run, err := handler.Handle(ctx, client.Beta.Threads.Runs.NewStreaming(ctx, "thread_1", openai.BetaThreadRunNewParams{AssistantID: "asst_1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != openai.RunStatusCompleted || len(statuses) != 3 {
+		t.Errorf("expected the run to complete after the tool outputs, got %v", statuses)
+	}
+	if text.String() != "Let me check.It is sunny." || strings.Join(snapshots, "|") != "Let me |Let me check.|It is sunny." {
+		t.Errorf("unexpected text deltas %q and snapshots %q", text.String(), snapshots)
+	}
+	if strings.Join(arguments, "|") != `{"city":|{"city":"Paris"}` {
+		t.Errorf("unexpected tool call snapshots %q", arguments)
+	}
+
+	var params struct {
+		ToolOutputs []struct {
+			ToolCallID string `json:"tool_call_id"`
+			Output     string `json:"output"`
+		} `json:"tool_outputs"`
+	}
+	if err := json.Unmarshal([]byte(submitted), &params); err != nil || len(params.ToolOutputs) != 1 || params.ToolOutputs[0].ToolCallID != "call_1" || params.ToolOutputs[0].Output != "sunny" {
+		t.Errorf("unexpected tool outputs %s", submitted)
+	}
+
+	messages := handler.Messages()
+	if len(messages) != 2 || messages[0].Status != openai.MessageStatusCompleted || messages[1].Content[0].Text.Value != "It is sunny." {
+		t.Errorf("unexpected message snapshots %v", messages)
+	}
+	steps := handler.RunSteps()
+	if len(steps) != 1 || steps[0].StepDetails.ToolCalls[0].ID != "call_1" || steps[0].StepDetails.ToolCalls[0].Function.Name != "get_weather" {
+		t.Errorf("unexpected run step snapshots %v", steps)
+	}
+}
+
+func TestAssistantStreamHandlerWithoutService(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeAssistantEvents(w,
+			"thread.run.requires_action", assistantRun("requires_action", `, "required_action": {"type": "submit_tool_outputs", "submit_tool_outputs": {"tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{}"}}]}}`),
+		)
+	}))
+	defer srv.Close()
+
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+	ctx := context.Background()
+	called := false
+	handler := &openai.AssistantStreamHandler{
+		ToolCall: func(ctx context.Context, call openai.RequiredActionFunctionToolCall) (string, error) {
+			called = true
+			return "sunny", nil
+		},
+	}
+	stream := client.Beta.Threads.Runs.NewStreaming(ctx, "thread_1", openai.BetaThreadRunNewParams{AssistantID: "asst_1"})
+	if _, err := handler.Handle(ctx, stream); err == nil || called {
+		t.Fatalf("expected an error without calling the tool, got %v", err)
+	}
+
+	// A handler without a ToolCall does not need the service.
+	stream = client.Beta.Threads.Runs.NewStreaming(ctx, "thread_1", openai.BetaThreadRunNewParams{AssistantID: "asst_1"})
+	run, err := (&openai.AssistantStreamHandler{}).Handle(ctx, stream)
+	if err != nil || run.Status != openai.RunStatusRequiresAction {
+		t.Fatalf("expected the run requiring action, got %v, %v", run, err)
+	}
+}
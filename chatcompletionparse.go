@@ -0,0 +1,188 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apierror"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/partialjson"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/jsonschema"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ssestream"
+	"github.com/Nordlys-Labs/openai-go/v3/shared"
+)
+
+// RefusalError is returned when the model refuses to produce structured output.
+type RefusalError = apierror.RefusalError
+
+// IncompleteOutputError is returned when structured output cannot be parsed
+// because generation stopped early.
+type IncompleteOutputError = apierror.IncompleteOutputError
+
+// ParsedChatCompletion is a [ChatCompletion] whose first choice was decoded into
+// a value of type T.
+type ParsedChatCompletion[T any] struct {
+	ChatCompletion
+	// The content of the first choice, decoded into T. It is the zero value when
+	// the model called tools instead of answering.
+	Parsed T
+}
+
+// ParseChatCompletion creates a chat completion with a JSON schema response
+// format derived from T using [jsonschema.For], and decodes the content of the
+// first choice into T.
+//
+// If the params already set a ResponseFormat, it is used as is.
+//
+// When the model refuses, a [*RefusalError] is returned, and when generation
+// stops early because of the token limit or the content filter, an
+// [*IncompleteOutputError] is returned. In both cases the chat completion is
+// returned alongside the error.
+//
+//	type Answer struct {
+//		City    string `json:"city"`
+//		Country string `json:"country"`
+//	}
+//
+//	completion, err := openai.ParseChatCompletion[Answer](ctx, &client.Chat.Completions, params)
+//	if err != nil {
+//		...
+//	}
+//	fmt.Println(completion.Parsed.City)
+func ParseChatCompletion[T any](ctx context.Context, r *ChatCompletionService, body ChatCompletionNewParams, opts ...option.RequestOption) (*ParsedChatCompletion[T], error) {
+	body, err := withChatCompletionResponseFormat[T](body)
+	if err != nil {
+		return nil, err
+	}
+	completion, err := r.New(ctx, body, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return parseChatCompletion[T](*completion)
+}
+
+// ParseChatCompletionStreaming is the streaming variant of
+// [ParseChatCompletion]. The returned stream exposes the partially decoded
+// content as chunks arrive.
+func ParseChatCompletionStreaming[T any](ctx context.Context, r *ChatCompletionService, body ChatCompletionNewParams, opts ...option.RequestOption) *ChatCompletionParseStream[T] {
+	body, err := withChatCompletionResponseFormat[T](body)
+	if err != nil {
+		return &ChatCompletionParseStream[T]{Stream: ssestream.NewStream[ChatCompletionChunk](nil, err)}
+	}
+	return &ChatCompletionParseStream[T]{Stream: r.NewStreaming(ctx, body, opts...)}
+}
+
+// ChatCompletionParseStream is a stream of [ChatCompletionChunk] which
+// accumulates the chunks and decodes the content of the first choice as it
+// grows.
+//
+//	stream := openai.ParseChatCompletionStreaming[Answer](ctx, &client.Chat.Completions, params)
+//	for stream.Next() {
+//		fmt.Printf("%+v\n", stream.Partial())
+//	}
+//	completion, err := stream.Result()
+type ChatCompletionParseStream[T any] struct {
+	*ssestream.Stream[ChatCompletionChunk]
+	acc     ChatCompletionAccumulator
+	partial T
+	err     error
+}
+
+// Next advances the stream and updates the accumulated chat completion. It
+// returns false once the stream has ended or an error occurred.
+func (s *ChatCompletionParseStream[T]) Next() bool {
+	if s.err != nil || !s.Stream.Next() {
+		return false
+	}
+	chunk := s.Stream.Current()
+	if !s.acc.AddChunk(chunk) {
+		s.err = fmt.Errorf("openai: chunk %q does not belong to chat completion %q", chunk.ID, s.acc.ID)
+		return false
+	}
+	if len(chunk.Choices) > 0 && chunk.Choices[0].Index == 0 && chunk.Choices[0].Delta.Content != "" {
+		var partial T
+		// Partial output is decoded on a best-effort basis. Errors are reported
+		// by Result once the output is complete.
+		if partialjson.Unmarshal(s.acc.Choices[0].Message.Content, &partial) == nil {
+			s.partial = partial
+		}
+	}
+	return true
+}
+
+// Partial returns the content of the first choice decoded from the chunks
+// received so far. Fields which have not been generated yet hold their zero
+// value, and the last string field may be cut short.
+func (s *ChatCompletionParseStream[T]) Partial() T {
+	return s.partial
+}
+
+// Accumulated returns the chat completion accumulated from the chunks received
+// so far.
+func (s *ChatCompletionParseStream[T]) Accumulated() ChatCompletion {
+	return s.acc.ChatCompletion
+}
+
+func (s *ChatCompletionParseStream[T]) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.Stream.Err()
+}
+
+// Result consumes the rest of the stream and decodes the accumulated chat
+// completion, reporting errors like [ParseChatCompletion].
+func (s *ChatCompletionParseStream[T]) Result() (*ParsedChatCompletion[T], error) {
+	for s.Next() {
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return parseChatCompletion[T](s.acc.ChatCompletion)
+}
+
+func withChatCompletionResponseFormat[T any](body ChatCompletionNewParams) (ChatCompletionNewParams, error) {
+	if !param.IsOmitted(body.ResponseFormat) {
+		return body, nil
+	}
+	schema, err := jsonschema.For[T]()
+	if err != nil {
+		return body, err
+	}
+	body.ResponseFormat = ChatCompletionNewParamsResponseFormatUnion{
+		OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
+			JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
+				Name:   jsonschema.Name(reflect.TypeOf((*T)(nil)).Elem()),
+				Schema: schema,
+				Strict: Bool(true),
+			},
+		},
+	}
+	return body, nil
+}
+
+func parseChatCompletion[T any](completion ChatCompletion) (*ParsedChatCompletion[T], error) {
+	parsed := &ParsedChatCompletion[T]{ChatCompletion: completion}
+	if len(completion.Choices) == 0 {
+		return parsed, fmt.Errorf("openai: chat completion %q has no choices", completion.ID)
+	}
+
+	choice := completion.Choices[0]
+	if choice.Message.Refusal != "" {
+		return parsed, &RefusalError{Refusal: choice.Message.Refusal}
+	}
+	switch choice.FinishReason {
+	case "length", "content_filter":
+		return parsed, &IncompleteOutputError{Reason: choice.FinishReason}
+	}
+	if choice.Message.Content == "" && len(choice.Message.ToolCalls) > 0 {
+		return parsed, nil
+	}
+	if err := json.Unmarshal([]byte(choice.Message.Content), &parsed.Parsed); err != nil {
+		return parsed, fmt.Errorf("openai: failed to decode structured output: %w", err)
+	}
+	return parsed, nil
+}
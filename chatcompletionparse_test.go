@@ -0,0 +1,154 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/tidwall/gjson"
+)
+
+type parsedCity struct {
+	City       string   `json:"city" jsonschema_description:"The name of the city"`
+	Country    string   `json:"country"`
+	Population *int64   `json:"population"`
+	Landmarks  []string `json:"landmarks"`
+}
+
+func chatCompletionBody(message string, finishReason string) string {
+	return fmt.Sprintf(`{"id":"chatcmpl_1","object":"chat.completion","created":1,"model":"gpt-4o","choices":[{"index":0,"finish_reason":%q,"message":{"role":"assistant",%s}}]}`, finishReason, message)
+}
+
+func newChatCompletionServer(t *testing.T, body string, requests chan<- []byte) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if requests != nil {
+			requests <- data
+		}
+		if strings.HasPrefix(body, "data:") {
+			w.Header().Set("Content-Type", "text/event-stream")
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestParseChatCompletion(t *testing.T) {
+	requests := make(chan []byte, 1)
+	srv := newChatCompletionServer(t, chatCompletionBody(`"content":"{\"city\":\"Paris\",\"country\":\"France\",\"population\":null,\"landmarks\":[\"Louvre\"]}"`, "stop"), requests)
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	completion, err := openai.ParseChatCompletion[parsedCity](context.Background(), &client.Chat.Completions, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Where is the Louvre?")},
+		Model:    openai.ChatModelGPT4o,
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if completion.Parsed.City != "Paris" || completion.Parsed.Country != "France" || completion.Parsed.Population != nil || len(completion.Parsed.Landmarks) != 1 {
+		t.Fatalf("unexpected parsed value %+v", completion.Parsed)
+	}
+	if completion.ID != "chatcmpl_1" {
+		t.Fatalf("expected the chat completion to be embedded, got ID %q", completion.ID)
+	}
+
+	body := gjson.ParseBytes(<-requests)
+	format := body.Get("response_format")
+	if format.Get("type").String() != "json_schema" || format.Get("json_schema.name").String() != "parsedCity" || !format.Get("json_schema.strict").Bool() {
+		t.Fatalf("unexpected response format %s", format.Raw)
+	}
+	var keys []string
+	format.Get("json_schema.schema.properties").ForEach(func(key, _ gjson.Result) bool {
+		keys = append(keys, key.String())
+		return true
+	})
+	if strings.Join(keys, ",") != "city,country,population,landmarks" {
+		t.Fatalf("expected properties in field order, got %v", keys)
+	}
+}
+
+func TestParseChatCompletionErrors(t *testing.T) {
+	cases := map[string]struct {
+		body  string
+		check func(error) bool
+	}{
+		"refusal": {
+			body: chatCompletionBody(`"content":"","refusal":"I can't help with that."`, "stop"),
+			check: func(err error) bool {
+				var refusal *openai.RefusalError
+				return errors.As(err, &refusal) && refusal.Refusal == "I can't help with that."
+			},
+		},
+		"length": {
+			body: chatCompletionBody(`"content":"{\"city\":\"Par"`, "length"),
+			check: func(err error) bool {
+				var incomplete *openai.IncompleteOutputError
+				return errors.As(err, &incomplete) && incomplete.Reason == "length"
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := newChatCompletionServer(t, tc.body, nil)
+			client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+			completion, err := openai.ParseChatCompletion[parsedCity](context.Background(), &client.Chat.Completions, openai.ChatCompletionNewParams{
+				Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Where is the Louvre?")},
+				Model:    openai.ChatModelGPT4o,
+			})
+			if !tc.check(err) {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if completion == nil || completion.ID != "chatcmpl_1" {
+				t.Fatalf("expected the chat completion to be returned with the error")
+			}
+		})
+	}
+}
+
+func TestParseChatCompletionStreaming(t *testing.T) {
+	deltas := []string{`{\"city\":\"Pa`, `ris\",\"coun`, `try\":\"France\",\"population\":2102650,`, `\"landmarks\":[]}`}
+	var body strings.Builder
+	for _, delta := range deltas {
+		fmt.Fprintf(&body, "data: {\"id\":\"chatcmpl_1\",\"object\":\"chat.completion.chunk\",\"created\":1,\"model\":\"gpt-4o\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"%s\"},\"finish_reason\":null}]}\n\n", delta)
+	}
+	body.WriteString("data: {\"id\":\"chatcmpl_1\",\"object\":\"chat.completion.chunk\",\"created\":1,\"model\":\"gpt-4o\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n")
+	body.WriteString("data: [DONE]\n\n")
+
+	srv := newChatCompletionServer(t, body.String(), nil)
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+	stream := openai.ParseChatCompletionStreaming[parsedCity](context.Background(), &client.Chat.Completions, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Where is the Louvre?")},
+		Model:    openai.ChatModelGPT4o,
+	})
+	defer stream.Close()
+
+	var partials []parsedCity
+	for stream.Next() {
+		partials = append(partials, stream.Partial())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if len(partials) != 5 || partials[0].City != "Pa" || partials[1].City != "Paris" || partials[1].Country != "" || partials[2].Country != "France" {
+		t.Fatalf("unexpected partial values %+v", partials)
+	}
+
+	completion, err := stream.Result()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if completion.Parsed.City != "Paris" || completion.Parsed.Population == nil || *completion.Parsed.Population != 2102650 {
+		t.Fatalf("unexpected parsed value %+v", completion.Parsed)
+	}
+}
@@ -39,6 +39,7 @@ type Client struct {
 	Responses     responses.ResponseService
 	Realtime      realtime.RealtimeService
 	Conversations conversations.ConversationService
+	Evals         EvalService
 	Containers    ContainerService
 	Skills        SkillService
 	Videos        VideoService
@@ -95,6 +96,7 @@ func NewClient(opts ...option.RequestOption) (r Client) {
 	r.Responses = responses.NewResponseService(opts...)
 	r.Realtime = realtime.NewRealtimeService(opts...)
 	r.Conversations = conversations.NewConversationService(opts...)
+	r.Evals = NewEvalService(opts...)
 	r.Containers = NewContainerService(opts...)
 	r.Skills = NewSkillService(opts...)
 	r.Videos = NewVideoService(opts...)
@@ -0,0 +1,58 @@
+package openai
+
+import (
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apierror"
+)
+
+// Sentinels matching the errors of the API with errors.Is, from the status code,
+// code and type of an [*Error], or from the code and type of a
+// ssestream.StreamError received in the middle of a stream:
+//
+//	_, err := client.Chat.Completions.New(ctx, params)
+//	switch {
+//	case errors.Is(err, openai.ErrContextLengthExceeded):
+//		// Shorten the conversation.
+//	case errors.Is(err, openai.ErrInsufficientQuota):
+//		// Retrying will not help.
+//	case errors.Is(err, openai.ErrRateLimited), errors.Is(err, openai.ErrTimeout):
+//		// Try again later.
+//	}
+//
+// An error may match several sentinels, for instance a context length error is
+// also a bad request.
+var (
+	ErrBadRequest            = apierror.ErrBadRequest
+	ErrAuthentication        = apierror.ErrAuthentication
+	ErrPermissionDenied      = apierror.ErrPermissionDenied
+	ErrNotFound              = apierror.ErrNotFound
+	ErrConflict              = apierror.ErrConflict
+	ErrUnprocessableEntity   = apierror.ErrUnprocessableEntity
+	ErrRateLimited           = apierror.ErrRateLimited
+	ErrInsufficientQuota     = apierror.ErrInsufficientQuota
+	ErrContextLengthExceeded = apierror.ErrContextLengthExceeded
+	ErrContentFilter         = apierror.ErrContentFilter
+	ErrServerError           = apierror.ErrServerError
+)
+
+// Sentinels matching the errors which happen before the API answers. They do not
+// match an [*Error].
+var (
+	// ErrConnection matches a [*ConnectionError].
+	ErrConnection = apierror.ErrConnection
+	// ErrTimeout matches a [*TimeoutError], a [*StreamIdleTimeoutError], and a
+	// [*ConnectionError] caused by a timeout.
+	ErrTimeout = apierror.ErrTimeout
+)
+
+// ConnectionError is returned when a request could not be sent, or its response
+// could not be received, after every retry. It wraps the error of the HTTP client.
+type ConnectionError = apierror.ConnectionError
+
+// TimeoutError is returned when an attempt of a request exceeds the timeout set
+// with option.WithRequestTimeout. It wraps [context.DeadlineExceeded].
+type TimeoutError = apierror.TimeoutError
+
+// StreamIdleTimeoutError is returned by a stream which received nothing, not even
+// a ping, for the timeout set with option.WithStreamIdleTimeout. It matches
+// [ErrTimeout], but not [context.DeadlineExceeded] unlike a [*TimeoutError].
+type StreamIdleTimeoutError = apierror.StreamIdleTimeoutError
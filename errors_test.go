@@ -0,0 +1,134 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/openaitest"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ssestream"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client(option.WithMaxRetries(0))
+	ctx := context.Background()
+	params := openai.ChatCompletionNewParams{
+		Model:    openai.ChatModelGPT4o,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hello")},
+	}
+
+	for name, test := range map[string]struct {
+		reply    openaitest.Reply
+		is, isNt []error
+	}{
+		"context length": {
+			reply: openaitest.Error(http.StatusBadRequest, "context_length_exceeded", "This model's maximum context length is 128000 tokens."),
+			is:    []error{openai.ErrContextLengthExceeded, openai.ErrBadRequest},
+			isNt:  []error{openai.ErrRateLimited, openai.ErrServerError, openai.ErrConnection},
+		},
+		"rate limited": {
+			reply: openaitest.RateLimited(time.Second),
+			is:    []error{openai.ErrRateLimited},
+			isNt:  []error{openai.ErrInsufficientQuota},
+		},
+		"insufficient quota": {
+			reply: openaitest.InsufficientQuota(),
+			is:    []error{openai.ErrInsufficientQuota},
+			isNt:  []error{openai.ErrRateLimited},
+		},
+		"authentication": {
+			reply: openaitest.Error(http.StatusUnauthorized, "invalid_api_key", "Incorrect API key provided."),
+			is:    []error{openai.ErrAuthentication},
+		},
+		"server error": {
+			reply: openaitest.Error(http.StatusServiceUnavailable, "", "The server is overloaded."),
+			is:    []error{openai.ErrServerError},
+			isNt:  []error{openai.ErrBadRequest},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv.Enqueue(openaitest.RouteChatCompletionNew, test.reply)
+			_, err := client.Chat.Completions.New(ctx, params)
+			var apierr *openai.Error
+			if !errors.As(err, &apierr) {
+				t.Fatalf("expected an API error, got %v", err)
+			}
+			for _, target := range test.is {
+				if !errors.Is(err, target) {
+					t.Errorf("expected the error to match %v", target)
+				}
+			}
+			for _, target := range test.isNt {
+				if errors.Is(err, target) {
+					t.Errorf("expected the error not to match %v", target)
+				}
+			}
+		})
+	}
+}
+
+func TestConnectionErrors(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	srv.Handle(openaitest.RouteChatCompletionNew, func(r openaitest.Request) openaitest.Reply {
+		time.Sleep(100 * time.Millisecond)
+		return openaitest.JSON(map[string]any{})
+	})
+	params := openai.ChatCompletionNewParams{
+		Model:    openai.ChatModelGPT4o,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hello")},
+	}
+
+	client := srv.Client(option.WithMaxRetries(0), option.WithRequestTimeout(10*time.Millisecond))
+	_, err := client.Chat.Completions.New(context.Background(), params)
+	var timeout *openai.TimeoutError
+	if !errors.As(err, &timeout) || !errors.Is(err, openai.ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, openai.ErrServerError) {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+
+	closed := openaitest.NewServer()
+	closed.Close()
+	client = closed.Client(option.WithMaxRetries(0))
+	_, err = client.Chat.Completions.New(context.Background(), params)
+	var connErr *openai.ConnectionError
+	if !errors.As(err, &connErr) || !errors.Is(err, openai.ErrConnection) || errors.Is(err, openai.ErrTimeout) {
+		t.Fatalf("expected a connection error, got %v", err)
+	}
+
+	// The errors of a middleware which did not send the request are returned
+	// as is.
+	errMiddleware := errors.New("middleware error")
+	client = closed.Client(option.WithMaxRetries(0), option.WithMiddleware(func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+		return nil, errMiddleware
+	}))
+	_, err = client.Chat.Completions.New(context.Background(), params)
+	if err != errMiddleware || errors.Is(err, openai.ErrConnection) {
+		t.Fatalf("expected the error of the middleware, got %v", err)
+	}
+}
+
+func TestStreamErrorSentinels(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	srv.Enqueue(openaitest.RouteChatCompletionNew, openaitest.Stream(
+		map[string]any{"id": "chatcmpl-1", "choices": []any{map[string]any{"index": 0, "delta": map[string]any{"content": "Hel"}}}},
+		map[string]any{"error": map[string]any{"code": "server_error", "type": "server_error", "message": "The server had an error."}},
+	))
+	client := srv.Client()
+	stream := client.Chat.Completions.NewStreaming(context.Background(), openai.ChatCompletionNewParams{
+		Model:    openai.ChatModelGPT4o,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hello")},
+	})
+	for stream.Next() {
+	}
+	var streamErr *ssestream.StreamError
+	if err := stream.Err(); !errors.As(err, &streamErr) || !errors.Is(err, openai.ErrServerError) || streamErr.Code != "server_error" {
+		t.Fatalf("expected a server error in the stream, got %v", err)
+	}
+}
@@ -0,0 +1,981 @@
+// File generated from our OpenAPI spec by Stainless. See CONTRIBUTING.md for details.
+
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apijson"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apiquery"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/pagination"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/respjson"
+	"github.com/Nordlys-Labs/openai-go/v3/shared"
+	"github.com/Nordlys-Labs/openai-go/v3/shared/constant"
+)
+
+// EvalService contains methods and other services that help with interacting with
+// the openai API.
+//
+// Note, unlike clients, this service does not read variables from the environment
+// automatically. You should not instantiate this service directly, and instead use
+// the [NewEvalService] method instead.
+type EvalService struct {
+	Options []option.RequestOption
+	Runs    EvalRunService
+}
+
+// NewEvalService generates a new service that applies the given options to each
+// request. These options are applied after the parent client's options (if there
+// is one), and before any request-specific options.
+func NewEvalService(opts ...option.RequestOption) (r EvalService) {
+	r = EvalService{}
+	r.Options = opts
+	r.Runs = NewEvalRunService(opts...)
+	return
+}
+
+// Create the structure of an evaluation that can be used to test a model's
+// performance. An evaluation is a set of testing criteria and the config for a
+// data source, which dictates the schema of the data used in the evaluation. After
+// creating an evaluation, you can run it on different models and model parameters.
+// We support several types of graders and datasources. For more information, see
+// the [Evals guide](https://platform.openai.com/docs/guides/evals).
+func (r *EvalService) New(ctx context.Context, body EvalNewParams, opts ...option.RequestOption) (res *Eval, err error) {
+	opts = slices.Concat(r.Options, opts)
+	path := "evals"
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
+	return
+}
+
+// Get an evaluation by ID.
+func (r *EvalService) Get(ctx context.Context, evalID string, opts ...option.RequestOption) (res *Eval, err error) {
+	opts = slices.Concat(r.Options, opts)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s", evalID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
+	return
+}
+
+// Update certain properties of an evaluation.
+func (r *EvalService) Update(ctx context.Context, evalID string, body EvalUpdateParams, opts ...option.RequestOption) (res *Eval, err error) {
+	opts = slices.Concat(r.Options, opts)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s", evalID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
+	return
+}
+
+// List evaluations for a project.
+func (r *EvalService) List(ctx context.Context, query EvalListParams, opts ...option.RequestOption) (res *pagination.CursorPage[Eval], err error) {
+	var raw *http.Response
+	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
+	path := "evals"
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// List evaluations for a project.
+func (r *EvalService) ListAutoPaging(ctx context.Context, query EvalListParams, opts ...option.RequestOption) *pagination.CursorPageAutoPager[Eval] {
+	return pagination.NewCursorPageAutoPager(r.List(ctx, query, opts...))
+}
+
+// Delete an evaluation.
+func (r *EvalService) Delete(ctx context.Context, evalID string, opts ...option.RequestOption) (res *EvalDeleteResponse, err error) {
+	opts = slices.Concat(r.Options, opts)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s", evalID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodDelete, path, nil, &res, opts...)
+	return
+}
+
+// An Eval object with a data source config and testing criteria. An Eval
+// represents a task to be done for your LLM integration. Like:
+//
+// - Improve the quality of my chatbot
+// - See how well my chatbot handles customer support
+// - Check if o4-mini is better at my usecase than gpt-4o
+type Eval struct {
+	// Unique identifier for the evaluation.
+	ID string `json:"id,required"`
+	// The Unix timestamp (in seconds) for when the eval was created.
+	CreatedAt int64 `json:"created_at,required"`
+	// Configuration of data sources used in runs of the evaluation.
+	DataSourceConfig EvalDataSourceConfigUnion `json:"data_source_config,required"`
+	// Set of 16 key-value pairs that can be attached to an object. This can be useful
+	// for storing additional information about the object in a structured format, and
+	// querying for objects via API or the dashboard.
+	//
+	// Keys are strings with a maximum length of 64 characters. Values are strings with
+	// a maximum length of 512 characters.
+	Metadata shared.Metadata `json:"metadata,required"`
+	// The name of the evaluation.
+	Name string `json:"name,required"`
+	// The object type.
+	Object constant.Eval `json:"object,required"`
+	// A list of testing criteria.
+	TestingCriteria []EvalTestingCriterionUnion `json:"testing_criteria,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID               respjson.Field
+		CreatedAt        respjson.Field
+		DataSourceConfig respjson.Field
+		Metadata         respjson.Field
+		Name             respjson.Field
+		Object           respjson.Field
+		TestingCriteria  respjson.Field
+		ExtraFields      map[string]respjson.Field
+		raw              string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r Eval) RawJSON() string { return r.JSON.raw }
+func (r *Eval) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// EvalDataSourceConfigUnion contains all possible properties and values from
+// [EvalCustomDataSourceConfig], [EvalLogsDataSourceConfig],
+// [EvalStoredCompletionsDataSourceConfig].
+//
+// Use the [EvalDataSourceConfigUnion.AsAny] method to switch on the variant.
+//
+// Use the methods beginning with 'As' to cast the union to one of its variants.
+type EvalDataSourceConfigUnion struct {
+	Schema map[string]any `json:"schema"`
+	// Any of "custom", "logs", "stored_completions".
+	Type string `json:"type"`
+	// This field is from variant [EvalLogsDataSourceConfig],
+	// [EvalStoredCompletionsDataSourceConfig].
+	Metadata shared.Metadata `json:"metadata"`
+	JSON     struct {
+		Schema   respjson.Field
+		Type     respjson.Field
+		Metadata respjson.Field
+		raw      string
+	} `json:"-"`
+}
+
+// anyEvalDataSourceConfig is implemented by each variant of
+// [EvalDataSourceConfigUnion] to add type safety for the return type of
+// [EvalDataSourceConfigUnion.AsAny]
+type anyEvalDataSourceConfig interface {
+	implEvalDataSourceConfigUnion()
+}
+
+func (EvalCustomDataSourceConfig) implEvalDataSourceConfigUnion()            {}
+func (EvalLogsDataSourceConfig) implEvalDataSourceConfigUnion()              {}
+func (EvalStoredCompletionsDataSourceConfig) implEvalDataSourceConfigUnion() {}
+
+// Use the following switch statement to find the correct variant
+//
+//	switch variant := EvalDataSourceConfigUnion.AsAny().(type) {
+//	case openai.EvalCustomDataSourceConfig:
+//	case openai.EvalLogsDataSourceConfig:
+//	case openai.EvalStoredCompletionsDataSourceConfig:
+//	default:
+//	  fmt.Errorf("no variant present")
+//	}
+func (u EvalDataSourceConfigUnion) AsAny() anyEvalDataSourceConfig {
+	switch u.Type {
+	case "custom":
+		return u.AsCustom()
+	case "logs":
+		return u.AsLogs()
+	case "stored_completions":
+		return u.AsStoredCompletions()
+	}
+	return nil
+}
+
+func (u EvalDataSourceConfigUnion) AsCustom() (v EvalCustomDataSourceConfig) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalDataSourceConfigUnion) AsLogs() (v EvalLogsDataSourceConfig) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalDataSourceConfigUnion) AsStoredCompletions() (v EvalStoredCompletionsDataSourceConfig) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+// Returns the unmodified JSON received from the API
+func (u EvalDataSourceConfigUnion) RawJSON() string { return u.JSON.raw }
+
+func (r *EvalDataSourceConfigUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A CustomDataSourceConfig which specifies the schema of your `item` and
+// optionally `sample` namespaces. The response schema defines the shape of the
+// data that will be:
+//
+// - Used to define your testing criteria and
+// - What data is required when creating a run
+type EvalCustomDataSourceConfig struct {
+	// The json schema for the run data source items. Learn how to build JSON schemas
+	// [here](https://json-schema.org/).
+	Schema map[string]any `json:"schema,required"`
+	// The type of data source. Always `custom`.
+	Type constant.Custom `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Schema      respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalCustomDataSourceConfig) RawJSON() string { return r.JSON.raw }
+func (r *EvalCustomDataSourceConfig) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A LogsDataSourceConfig which specifies the metadata property of your logs query.
+// This is usually metadata like `usecase=chatbot` or `prompt-version=v2`, etc. The
+// schema returned by this data source config is used to defined what variables are
+// available in your evals. `item` and `sample` are both defined when using this
+// data source config.
+type EvalLogsDataSourceConfig struct {
+	// The json schema for the run data source items. Learn how to build JSON schemas
+	// [here](https://json-schema.org/).
+	Schema map[string]any `json:"schema,required"`
+	// The type of data source. Always `logs`.
+	Type constant.Logs `json:"type,required"`
+	// Set of 16 key-value pairs that can be attached to an object. This can be useful
+	// for storing additional information about the object in a structured format, and
+	// querying for objects via API or the dashboard.
+	//
+	// Keys are strings with a maximum length of 64 characters. Values are strings with
+	// a maximum length of 512 characters.
+	Metadata shared.Metadata `json:"metadata,nullable"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Schema      respjson.Field
+		Type        respjson.Field
+		Metadata    respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalLogsDataSourceConfig) RawJSON() string { return r.JSON.raw }
+func (r *EvalLogsDataSourceConfig) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A StoredCompletionsDataSourceConfig which specifies the metadata property of
+// your stored completions query. This is usually metadata like `usecase=chatbot`
+// or `prompt-version=v2`, etc. The schema returned by this data source config is
+// used to defined what variables are available in your evals. `item` and `sample`
+// are both defined when using this data source config.
+type EvalStoredCompletionsDataSourceConfig struct {
+	// The json schema for the run data source items. Learn how to build JSON schemas
+	// [here](https://json-schema.org/).
+	Schema map[string]any `json:"schema,required"`
+	// The type of data source. Always `stored_completions`.
+	Type constant.StoredCompletions `json:"type,required"`
+	// Set of 16 key-value pairs that can be attached to an object. This can be useful
+	// for storing additional information about the object in a structured format, and
+	// querying for objects via API or the dashboard.
+	//
+	// Keys are strings with a maximum length of 64 characters. Values are strings with
+	// a maximum length of 512 characters.
+	Metadata shared.Metadata `json:"metadata,nullable"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Schema      respjson.Field
+		Type        respjson.Field
+		Metadata    respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalStoredCompletionsDataSourceConfig) RawJSON() string { return r.JSON.raw }
+func (r *EvalStoredCompletionsDataSourceConfig) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// EvalTestingCriterionUnion contains all possible properties and values from
+// [LabelModelGrader], [StringCheckGrader], [EvalGraderTextSimilarity],
+// [EvalGraderPython], [EvalGraderScoreModel].
+//
+// Use the [EvalTestingCriterionUnion.AsAny] method to switch on the variant.
+//
+// Use the methods beginning with 'As' to cast the union to one of its variants.
+type EvalTestingCriterionUnion struct {
+	// This field is a union of [[]LabelModelGraderInput], [string], [string],
+	// [[]ScoreModelGraderInput]
+	Input EvalTestingCriterionUnionInput `json:"input"`
+	// This field is from variant [LabelModelGrader].
+	Labels []string `json:"labels"`
+	Model  string   `json:"model"`
+	Name   string   `json:"name"`
+	// This field is from variant [LabelModelGrader].
+	PassingLabels []string `json:"passing_labels"`
+	// Any of "label_model", "string_check", "text_similarity", "python",
+	// "score_model".
+	Type string `json:"type"`
+	// This field is from variant [StringCheckGrader].
+	Operation StringCheckGraderOperation `json:"operation"`
+	Reference string                     `json:"reference"`
+	// This field is from variant [EvalGraderTextSimilarity].
+	EvaluationMetric TextSimilarityGraderEvaluationMetric `json:"evaluation_metric"`
+	PassThreshold    float64                              `json:"pass_threshold"`
+	// This field is from variant [EvalGraderPython].
+	Source string `json:"source"`
+	// This field is from variant [EvalGraderPython].
+	ImageTag string `json:"image_tag"`
+	// This field is from variant [EvalGraderScoreModel].
+	Range []float64 `json:"range"`
+	// This field is from variant [EvalGraderScoreModel].
+	SamplingParams ScoreModelGraderSamplingParams `json:"sampling_params"`
+	JSON           struct {
+		Input            respjson.Field
+		Labels           respjson.Field
+		Model            respjson.Field
+		Name             respjson.Field
+		PassingLabels    respjson.Field
+		Type             respjson.Field
+		Operation        respjson.Field
+		Reference        respjson.Field
+		EvaluationMetric respjson.Field
+		PassThreshold    respjson.Field
+		Source           respjson.Field
+		ImageTag         respjson.Field
+		Range            respjson.Field
+		SamplingParams   respjson.Field
+		raw              string
+	} `json:"-"`
+}
+
+// anyEvalTestingCriterion is implemented by each variant of
+// [EvalTestingCriterionUnion] to add type safety for the return type of
+// [EvalTestingCriterionUnion.AsAny]
+type anyEvalTestingCriterion interface {
+	implEvalTestingCriterionUnion()
+}
+
+func (LabelModelGrader) implEvalTestingCriterionUnion()         {}
+func (StringCheckGrader) implEvalTestingCriterionUnion()        {}
+func (EvalGraderTextSimilarity) implEvalTestingCriterionUnion() {}
+func (EvalGraderPython) implEvalTestingCriterionUnion()         {}
+func (EvalGraderScoreModel) implEvalTestingCriterionUnion()     {}
+
+// Use the following switch statement to find the correct variant
+//
+//	switch variant := EvalTestingCriterionUnion.AsAny().(type) {
+//	case openai.LabelModelGrader:
+//	case openai.StringCheckGrader:
+//	case openai.EvalGraderTextSimilarity:
+//	case openai.EvalGraderPython:
+//	case openai.EvalGraderScoreModel:
+//	default:
+//	  fmt.Errorf("no variant present")
+//	}
+func (u EvalTestingCriterionUnion) AsAny() anyEvalTestingCriterion {
+	switch u.Type {
+	case "label_model":
+		return u.AsLabelModel()
+	case "string_check":
+		return u.AsStringCheck()
+	case "text_similarity":
+		return u.AsTextSimilarity()
+	case "python":
+		return u.AsPython()
+	case "score_model":
+		return u.AsScoreModel()
+	}
+	return nil
+}
+
+func (u EvalTestingCriterionUnion) AsLabelModel() (v LabelModelGrader) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalTestingCriterionUnion) AsStringCheck() (v StringCheckGrader) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalTestingCriterionUnion) AsTextSimilarity() (v EvalGraderTextSimilarity) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalTestingCriterionUnion) AsPython() (v EvalGraderPython) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalTestingCriterionUnion) AsScoreModel() (v EvalGraderScoreModel) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+// Returns the unmodified JSON received from the API
+func (u EvalTestingCriterionUnion) RawJSON() string { return u.JSON.raw }
+
+func (r *EvalTestingCriterionUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// EvalTestingCriterionUnionInput is an implicit subunion of
+// [EvalTestingCriterionUnion]. EvalTestingCriterionUnionInput provides convenient
+// access to the sub-properties of the union.
+//
+// For type safety it is recommended to directly use a variant of the
+// [EvalTestingCriterionUnion].
+//
+// If the underlying value is not a json object, one of the following properties
+// will be valid: OfString OfLabelModelGraderInputArray]
+type EvalTestingCriterionUnionInput struct {
+	// This field will be present if the value is a [string] instead of an object.
+	OfString string `json:",inline"`
+	// This field will be present if the value is a [[]LabelModelGraderInput] instead
+	// of an object.
+	OfLabelModelGraderInputArray []LabelModelGraderInput `json:",inline"`
+	JSON                         struct {
+		OfString                     respjson.Field
+		OfLabelModelGraderInputArray respjson.Field
+		raw                          string
+	} `json:"-"`
+}
+
+func (r *EvalTestingCriterionUnionInput) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A TextSimilarityGrader object which grades text based on similarity metrics.
+type EvalGraderTextSimilarity struct {
+	// The threshold for the score.
+	PassThreshold float64 `json:"pass_threshold,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		PassThreshold respjson.Field
+		ExtraFields   map[string]respjson.Field
+		raw           string
+	} `json:"-"`
+	TextSimilarityGrader
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalGraderTextSimilarity) RawJSON() string { return r.JSON.raw }
+func (r *EvalGraderTextSimilarity) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A PythonGrader object that runs a python script on the input.
+type EvalGraderPython struct {
+	// The threshold for the score.
+	PassThreshold float64 `json:"pass_threshold"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		PassThreshold respjson.Field
+		ExtraFields   map[string]respjson.Field
+		raw           string
+	} `json:"-"`
+	PythonGrader
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalGraderPython) RawJSON() string { return r.JSON.raw }
+func (r *EvalGraderPython) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A ScoreModelGrader object that uses a model to assign a score to the input.
+type EvalGraderScoreModel struct {
+	// The threshold for the score.
+	PassThreshold float64 `json:"pass_threshold"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		PassThreshold respjson.Field
+		ExtraFields   map[string]respjson.Field
+		raw           string
+	} `json:"-"`
+	ScoreModelGrader
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalGraderScoreModel) RawJSON() string { return r.JSON.raw }
+func (r *EvalGraderScoreModel) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalDeleteResponse struct {
+	Deleted bool   `json:"deleted,required"`
+	EvalID  string `json:"eval_id,required"`
+	Object  string `json:"object,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Deleted     respjson.Field
+		EvalID      respjson.Field
+		Object      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalDeleteResponse) RawJSON() string { return r.JSON.raw }
+func (r *EvalDeleteResponse) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalNewParams struct {
+	// The configuration for the data source used for the evaluation runs. Dictates the
+	// schema of the data used in the evaluation.
+	DataSourceConfig EvalNewParamsDataSourceConfigUnion `json:"data_source_config,omitzero,required"`
+	// A list of graders for all eval runs in this group. Graders can reference
+	// variables in the data source using double curly braces notation, like
+	// `{{item.variable_name}}`. To reference the model's output, use the `sample`
+	// namespace (ie, `{{sample.output_text}}`).
+	TestingCriteria []EvalNewParamsTestingCriterionUnion `json:"testing_criteria,omitzero,required"`
+	// The name of the evaluation.
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Set of 16 key-value pairs that can be attached to an object. This can be useful
+	// for storing additional information about the object in a structured format, and
+	// querying for objects via API or the dashboard.
+	//
+	// Keys are strings with a maximum length of 64 characters. Values are strings with
+	// a maximum length of 512 characters.
+	Metadata shared.Metadata `json:"metadata,omitzero"`
+	paramObj
+}
+
+func (r EvalNewParams) MarshalJSON() (data []byte, err error) {
+	type shadow EvalNewParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalNewParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type EvalNewParamsDataSourceConfigUnion struct {
+	OfCustom            *EvalNewParamsDataSourceConfigCustom            `json:",omitzero,inline"`
+	OfLogs              *EvalNewParamsDataSourceConfigLogs              `json:",omitzero,inline"`
+	OfStoredCompletions *EvalNewParamsDataSourceConfigStoredCompletions `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u EvalNewParamsDataSourceConfigUnion) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfCustom, u.OfLogs, u.OfStoredCompletions)
+}
+func (u *EvalNewParamsDataSourceConfigUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+func (u *EvalNewParamsDataSourceConfigUnion) asAny() any {
+	if !param.IsOmitted(u.OfCustom) {
+		return u.OfCustom
+	} else if !param.IsOmitted(u.OfLogs) {
+		return u.OfLogs
+	} else if !param.IsOmitted(u.OfStoredCompletions) {
+		return u.OfStoredCompletions
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalNewParamsDataSourceConfigUnion) GetItemSchema() map[string]any {
+	if vt := u.OfCustom; vt != nil {
+		return vt.ItemSchema
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalNewParamsDataSourceConfigUnion) GetIncludeSampleSchema() *bool {
+	if vt := u.OfCustom; vt != nil && vt.IncludeSampleSchema.Valid() {
+		return &vt.IncludeSampleSchema.Value
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalNewParamsDataSourceConfigUnion) GetType() *string {
+	if vt := u.OfCustom; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfLogs; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfStoredCompletions; vt != nil {
+		return (*string)(&vt.Type)
+	}
+	return nil
+}
+
+func init() {
+	apijson.RegisterUnion[EvalNewParamsDataSourceConfigUnion](
+		"type",
+		apijson.Discriminator[EvalNewParamsDataSourceConfigCustom]("custom"),
+		apijson.Discriminator[EvalNewParamsDataSourceConfigLogs]("logs"),
+		apijson.Discriminator[EvalNewParamsDataSourceConfigStoredCompletions]("stored_completions"),
+	)
+}
+
+// A CustomDataSourceConfig object that defines the schema for the data source used
+// for the evaluation runs. This schema is used to define the shape of the data
+// that will be:
+//
+// - Used to define your testing criteria and
+// - What data is required when creating a run
+//
+// The properties ItemSchema, Type are required.
+type EvalNewParamsDataSourceConfigCustom struct {
+	// The json schema for each row in the data source.
+	ItemSchema map[string]any `json:"item_schema,omitzero,required"`
+	// Whether the eval should expect you to populate the sample namespace (ie, by
+	// generating responses off of your data source)
+	IncludeSampleSchema param.Opt[bool] `json:"include_sample_schema,omitzero"`
+	// The type of data source. Always `custom`.
+	//
+	// This field can be elided, and will marshal its zero value as "custom".
+	Type constant.Custom `json:"type,required"`
+	paramObj
+}
+
+func (r EvalNewParamsDataSourceConfigCustom) MarshalJSON() (data []byte, err error) {
+	type shadow EvalNewParamsDataSourceConfigCustom
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalNewParamsDataSourceConfigCustom) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A data source config which specifies the metadata property of your logs query.
+// This is usually metadata like `usecase=chatbot` or `prompt-version=v2`, etc.
+//
+// The property Type is required.
+type EvalNewParamsDataSourceConfigLogs struct {
+	// Metadata filters for the logs data source.
+	Metadata map[string]any `json:"metadata,omitzero"`
+	// The type of data source. Always `logs`.
+	//
+	// This field can be elided, and will marshal its zero value as "logs".
+	Type constant.Logs `json:"type,required"`
+	paramObj
+}
+
+func (r EvalNewParamsDataSourceConfigLogs) MarshalJSON() (data []byte, err error) {
+	type shadow EvalNewParamsDataSourceConfigLogs
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalNewParamsDataSourceConfigLogs) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A data source config which specifies the metadata property of your stored
+// completions query. This is usually metadata like `usecase=chatbot` or
+// `prompt-version=v2`, etc.
+//
+// The property Type is required.
+type EvalNewParamsDataSourceConfigStoredCompletions struct {
+	// Metadata filters for the stored completions data source.
+	Metadata map[string]any `json:"metadata,omitzero"`
+	// The type of data source. Always `stored_completions`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "stored_completions".
+	Type constant.StoredCompletions `json:"type,required"`
+	paramObj
+}
+
+func (r EvalNewParamsDataSourceConfigStoredCompletions) MarshalJSON() (data []byte, err error) {
+	type shadow EvalNewParamsDataSourceConfigStoredCompletions
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalNewParamsDataSourceConfigStoredCompletions) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type EvalNewParamsTestingCriterionUnion struct {
+	OfLabelModel     *LabelModelGraderParam                       `json:",omitzero,inline"`
+	OfStringCheck    *StringCheckGraderParam                      `json:",omitzero,inline"`
+	OfTextSimilarity *EvalNewParamsTestingCriterionTextSimilarity `json:",omitzero,inline"`
+	OfPython         *EvalNewParamsTestingCriterionPython         `json:",omitzero,inline"`
+	OfScoreModel     *EvalNewParamsTestingCriterionScoreModel     `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u EvalNewParamsTestingCriterionUnion) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfLabelModel,
+		u.OfStringCheck,
+		u.OfTextSimilarity,
+		u.OfPython,
+		u.OfScoreModel)
+}
+func (u *EvalNewParamsTestingCriterionUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+func (u *EvalNewParamsTestingCriterionUnion) asAny() any {
+	if !param.IsOmitted(u.OfLabelModel) {
+		return u.OfLabelModel
+	} else if !param.IsOmitted(u.OfStringCheck) {
+		return u.OfStringCheck
+	} else if !param.IsOmitted(u.OfTextSimilarity) {
+		return u.OfTextSimilarity
+	} else if !param.IsOmitted(u.OfPython) {
+		return u.OfPython
+	} else if !param.IsOmitted(u.OfScoreModel) {
+		return u.OfScoreModel
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalNewParamsTestingCriterionUnion) GetPassThreshold() *float64 {
+	if vt := u.OfTextSimilarity; vt != nil {
+		return &vt.PassThreshold
+	} else if vt := u.OfPython; vt != nil && vt.PassThreshold.Valid() {
+		return &vt.PassThreshold.Value
+	} else if vt := u.OfScoreModel; vt != nil && vt.PassThreshold.Valid() {
+		return &vt.PassThreshold.Value
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalNewParamsTestingCriterionUnion) GetName() *string {
+	if vt := u.OfLabelModel; vt != nil {
+		return (*string)(&vt.Name)
+	} else if vt := u.OfStringCheck; vt != nil {
+		return (*string)(&vt.Name)
+	} else if vt := u.OfTextSimilarity; vt != nil {
+		return (*string)(&vt.Name)
+	} else if vt := u.OfPython; vt != nil {
+		return (*string)(&vt.Name)
+	} else if vt := u.OfScoreModel; vt != nil {
+		return (*string)(&vt.Name)
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalNewParamsTestingCriterionUnion) GetType() *string {
+	if vt := u.OfLabelModel; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfStringCheck; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfTextSimilarity; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfPython; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfScoreModel; vt != nil {
+		return (*string)(&vt.Type)
+	}
+	return nil
+}
+
+func init() {
+	apijson.RegisterUnion[EvalNewParamsTestingCriterionUnion](
+		"type",
+		apijson.Discriminator[LabelModelGraderParam]("label_model"),
+		apijson.Discriminator[StringCheckGraderParam]("string_check"),
+		apijson.Discriminator[EvalNewParamsTestingCriterionTextSimilarity]("text_similarity"),
+		apijson.Discriminator[EvalNewParamsTestingCriterionPython]("python"),
+		apijson.Discriminator[EvalNewParamsTestingCriterionScoreModel]("score_model"),
+	)
+}
+
+// A TextSimilarityGrader object which grades text based on similarity metrics.
+//
+// The properties EvaluationMetric, Input, Name, PassThreshold, Reference, Type are
+// required.
+type EvalNewParamsTestingCriterionTextSimilarity struct {
+	// The evaluation metric to use. One of `cosine`, `fuzzy_match`, `bleu`, `gleu`,
+	// `meteor`, `rouge_1`, `rouge_2`, `rouge_3`, `rouge_4`, `rouge_5`, or `rouge_l`.
+	//
+	// Any of "cosine", "fuzzy_match", "bleu", "gleu", "meteor", "rouge_1", "rouge_2",
+	// "rouge_3", "rouge_4", "rouge_5", "rouge_l".
+	EvaluationMetric TextSimilarityGraderEvaluationMetric `json:"evaluation_metric,omitzero,required"`
+	// The text being graded.
+	Input string `json:"input,required"`
+	// The name of the grader.
+	Name string `json:"name,required"`
+	// The threshold for the score.
+	PassThreshold float64 `json:"pass_threshold,required"`
+	// The text being graded against.
+	Reference string `json:"reference,required"`
+	// The type of grader.
+	//
+	// This field can be elided, and will marshal its zero value as "text_similarity".
+	Type constant.TextSimilarity `json:"type,required"`
+	paramObj
+}
+
+func (r EvalNewParamsTestingCriterionTextSimilarity) MarshalJSON() (data []byte, err error) {
+	type shadow EvalNewParamsTestingCriterionTextSimilarity
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalNewParamsTestingCriterionTextSimilarity) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A PythonGrader object that runs a python script on the input.
+//
+// The properties Name, Source, Type are required.
+type EvalNewParamsTestingCriterionPython struct {
+	// The name of the grader.
+	Name string `json:"name,required"`
+	// The source code of the python script.
+	Source string `json:"source,required"`
+	// The image tag to use for the python script.
+	ImageTag param.Opt[string] `json:"image_tag,omitzero"`
+	// The threshold for the score.
+	PassThreshold param.Opt[float64] `json:"pass_threshold,omitzero"`
+	// The object type, which is always `python`.
+	//
+	// This field can be elided, and will marshal its zero value as "python".
+	Type constant.Python `json:"type,required"`
+	paramObj
+}
+
+func (r EvalNewParamsTestingCriterionPython) MarshalJSON() (data []byte, err error) {
+	type shadow EvalNewParamsTestingCriterionPython
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalNewParamsTestingCriterionPython) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A ScoreModelGrader object that uses a model to assign a score to the input.
+//
+// The properties Input, Model, Name, Type are required.
+type EvalNewParamsTestingCriterionScoreModel struct {
+	// The input messages evaluated by the grader. Supports text, output text, input
+	// image, and input audio content blocks, and may include template strings.
+	Input []ScoreModelGraderInputParam `json:"input,omitzero,required"`
+	// The model to use for the evaluation.
+	Model string `json:"model,required"`
+	// The name of the grader.
+	Name string `json:"name,required"`
+	// The threshold for the score.
+	PassThreshold param.Opt[float64] `json:"pass_threshold,omitzero"`
+	// The range of the score. Defaults to `[0, 1]`.
+	Range []float64 `json:"range,omitzero"`
+	// The sampling parameters for the model.
+	SamplingParams ScoreModelGraderSamplingParamsParam `json:"sampling_params,omitzero"`
+	// The object type, which is always `score_model`.
+	//
+	// This field can be elided, and will marshal its zero value as "score_model".
+	Type constant.ScoreModel `json:"type,required"`
+	paramObj
+}
+
+func (r EvalNewParamsTestingCriterionScoreModel) MarshalJSON() (data []byte, err error) {
+	type shadow EvalNewParamsTestingCriterionScoreModel
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalNewParamsTestingCriterionScoreModel) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalUpdateParams struct {
+	// Rename the evaluation.
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Set of 16 key-value pairs that can be attached to an object. This can be useful
+	// for storing additional information about the object in a structured format, and
+	// querying for objects via API or the dashboard.
+	//
+	// Keys are strings with a maximum length of 64 characters. Values are strings with
+	// a maximum length of 512 characters.
+	Metadata shared.Metadata `json:"metadata,omitzero"`
+	paramObj
+}
+
+func (r EvalUpdateParams) MarshalJSON() (data []byte, err error) {
+	type shadow EvalUpdateParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalUpdateParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalListParams struct {
+	// Identifier for the last eval from the previous pagination request.
+	After param.Opt[string] `query:"after,omitzero" json:"-"`
+	// Number of evals to retrieve.
+	Limit param.Opt[int64] `query:"limit,omitzero" json:"-"`
+	// Sort order for evals by timestamp. Use `asc` for ascending order or `desc` for
+	// descending order.
+	//
+	// Any of "asc", "desc".
+	Order EvalListParamsOrder `query:"order,omitzero" json:"-"`
+	// Evals can be ordered by creation time or last updated time. Use `created_at`
+	// for creation time or `updated_at` for last updated time.
+	//
+	// Any of "created_at", "updated_at".
+	OrderBy EvalListParamsOrderBy `query:"order_by,omitzero" json:"-"`
+	paramObj
+}
+
+// URLQuery serializes [EvalListParams]'s query parameters as `url.Values`.
+func (r EvalListParams) URLQuery() (v url.Values, err error) {
+	return apiquery.MarshalWithSettings(r, apiquery.QuerySettings{
+		ArrayFormat:  apiquery.ArrayQueryFormatBrackets,
+		NestedFormat: apiquery.NestedQueryFormatBrackets,
+	})
+}
+
+// Sort order for evals by timestamp. Use `asc` for ascending order or `desc` for
+// descending order.
+type EvalListParamsOrder string
+
+const (
+	EvalListParamsOrderAsc  EvalListParamsOrder = "asc"
+	EvalListParamsOrderDesc EvalListParamsOrder = "desc"
+)
+
+// Evals can be ordered by creation time or last updated time. Use `created_at`
+// for creation time or `updated_at` for last updated time.
+type EvalListParamsOrderBy string
+
+const (
+	EvalListParamsOrderByCreatedAt EvalListParamsOrderBy = "created_at"
+	EvalListParamsOrderByUpdatedAt EvalListParamsOrderBy = "updated_at"
+)
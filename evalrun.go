@@ -0,0 +1,1501 @@
+// File generated from our OpenAPI spec by Stainless. See CONTRIBUTING.md for details.
+
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apijson"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apiquery"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/pagination"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/respjson"
+	"github.com/Nordlys-Labs/openai-go/v3/shared"
+	"github.com/Nordlys-Labs/openai-go/v3/shared/constant"
+)
+
+// EvalRunService contains methods and other services that help with interacting
+// with the openai API.
+//
+// Note, unlike clients, this service does not read variables from the environment
+// automatically. You should not instantiate this service directly, and instead use
+// the [NewEvalRunService] method instead.
+type EvalRunService struct {
+	Options     []option.RequestOption
+	OutputItems EvalRunOutputItemService
+}
+
+// NewEvalRunService generates a new service that applies the given options to each
+// request. These options are applied after the parent client's options (if there
+// is one), and before any request-specific options.
+func NewEvalRunService(opts ...option.RequestOption) (r EvalRunService) {
+	r = EvalRunService{}
+	r.Options = opts
+	r.OutputItems = NewEvalRunOutputItemService(opts...)
+	return
+}
+
+// Kicks off a new run for a given evaluation, specifying the data source, and what
+// model configuration to use to test. The datasource will be validated against the
+// schema specified in the config of the evaluation.
+func (r *EvalRunService) New(ctx context.Context, evalID string, body EvalRunNewParams, opts ...option.RequestOption) (res *EvalRun, err error) {
+	opts = slices.Concat(r.Options, opts)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s/runs", evalID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
+	return
+}
+
+// Get an evaluation run by ID.
+func (r *EvalRunService) Get(ctx context.Context, evalID string, runID string, opts ...option.RequestOption) (res *EvalRun, err error) {
+	opts = slices.Concat(r.Options, opts)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	if runID == "" {
+		err = errors.New("missing required run_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s/runs/%s", evalID, runID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
+	return
+}
+
+// Get a list of runs for an evaluation.
+func (r *EvalRunService) List(ctx context.Context, evalID string, query EvalRunListParams, opts ...option.RequestOption) (res *pagination.CursorPage[EvalRun], err error) {
+	var raw *http.Response
+	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s/runs", evalID)
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Get a list of runs for an evaluation.
+func (r *EvalRunService) ListAutoPaging(ctx context.Context, evalID string, query EvalRunListParams, opts ...option.RequestOption) *pagination.CursorPageAutoPager[EvalRun] {
+	return pagination.NewCursorPageAutoPager(r.List(ctx, evalID, query, opts...))
+}
+
+// Delete an eval run.
+func (r *EvalRunService) Delete(ctx context.Context, evalID string, runID string, opts ...option.RequestOption) (res *EvalRunDeleteResponse, err error) {
+	opts = slices.Concat(r.Options, opts)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	if runID == "" {
+		err = errors.New("missing required run_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s/runs/%s", evalID, runID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodDelete, path, nil, &res, opts...)
+	return
+}
+
+// Cancel an ongoing evaluation run.
+func (r *EvalRunService) Cancel(ctx context.Context, evalID string, runID string, opts ...option.RequestOption) (res *EvalRun, err error) {
+	opts = slices.Concat(r.Options, opts)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	if runID == "" {
+		err = errors.New("missing required run_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s/runs/%s", evalID, runID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, nil, &res, opts...)
+	return
+}
+
+// An object representing an error response from the Eval API.
+type EvalAPIError struct {
+	// The error code.
+	Code string `json:"code,required"`
+	// The error message.
+	Message string `json:"message,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Code        respjson.Field
+		Message     respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalAPIError) RawJSON() string { return r.JSON.raw }
+func (r *EvalAPIError) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A schema representing an evaluation run.
+type EvalRun struct {
+	// Unique identifier for the evaluation run.
+	ID string `json:"id,required"`
+	// Unix timestamp (in seconds) when the evaluation run was created.
+	CreatedAt int64 `json:"created_at,required"`
+	// Information about the run's data source.
+	DataSource EvalRunDataSourceUnion `json:"data_source,required"`
+	// An object representing an error response from the Eval API.
+	Error EvalAPIError `json:"error,required"`
+	// The identifier of the associated evaluation.
+	EvalID string `json:"eval_id,required"`
+	// Set of 16 key-value pairs that can be attached to an object. This can be useful
+	// for storing additional information about the object in a structured format, and
+	// querying for objects via API or the dashboard.
+	//
+	// Keys are strings with a maximum length of 64 characters. Values are strings with
+	// a maximum length of 512 characters.
+	Metadata shared.Metadata `json:"metadata,required"`
+	// The model that is evaluated, if applicable.
+	Model string `json:"model,required"`
+	// The name of the evaluation run.
+	Name string `json:"name,required"`
+	// The type of the object. Always "eval.run".
+	Object constant.EvalRun `json:"object,required"`
+	// Usage statistics for each model during the evaluation run.
+	PerModelUsage []EvalRunPerModelUsage `json:"per_model_usage,required"`
+	// Results per testing criteria applied during the evaluation run.
+	PerTestingCriteriaResults []EvalRunPerTestingCriteriaResult `json:"per_testing_criteria_results,required"`
+	// The URL to the rendered evaluation run report on the UI dashboard.
+	ReportURL string `json:"report_url,required"`
+	// Counters summarizing the outcomes of the evaluation run.
+	ResultCounts EvalRunResultCounts `json:"result_counts,required"`
+	// The status of the evaluation run.
+	//
+	// Any of "queued", "in_progress", "completed", "canceled", "failed".
+	Status EvalRunStatus `json:"status,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID                        respjson.Field
+		CreatedAt                 respjson.Field
+		DataSource                respjson.Field
+		Error                     respjson.Field
+		EvalID                    respjson.Field
+		Metadata                  respjson.Field
+		Model                     respjson.Field
+		Name                      respjson.Field
+		Object                    respjson.Field
+		PerModelUsage             respjson.Field
+		PerTestingCriteriaResults respjson.Field
+		ReportURL                 respjson.Field
+		ResultCounts              respjson.Field
+		Status                    respjson.Field
+		ExtraFields               map[string]respjson.Field
+		raw                       string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRun) RawJSON() string { return r.JSON.raw }
+func (r *EvalRun) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The status of the evaluation run.
+type EvalRunStatus string
+
+const (
+	EvalRunStatusQueued     EvalRunStatus = "queued"
+	EvalRunStatusInProgress EvalRunStatus = "in_progress"
+	EvalRunStatusCompleted  EvalRunStatus = "completed"
+	EvalRunStatusCanceled   EvalRunStatus = "canceled"
+	EvalRunStatusFailed     EvalRunStatus = "failed"
+)
+
+type EvalRunPerModelUsage struct {
+	// The number of tokens retrieved from cache.
+	CachedTokens int64 `json:"cached_tokens,required"`
+	// The number of completion tokens generated.
+	CompletionTokens int64 `json:"completion_tokens,required"`
+	// The number of invocations.
+	InvocationCount int64 `json:"invocation_count,required"`
+	// The name of the model.
+	ModelName string `json:"model_name,required"`
+	// The number of prompt tokens used.
+	PromptTokens int64 `json:"prompt_tokens,required"`
+	// The total number of tokens used.
+	TotalTokens int64 `json:"total_tokens,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		CachedTokens     respjson.Field
+		CompletionTokens respjson.Field
+		InvocationCount  respjson.Field
+		ModelName        respjson.Field
+		PromptTokens     respjson.Field
+		TotalTokens      respjson.Field
+		ExtraFields      map[string]respjson.Field
+		raw              string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunPerModelUsage) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunPerModelUsage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunPerTestingCriteriaResult struct {
+	// Number of tests failed for this criteria.
+	Failed int64 `json:"failed,required"`
+	// Number of tests passed for this criteria.
+	Passed int64 `json:"passed,required"`
+	// A description of the testing criteria.
+	TestingCriteria string `json:"testing_criteria,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Failed          respjson.Field
+		Passed          respjson.Field
+		TestingCriteria respjson.Field
+		ExtraFields     map[string]respjson.Field
+		raw             string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunPerTestingCriteriaResult) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunPerTestingCriteriaResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Counters summarizing the outcomes of the evaluation run.
+type EvalRunResultCounts struct {
+	// Number of output items that resulted in an error.
+	Errored int64 `json:"errored,required"`
+	// Number of output items that failed to pass the evaluation.
+	Failed int64 `json:"failed,required"`
+	// Number of output items that passed the evaluation.
+	Passed int64 `json:"passed,required"`
+	// Total number of executed output items.
+	Total int64 `json:"total,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Errored     respjson.Field
+		Failed      respjson.Field
+		Passed      respjson.Field
+		Total       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunResultCounts) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunResultCounts) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// EvalRunDataSourceUnion contains all possible properties and values from
+// [CreateEvalJSONLRunDataSource], [CreateEvalCompletionsRunDataSource],
+// [CreateEvalResponsesRunDataSource].
+//
+// Use the [EvalRunDataSourceUnion.AsAny] method to switch on the variant.
+//
+// Use the methods beginning with 'As' to cast the union to one of its variants.
+type EvalRunDataSourceUnion struct {
+	Source EvalRunSourceUnion `json:"source"`
+	// Any of "jsonl", "completions", "responses".
+	Type          string                    `json:"type"`
+	InputMessages EvalRunInputMessagesUnion `json:"input_messages"`
+	Model         string                    `json:"model"`
+	// This field is from variant [CreateEvalCompletionsRunDataSource],
+	// [CreateEvalResponsesRunDataSource].
+	SamplingParams EvalRunSamplingParams `json:"sampling_params"`
+	JSON           struct {
+		Source         respjson.Field
+		Type           respjson.Field
+		InputMessages  respjson.Field
+		Model          respjson.Field
+		SamplingParams respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+// anyEvalRunDataSource is implemented by each variant of [EvalRunDataSourceUnion]
+// to add type safety for the return type of [EvalRunDataSourceUnion.AsAny]
+type anyEvalRunDataSource interface {
+	implEvalRunDataSourceUnion()
+}
+
+func (CreateEvalJSONLRunDataSource) implEvalRunDataSourceUnion()       {}
+func (CreateEvalCompletionsRunDataSource) implEvalRunDataSourceUnion() {}
+func (CreateEvalResponsesRunDataSource) implEvalRunDataSourceUnion()   {}
+
+// Use the following switch statement to find the correct variant
+//
+//	switch variant := EvalRunDataSourceUnion.AsAny().(type) {
+//	case openai.CreateEvalJSONLRunDataSource:
+//	case openai.CreateEvalCompletionsRunDataSource:
+//	case openai.CreateEvalResponsesRunDataSource:
+//	default:
+//	  fmt.Errorf("no variant present")
+//	}
+func (u EvalRunDataSourceUnion) AsAny() anyEvalRunDataSource {
+	switch u.Type {
+	case "jsonl":
+		return u.AsJSONL()
+	case "completions":
+		return u.AsCompletions()
+	case "responses":
+		return u.AsResponses()
+	}
+	return nil
+}
+
+func (u EvalRunDataSourceUnion) AsJSONL() (v CreateEvalJSONLRunDataSource) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalRunDataSourceUnion) AsCompletions() (v CreateEvalCompletionsRunDataSource) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalRunDataSourceUnion) AsResponses() (v CreateEvalResponsesRunDataSource) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+// Returns the unmodified JSON received from the API
+func (u EvalRunDataSourceUnion) RawJSON() string { return u.JSON.raw }
+
+func (r *EvalRunDataSourceUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A JsonlRunDataSource object with that specifies a JSONL file that matches the
+// eval
+type CreateEvalJSONLRunDataSource struct {
+	// Determines what populates the `item` namespace in the data source.
+	Source EvalRunSourceUnion `json:"source,required"`
+	// The type of data source. Always `jsonl`.
+	Type constant.Jsonl `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Source      respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r CreateEvalJSONLRunDataSource) RawJSON() string { return r.JSON.raw }
+func (r *CreateEvalJSONLRunDataSource) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A CompletionsRunDataSource object describing a model sampling configuration.
+type CreateEvalCompletionsRunDataSource struct {
+	// Determines what populates the `item` namespace in this run's data source.
+	Source EvalRunSourceUnion `json:"source,required"`
+	// The type of run data source. Always `completions`.
+	Type constant.Completions `json:"type,required"`
+	// Used when sampling from a model. Dictates the structure of the messages passed
+	// into the model. Can either be a reference to a prebuilt trajectory (ie,
+	// `item.input_trajectory`), or a template with variable references to the `item`
+	// namespace.
+	InputMessages EvalRunInputMessagesUnion `json:"input_messages"`
+	// The name of the model to use for generating completions (e.g. "o3-mini").
+	Model string `json:"model"`
+	// The sampling parameters used when generating completions.
+	SamplingParams EvalRunSamplingParams `json:"sampling_params"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Source         respjson.Field
+		Type           respjson.Field
+		InputMessages  respjson.Field
+		Model          respjson.Field
+		SamplingParams respjson.Field
+		ExtraFields    map[string]respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r CreateEvalCompletionsRunDataSource) RawJSON() string { return r.JSON.raw }
+func (r *CreateEvalCompletionsRunDataSource) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A ResponsesRunDataSource object describing a model sampling configuration.
+type CreateEvalResponsesRunDataSource struct {
+	// Determines what populates the `item` namespace in this run's data source.
+	Source EvalRunSourceUnion `json:"source,required"`
+	// The type of run data source. Always `responses`.
+	Type constant.Responses `json:"type,required"`
+	// Used when sampling from a model. Dictates the structure of the messages passed
+	// into the model. Can either be a reference to a prebuilt trajectory (ie,
+	// `item.input_trajectory`), or a template with variable references to the `item`
+	// namespace.
+	InputMessages EvalRunInputMessagesUnion `json:"input_messages"`
+	// The name of the model to use for generating completions (e.g. "o3-mini").
+	Model string `json:"model"`
+	// The sampling parameters used when generating responses.
+	SamplingParams EvalRunSamplingParams `json:"sampling_params"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Source         respjson.Field
+		Type           respjson.Field
+		InputMessages  respjson.Field
+		Model          respjson.Field
+		SamplingParams respjson.Field
+		ExtraFields    map[string]respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r CreateEvalResponsesRunDataSource) RawJSON() string { return r.JSON.raw }
+func (r *CreateEvalResponsesRunDataSource) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// EvalRunSourceUnion contains all possible properties and values from
+// [EvalRunFileContentSource], [EvalRunFileIDSource],
+// [EvalRunStoredCompletionsSource], [EvalRunResponsesSource].
+//
+// Use the [EvalRunSourceUnion.AsAny] method to switch on the variant.
+//
+// Use the methods beginning with 'As' to cast the union to one of its variants.
+type EvalRunSourceUnion struct {
+	// This field is from variant [EvalRunFileContentSource].
+	Content []EvalRunFileContentSourceContent `json:"content"`
+	// Any of "file_content", "file_id", "stored_completions", "responses".
+	Type string `json:"type"`
+	// This field is from variant [EvalRunFileIDSource].
+	ID            string `json:"id"`
+	CreatedAfter  int64  `json:"created_after"`
+	CreatedBefore int64  `json:"created_before"`
+	// This field is from variant [EvalRunStoredCompletionsSource].
+	Limit int64 `json:"limit"`
+	// This field is a union of [shared.Metadata], [map[string]any]
+	Metadata map[string]any `json:"metadata"`
+	Model    string         `json:"model"`
+	// This field is from variant [EvalRunResponsesSource].
+	InstructionsSearch string `json:"instructions_search"`
+	// This field is from variant [EvalRunResponsesSource].
+	ReasoningEffort shared.ReasoningEffort `json:"reasoning_effort"`
+	// This field is from variant [EvalRunResponsesSource].
+	Temperature float64 `json:"temperature"`
+	// This field is from variant [EvalRunResponsesSource].
+	Tools []string `json:"tools"`
+	// This field is from variant [EvalRunResponsesSource].
+	TopP float64 `json:"top_p"`
+	// This field is from variant [EvalRunResponsesSource].
+	Users []string `json:"users"`
+	JSON  struct {
+		Content            respjson.Field
+		Type               respjson.Field
+		ID                 respjson.Field
+		CreatedAfter       respjson.Field
+		CreatedBefore      respjson.Field
+		Limit              respjson.Field
+		Metadata           respjson.Field
+		Model              respjson.Field
+		InstructionsSearch respjson.Field
+		ReasoningEffort    respjson.Field
+		Temperature        respjson.Field
+		Tools              respjson.Field
+		TopP               respjson.Field
+		Users              respjson.Field
+		raw                string
+	} `json:"-"`
+}
+
+// anyEvalRunSource is implemented by each variant of [EvalRunSourceUnion] to add
+// type safety for the return type of [EvalRunSourceUnion.AsAny]
+type anyEvalRunSource interface {
+	implEvalRunSourceUnion()
+}
+
+func (EvalRunFileContentSource) implEvalRunSourceUnion()       {}
+func (EvalRunFileIDSource) implEvalRunSourceUnion()            {}
+func (EvalRunStoredCompletionsSource) implEvalRunSourceUnion() {}
+func (EvalRunResponsesSource) implEvalRunSourceUnion()         {}
+
+// Use the following switch statement to find the correct variant
+//
+//	switch variant := EvalRunSourceUnion.AsAny().(type) {
+//	case openai.EvalRunFileContentSource:
+//	case openai.EvalRunFileIDSource:
+//	case openai.EvalRunStoredCompletionsSource:
+//	case openai.EvalRunResponsesSource:
+//	default:
+//	  fmt.Errorf("no variant present")
+//	}
+func (u EvalRunSourceUnion) AsAny() anyEvalRunSource {
+	switch u.Type {
+	case "file_content":
+		return u.AsFileContent()
+	case "file_id":
+		return u.AsFileID()
+	case "stored_completions":
+		return u.AsStoredCompletions()
+	case "responses":
+		return u.AsResponses()
+	}
+	return nil
+}
+
+func (u EvalRunSourceUnion) AsFileContent() (v EvalRunFileContentSource) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalRunSourceUnion) AsFileID() (v EvalRunFileIDSource) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalRunSourceUnion) AsStoredCompletions() (v EvalRunStoredCompletionsSource) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalRunSourceUnion) AsResponses() (v EvalRunResponsesSource) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+// Returns the unmodified JSON received from the API
+func (u EvalRunSourceUnion) RawJSON() string { return u.JSON.raw }
+
+func (r *EvalRunSourceUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunFileContentSource struct {
+	// The content of the jsonl file.
+	Content []EvalRunFileContentSourceContent `json:"content,required"`
+	// The type of jsonl source. Always `file_content`.
+	Type constant.FileContent `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Content     respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunFileContentSource) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunFileContentSource) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunFileContentSourceContent struct {
+	Item   map[string]any `json:"item,required"`
+	Sample map[string]any `json:"sample"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Item        respjson.Field
+		Sample      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunFileContentSourceContent) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunFileContentSourceContent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunFileIDSource struct {
+	// The identifier of the file.
+	ID string `json:"id,required"`
+	// The type of jsonl source. Always `file_id`.
+	Type constant.FileID `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID          respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunFileIDSource) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunFileIDSource) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A StoredCompletionsRunDataSource configuration describing a set of filters
+type EvalRunStoredCompletionsSource struct {
+	// The type of source. Always `stored_completions`.
+	Type constant.StoredCompletions `json:"type,required"`
+	// An optional Unix timestamp to filter items created after this time.
+	CreatedAfter int64 `json:"created_after,nullable"`
+	// An optional Unix timestamp to filter items created before this time.
+	CreatedBefore int64 `json:"created_before,nullable"`
+	// An optional maximum number of items to return.
+	Limit int64 `json:"limit,nullable"`
+	// Set of 16 key-value pairs that can be attached to an object. This can be useful
+	// for storing additional information about the object in a structured format, and
+	// querying for objects via API or the dashboard.
+	//
+	// Keys are strings with a maximum length of 64 characters. Values are strings with
+	// a maximum length of 512 characters.
+	Metadata shared.Metadata `json:"metadata,nullable"`
+	// An optional model to filter by (e.g., 'gpt-4o').
+	Model string `json:"model,nullable"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Type          respjson.Field
+		CreatedAfter  respjson.Field
+		CreatedBefore respjson.Field
+		Limit         respjson.Field
+		Metadata      respjson.Field
+		Model         respjson.Field
+		ExtraFields   map[string]respjson.Field
+		raw           string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunStoredCompletionsSource) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunStoredCompletionsSource) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A EvalResponsesSource object describing a run data source configuration.
+type EvalRunResponsesSource struct {
+	// The type of run data source. Always `responses`.
+	Type constant.Responses `json:"type,required"`
+	// Only include items created after this timestamp (inclusive). This is a query
+	// parameter used to select responses.
+	CreatedAfter int64 `json:"created_after,nullable"`
+	// Only include items created before this timestamp (inclusive). This is a query
+	// parameter used to select responses.
+	CreatedBefore int64 `json:"created_before,nullable"`
+	// Optional string to search the 'instructions' field. This is a query parameter
+	// used to select responses.
+	InstructionsSearch string `json:"instructions_search,nullable"`
+	// Metadata filter for the responses. This is a query parameter used to select
+	// responses.
+	Metadata map[string]any `json:"metadata,nullable"`
+	// The name of the model to find responses for. This is a query parameter used to
+	// select responses.
+	Model string `json:"model,nullable"`
+	// Optional reasoning effort parameter. This is a query parameter used to select
+	// responses.
+	//
+	// Any of "none", "minimal", "low", "medium", "high", "xhigh".
+	ReasoningEffort shared.ReasoningEffort `json:"reasoning_effort,nullable"`
+	// Sampling temperature. This is a query parameter used to select responses.
+	Temperature float64 `json:"temperature,nullable"`
+	// List of tool names. This is a query parameter used to select responses.
+	Tools []string `json:"tools,nullable"`
+	// Nucleus sampling parameter. This is a query parameter used to select responses.
+	TopP float64 `json:"top_p,nullable"`
+	// List of user identifiers. This is a query parameter used to select responses.
+	Users []string `json:"users,nullable"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Type               respjson.Field
+		CreatedAfter       respjson.Field
+		CreatedBefore      respjson.Field
+		InstructionsSearch respjson.Field
+		Metadata           respjson.Field
+		Model              respjson.Field
+		ReasoningEffort    respjson.Field
+		Temperature        respjson.Field
+		Tools              respjson.Field
+		TopP               respjson.Field
+		Users              respjson.Field
+		ExtraFields        map[string]respjson.Field
+		raw                string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunResponsesSource) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunResponsesSource) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// EvalRunInputMessagesUnion contains all possible properties and values from
+// [EvalRunInputMessagesTemplate], [EvalRunInputMessagesItemReference].
+//
+// Use the [EvalRunInputMessagesUnion.AsAny] method to switch on the variant.
+//
+// Use the methods beginning with 'As' to cast the union to one of its variants.
+type EvalRunInputMessagesUnion struct {
+	// This field is from variant [EvalRunInputMessagesTemplate].
+	Template []LabelModelGraderInput `json:"template"`
+	// Any of "template", "item_reference".
+	Type string `json:"type"`
+	// This field is from variant [EvalRunInputMessagesItemReference].
+	ItemReference string `json:"item_reference"`
+	JSON          struct {
+		Template      respjson.Field
+		Type          respjson.Field
+		ItemReference respjson.Field
+		raw           string
+	} `json:"-"`
+}
+
+// anyEvalRunInputMessages is implemented by each variant of
+// [EvalRunInputMessagesUnion] to add type safety for the return type of
+// [EvalRunInputMessagesUnion.AsAny]
+type anyEvalRunInputMessages interface {
+	implEvalRunInputMessagesUnion()
+}
+
+func (EvalRunInputMessagesTemplate) implEvalRunInputMessagesUnion()      {}
+func (EvalRunInputMessagesItemReference) implEvalRunInputMessagesUnion() {}
+
+// Use the following switch statement to find the correct variant
+//
+//	switch variant := EvalRunInputMessagesUnion.AsAny().(type) {
+//	case openai.EvalRunInputMessagesTemplate:
+//	case openai.EvalRunInputMessagesItemReference:
+//	default:
+//	  fmt.Errorf("no variant present")
+//	}
+func (u EvalRunInputMessagesUnion) AsAny() anyEvalRunInputMessages {
+	switch u.Type {
+	case "template":
+		return u.AsTemplate()
+	case "item_reference":
+		return u.AsItemReference()
+	}
+	return nil
+}
+
+func (u EvalRunInputMessagesUnion) AsTemplate() (v EvalRunInputMessagesTemplate) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u EvalRunInputMessagesUnion) AsItemReference() (v EvalRunInputMessagesItemReference) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+// Returns the unmodified JSON received from the API
+func (u EvalRunInputMessagesUnion) RawJSON() string { return u.JSON.raw }
+
+func (r *EvalRunInputMessagesUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunInputMessagesTemplate struct {
+	// A list of chat messages forming the prompt or context. May include variable
+	// references to the `item` namespace, ie {{item.name}}.
+	Template []LabelModelGraderInput `json:"template,required"`
+	// The type of input messages. Always `template`.
+	Type constant.Template `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Template    respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunInputMessagesTemplate) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunInputMessagesTemplate) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunInputMessagesItemReference struct {
+	// A reference to a variable in the `item` namespace. Ie, "item.input_trajectory"
+	ItemReference string `json:"item_reference,required"`
+	// The type of input messages. Always `item_reference`.
+	Type constant.ItemReference `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ItemReference respjson.Field
+		Type          respjson.Field
+		ExtraFields   map[string]respjson.Field
+		raw           string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunInputMessagesItemReference) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunInputMessagesItemReference) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunSamplingParams struct {
+	// The maximum number of tokens in the generated output.
+	MaxCompletionTokens int64 `json:"max_completion_tokens"`
+	// Constrains effort on reasoning for
+	// [reasoning models](https://platform.openai.com/docs/guides/reasoning).
+	//
+	// Any of "none", "minimal", "low", "medium", "high", "xhigh".
+	ReasoningEffort shared.ReasoningEffort `json:"reasoning_effort,nullable"`
+	// A seed value to initialize the randomness, during sampling.
+	Seed int64 `json:"seed"`
+	// A higher temperature increases randomness in the outputs.
+	Temperature float64 `json:"temperature"`
+	// An alternative to temperature for nucleus sampling; 1.0 includes all tokens.
+	TopP float64 `json:"top_p"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		MaxCompletionTokens respjson.Field
+		ReasoningEffort     respjson.Field
+		Seed                respjson.Field
+		Temperature         respjson.Field
+		TopP                respjson.Field
+		ExtraFields         map[string]respjson.Field
+		raw                 string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunSamplingParams) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunSamplingParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunDeleteResponse struct {
+	Deleted bool   `json:"deleted"`
+	Object  string `json:"object"`
+	RunID   string `json:"run_id"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Deleted     respjson.Field
+		Object      respjson.Field
+		RunID       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunDeleteResponse) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunDeleteResponse) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunNewParams struct {
+	// Details about the run's data source.
+	DataSource EvalRunNewParamsDataSourceUnion `json:"data_source,omitzero,required"`
+	// The name of the run.
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Set of 16 key-value pairs that can be attached to an object. This can be useful
+	// for storing additional information about the object in a structured format, and
+	// querying for objects via API or the dashboard.
+	//
+	// Keys are strings with a maximum length of 64 characters. Values are strings with
+	// a maximum length of 512 characters.
+	Metadata shared.Metadata `json:"metadata,omitzero"`
+	paramObj
+}
+
+func (r EvalRunNewParams) MarshalJSON() (data []byte, err error) {
+	type shadow EvalRunNewParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalRunNewParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type EvalRunNewParamsDataSourceUnion struct {
+	OfJSONL       *CreateEvalJSONLRunDataSourceParam       `json:",omitzero,inline"`
+	OfCompletions *CreateEvalCompletionsRunDataSourceParam `json:",omitzero,inline"`
+	OfResponses   *CreateEvalResponsesRunDataSourceParam   `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u EvalRunNewParamsDataSourceUnion) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfJSONL, u.OfCompletions, u.OfResponses)
+}
+func (u *EvalRunNewParamsDataSourceUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+func (u *EvalRunNewParamsDataSourceUnion) asAny() any {
+	if !param.IsOmitted(u.OfJSONL) {
+		return u.OfJSONL
+	} else if !param.IsOmitted(u.OfCompletions) {
+		return u.OfCompletions
+	} else if !param.IsOmitted(u.OfResponses) {
+		return u.OfResponses
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalRunNewParamsDataSourceUnion) GetType() *string {
+	if vt := u.OfJSONL; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfCompletions; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfResponses; vt != nil {
+		return (*string)(&vt.Type)
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalRunNewParamsDataSourceUnion) GetModel() *string {
+	if vt := u.OfCompletions; vt != nil && vt.Model.Valid() {
+		return &vt.Model.Value
+	} else if vt := u.OfResponses; vt != nil && vt.Model.Valid() {
+		return &vt.Model.Value
+	}
+	return nil
+}
+
+func init() {
+	apijson.RegisterUnion[EvalRunNewParamsDataSourceUnion](
+		"type",
+		apijson.Discriminator[CreateEvalJSONLRunDataSourceParam]("jsonl"),
+		apijson.Discriminator[CreateEvalCompletionsRunDataSourceParam]("completions"),
+		apijson.Discriminator[CreateEvalResponsesRunDataSourceParam]("responses"),
+	)
+}
+
+// A JsonlRunDataSource object with that specifies a JSONL file that matches the
+// eval
+//
+// The properties Source, Type are required.
+type CreateEvalJSONLRunDataSourceParam struct {
+	// Determines what populates the `item` namespace in the data source.
+	Source CreateEvalJSONLRunDataSourceSourceUnionParam `json:"source,omitzero,required"`
+	// The type of data source. Always `jsonl`.
+	//
+	// This field can be elided, and will marshal its zero value as "jsonl".
+	Type constant.Jsonl `json:"type,required"`
+	paramObj
+}
+
+func (r CreateEvalJSONLRunDataSourceParam) MarshalJSON() (data []byte, err error) {
+	type shadow CreateEvalJSONLRunDataSourceParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *CreateEvalJSONLRunDataSourceParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type CreateEvalJSONLRunDataSourceSourceUnionParam struct {
+	OfFileContent *EvalRunFileContentSourceParam `json:",omitzero,inline"`
+	OfFileID      *EvalRunFileIDSourceParam      `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u CreateEvalJSONLRunDataSourceSourceUnionParam) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfFileContent, u.OfFileID)
+}
+func (u *CreateEvalJSONLRunDataSourceSourceUnionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+func (u *CreateEvalJSONLRunDataSourceSourceUnionParam) asAny() any {
+	if !param.IsOmitted(u.OfFileContent) {
+		return u.OfFileContent
+	} else if !param.IsOmitted(u.OfFileID) {
+		return u.OfFileID
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u CreateEvalJSONLRunDataSourceSourceUnionParam) GetType() *string {
+	if vt := u.OfFileContent; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfFileID; vt != nil {
+		return (*string)(&vt.Type)
+	}
+	return nil
+}
+
+func init() {
+	apijson.RegisterUnion[CreateEvalJSONLRunDataSourceSourceUnionParam](
+		"type",
+		apijson.Discriminator[EvalRunFileContentSourceParam]("file_content"),
+		apijson.Discriminator[EvalRunFileIDSourceParam]("file_id"),
+	)
+}
+
+// A CompletionsRunDataSource object describing a model sampling configuration.
+//
+// The properties Source, Type are required.
+type CreateEvalCompletionsRunDataSourceParam struct {
+	// Determines what populates the `item` namespace in this run's data source.
+	Source CreateEvalCompletionsRunDataSourceSourceUnionParam `json:"source,omitzero,required"`
+	// The name of the model to use for generating completions (e.g. "o3-mini").
+	Model param.Opt[string] `json:"model,omitzero"`
+	// Used when sampling from a model. Dictates the structure of the messages passed
+	// into the model. Can either be a reference to a prebuilt trajectory (ie,
+	// `item.input_trajectory`), or a template with variable references to the `item`
+	// namespace.
+	InputMessages EvalRunInputMessagesUnionParam `json:"input_messages,omitzero"`
+	// The sampling parameters used when generating completions.
+	SamplingParams EvalRunSamplingParamsParam `json:"sampling_params,omitzero"`
+	// The type of run data source. Always `completions`.
+	//
+	// This field can be elided, and will marshal its zero value as "completions".
+	Type constant.Completions `json:"type,required"`
+	paramObj
+}
+
+func (r CreateEvalCompletionsRunDataSourceParam) MarshalJSON() (data []byte, err error) {
+	type shadow CreateEvalCompletionsRunDataSourceParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *CreateEvalCompletionsRunDataSourceParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type CreateEvalCompletionsRunDataSourceSourceUnionParam struct {
+	OfFileContent       *EvalRunFileContentSourceParam       `json:",omitzero,inline"`
+	OfFileID            *EvalRunFileIDSourceParam            `json:",omitzero,inline"`
+	OfStoredCompletions *EvalRunStoredCompletionsSourceParam `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u CreateEvalCompletionsRunDataSourceSourceUnionParam) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfFileContent, u.OfFileID, u.OfStoredCompletions)
+}
+func (u *CreateEvalCompletionsRunDataSourceSourceUnionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+func (u *CreateEvalCompletionsRunDataSourceSourceUnionParam) asAny() any {
+	if !param.IsOmitted(u.OfFileContent) {
+		return u.OfFileContent
+	} else if !param.IsOmitted(u.OfFileID) {
+		return u.OfFileID
+	} else if !param.IsOmitted(u.OfStoredCompletions) {
+		return u.OfStoredCompletions
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u CreateEvalCompletionsRunDataSourceSourceUnionParam) GetType() *string {
+	if vt := u.OfFileContent; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfFileID; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfStoredCompletions; vt != nil {
+		return (*string)(&vt.Type)
+	}
+	return nil
+}
+
+func init() {
+	apijson.RegisterUnion[CreateEvalCompletionsRunDataSourceSourceUnionParam](
+		"type",
+		apijson.Discriminator[EvalRunFileContentSourceParam]("file_content"),
+		apijson.Discriminator[EvalRunFileIDSourceParam]("file_id"),
+		apijson.Discriminator[EvalRunStoredCompletionsSourceParam]("stored_completions"),
+	)
+}
+
+// A ResponsesRunDataSource object describing a model sampling configuration.
+//
+// The properties Source, Type are required.
+type CreateEvalResponsesRunDataSourceParam struct {
+	// Determines what populates the `item` namespace in this run's data source.
+	Source CreateEvalResponsesRunDataSourceSourceUnionParam `json:"source,omitzero,required"`
+	// The name of the model to use for generating completions (e.g. "o3-mini").
+	Model param.Opt[string] `json:"model,omitzero"`
+	// Used when sampling from a model. Dictates the structure of the messages passed
+	// into the model. Can either be a reference to a prebuilt trajectory (ie,
+	// `item.input_trajectory`), or a template with variable references to the `item`
+	// namespace.
+	InputMessages EvalRunInputMessagesUnionParam `json:"input_messages,omitzero"`
+	// The sampling parameters used when generating responses.
+	SamplingParams EvalRunSamplingParamsParam `json:"sampling_params,omitzero"`
+	// The type of run data source. Always `responses`.
+	//
+	// This field can be elided, and will marshal its zero value as "responses".
+	Type constant.Responses `json:"type,required"`
+	paramObj
+}
+
+func (r CreateEvalResponsesRunDataSourceParam) MarshalJSON() (data []byte, err error) {
+	type shadow CreateEvalResponsesRunDataSourceParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *CreateEvalResponsesRunDataSourceParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type CreateEvalResponsesRunDataSourceSourceUnionParam struct {
+	OfFileContent *EvalRunFileContentSourceParam `json:",omitzero,inline"`
+	OfFileID      *EvalRunFileIDSourceParam      `json:",omitzero,inline"`
+	OfResponses   *EvalRunResponsesSourceParam   `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u CreateEvalResponsesRunDataSourceSourceUnionParam) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfFileContent, u.OfFileID, u.OfResponses)
+}
+func (u *CreateEvalResponsesRunDataSourceSourceUnionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+func (u *CreateEvalResponsesRunDataSourceSourceUnionParam) asAny() any {
+	if !param.IsOmitted(u.OfFileContent) {
+		return u.OfFileContent
+	} else if !param.IsOmitted(u.OfFileID) {
+		return u.OfFileID
+	} else if !param.IsOmitted(u.OfResponses) {
+		return u.OfResponses
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u CreateEvalResponsesRunDataSourceSourceUnionParam) GetType() *string {
+	if vt := u.OfFileContent; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfFileID; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfResponses; vt != nil {
+		return (*string)(&vt.Type)
+	}
+	return nil
+}
+
+func init() {
+	apijson.RegisterUnion[CreateEvalResponsesRunDataSourceSourceUnionParam](
+		"type",
+		apijson.Discriminator[EvalRunFileContentSourceParam]("file_content"),
+		apijson.Discriminator[EvalRunFileIDSourceParam]("file_id"),
+		apijson.Discriminator[EvalRunResponsesSourceParam]("responses"),
+	)
+}
+
+// The properties Content, Type are required.
+type EvalRunFileContentSourceParam struct {
+	// The content of the jsonl file.
+	Content []EvalRunFileContentSourceContentParam `json:"content,omitzero,required"`
+	// The type of jsonl source. Always `file_content`.
+	//
+	// This field can be elided, and will marshal its zero value as "file_content".
+	Type constant.FileContent `json:"type,required"`
+	paramObj
+}
+
+func (r EvalRunFileContentSourceParam) MarshalJSON() (data []byte, err error) {
+	type shadow EvalRunFileContentSourceParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalRunFileContentSourceParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property Item is required.
+type EvalRunFileContentSourceContentParam struct {
+	Item   map[string]any `json:"item,omitzero,required"`
+	Sample map[string]any `json:"sample,omitzero"`
+	paramObj
+}
+
+func (r EvalRunFileContentSourceContentParam) MarshalJSON() (data []byte, err error) {
+	type shadow EvalRunFileContentSourceContentParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalRunFileContentSourceContentParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The properties ID, Type are required.
+type EvalRunFileIDSourceParam struct {
+	// The identifier of the file.
+	ID string `json:"id,required"`
+	// The type of jsonl source. Always `file_id`.
+	//
+	// This field can be elided, and will marshal its zero value as "file_id".
+	Type constant.FileID `json:"type,required"`
+	paramObj
+}
+
+func (r EvalRunFileIDSourceParam) MarshalJSON() (data []byte, err error) {
+	type shadow EvalRunFileIDSourceParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalRunFileIDSourceParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A StoredCompletionsRunDataSource configuration describing a set of filters
+//
+// The property Type is required.
+type EvalRunStoredCompletionsSourceParam struct {
+	// An optional Unix timestamp to filter items created after this time.
+	CreatedAfter param.Opt[int64] `json:"created_after,omitzero"`
+	// An optional Unix timestamp to filter items created before this time.
+	CreatedBefore param.Opt[int64] `json:"created_before,omitzero"`
+	// An optional maximum number of items to return.
+	Limit param.Opt[int64] `json:"limit,omitzero"`
+	// An optional model to filter by (e.g., 'gpt-4o').
+	Model param.Opt[string] `json:"model,omitzero"`
+	// Set of 16 key-value pairs that can be attached to an object. This can be useful
+	// for storing additional information about the object in a structured format, and
+	// querying for objects via API or the dashboard.
+	//
+	// Keys are strings with a maximum length of 64 characters. Values are strings with
+	// a maximum length of 512 characters.
+	Metadata shared.Metadata `json:"metadata,omitzero"`
+	// The type of source. Always `stored_completions`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "stored_completions".
+	Type constant.StoredCompletions `json:"type,required"`
+	paramObj
+}
+
+func (r EvalRunStoredCompletionsSourceParam) MarshalJSON() (data []byte, err error) {
+	type shadow EvalRunStoredCompletionsSourceParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalRunStoredCompletionsSourceParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A EvalResponsesSource object describing a run data source configuration.
+//
+// The property Type is required.
+type EvalRunResponsesSourceParam struct {
+	// Only include items created after this timestamp (inclusive). This is a query
+	// parameter used to select responses.
+	CreatedAfter param.Opt[int64] `json:"created_after,omitzero"`
+	// Only include items created before this timestamp (inclusive). This is a query
+	// parameter used to select responses.
+	CreatedBefore param.Opt[int64] `json:"created_before,omitzero"`
+	// Optional string to search the 'instructions' field. This is a query parameter
+	// used to select responses.
+	InstructionsSearch param.Opt[string] `json:"instructions_search,omitzero"`
+	// The name of the model to find responses for. This is a query parameter used to
+	// select responses.
+	Model param.Opt[string] `json:"model,omitzero"`
+	// Sampling temperature. This is a query parameter used to select responses.
+	Temperature param.Opt[float64] `json:"temperature,omitzero"`
+	// Nucleus sampling parameter. This is a query parameter used to select responses.
+	TopP param.Opt[float64] `json:"top_p,omitzero"`
+	// Metadata filter for the responses. This is a query parameter used to select
+	// responses.
+	Metadata map[string]any `json:"metadata,omitzero"`
+	// Optional reasoning effort parameter. This is a query parameter used to select
+	// responses.
+	//
+	// Any of "none", "minimal", "low", "medium", "high", "xhigh".
+	ReasoningEffort shared.ReasoningEffort `json:"reasoning_effort,omitzero"`
+	// List of tool names. This is a query parameter used to select responses.
+	Tools []string `json:"tools,omitzero"`
+	// List of user identifiers. This is a query parameter used to select responses.
+	Users []string `json:"users,omitzero"`
+	// The type of run data source. Always `responses`.
+	//
+	// This field can be elided, and will marshal its zero value as "responses".
+	Type constant.Responses `json:"type,required"`
+	paramObj
+}
+
+func (r EvalRunResponsesSourceParam) MarshalJSON() (data []byte, err error) {
+	type shadow EvalRunResponsesSourceParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalRunResponsesSourceParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type EvalRunInputMessagesUnionParam struct {
+	OfTemplate      *EvalRunInputMessagesTemplateParam      `json:",omitzero,inline"`
+	OfItemReference *EvalRunInputMessagesItemReferenceParam `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u EvalRunInputMessagesUnionParam) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfTemplate, u.OfItemReference)
+}
+func (u *EvalRunInputMessagesUnionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+func (u *EvalRunInputMessagesUnionParam) asAny() any {
+	if !param.IsOmitted(u.OfTemplate) {
+		return u.OfTemplate
+	} else if !param.IsOmitted(u.OfItemReference) {
+		return u.OfItemReference
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalRunInputMessagesUnionParam) GetTemplate() []LabelModelGraderInputParam {
+	if vt := u.OfTemplate; vt != nil {
+		return vt.Template
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalRunInputMessagesUnionParam) GetItemReference() *string {
+	if vt := u.OfItemReference; vt != nil {
+		return &vt.ItemReference
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u EvalRunInputMessagesUnionParam) GetType() *string {
+	if vt := u.OfTemplate; vt != nil {
+		return (*string)(&vt.Type)
+	} else if vt := u.OfItemReference; vt != nil {
+		return (*string)(&vt.Type)
+	}
+	return nil
+}
+
+func init() {
+	apijson.RegisterUnion[EvalRunInputMessagesUnionParam](
+		"type",
+		apijson.Discriminator[EvalRunInputMessagesTemplateParam]("template"),
+		apijson.Discriminator[EvalRunInputMessagesItemReferenceParam]("item_reference"),
+	)
+}
+
+// The properties Template, Type are required.
+type EvalRunInputMessagesTemplateParam struct {
+	// A list of chat messages forming the prompt or context. May include variable
+	// references to the `item` namespace, ie {{item.name}}.
+	Template []LabelModelGraderInputParam `json:"template,omitzero,required"`
+	// The type of input messages. Always `template`.
+	//
+	// This field can be elided, and will marshal its zero value as "template".
+	Type constant.Template `json:"type,required"`
+	paramObj
+}
+
+func (r EvalRunInputMessagesTemplateParam) MarshalJSON() (data []byte, err error) {
+	type shadow EvalRunInputMessagesTemplateParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalRunInputMessagesTemplateParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The properties ItemReference, Type are required.
+type EvalRunInputMessagesItemReferenceParam struct {
+	// A reference to a variable in the `item` namespace. Ie, "item.input_trajectory"
+	ItemReference string `json:"item_reference,required"`
+	// The type of input messages. Always `item_reference`.
+	//
+	// This field can be elided, and will marshal its zero value as "item_reference".
+	Type constant.ItemReference `json:"type,required"`
+	paramObj
+}
+
+func (r EvalRunInputMessagesItemReferenceParam) MarshalJSON() (data []byte, err error) {
+	type shadow EvalRunInputMessagesItemReferenceParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalRunInputMessagesItemReferenceParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunSamplingParamsParam struct {
+	// The maximum number of tokens in the generated output.
+	MaxCompletionTokens param.Opt[int64] `json:"max_completion_tokens,omitzero"`
+	// A seed value to initialize the randomness, during sampling.
+	Seed param.Opt[int64] `json:"seed,omitzero"`
+	// A higher temperature increases randomness in the outputs.
+	Temperature param.Opt[float64] `json:"temperature,omitzero"`
+	// An alternative to temperature for nucleus sampling; 1.0 includes all tokens.
+	TopP param.Opt[float64] `json:"top_p,omitzero"`
+	// Constrains effort on reasoning for
+	// [reasoning models](https://platform.openai.com/docs/guides/reasoning).
+	//
+	// Any of "none", "minimal", "low", "medium", "high", "xhigh".
+	ReasoningEffort shared.ReasoningEffort `json:"reasoning_effort,omitzero"`
+	paramObj
+}
+
+func (r EvalRunSamplingParamsParam) MarshalJSON() (data []byte, err error) {
+	type shadow EvalRunSamplingParamsParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *EvalRunSamplingParamsParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunListParams struct {
+	// Identifier for the last run from the previous pagination request.
+	After param.Opt[string] `query:"after,omitzero" json:"-"`
+	// Number of runs to retrieve.
+	Limit param.Opt[int64] `query:"limit,omitzero" json:"-"`
+	// Sort order for runs by timestamp. Use `asc` for ascending order or `desc` for
+	// descending order. Defaults to `asc`.
+	//
+	// Any of "asc", "desc".
+	Order EvalRunListParamsOrder `query:"order,omitzero" json:"-"`
+	// Filter runs by status. One of `queued` | `in_progress` | `failed` | `completed`
+	// | `canceled`.
+	//
+	// Any of "queued", "in_progress", "completed", "canceled", "failed".
+	Status EvalRunStatus `query:"status,omitzero" json:"-"`
+	paramObj
+}
+
+// URLQuery serializes [EvalRunListParams]'s query parameters as `url.Values`.
+func (r EvalRunListParams) URLQuery() (v url.Values, err error) {
+	return apiquery.MarshalWithSettings(r, apiquery.QuerySettings{
+		ArrayFormat:  apiquery.ArrayQueryFormatBrackets,
+		NestedFormat: apiquery.NestedQueryFormatBrackets,
+	})
+}
+
+// Sort order for runs by timestamp. Use `asc` for ascending order or `desc` for
+// descending order. Defaults to `asc`.
+type EvalRunListParamsOrder string
+
+const (
+	EvalRunListParamsOrderAsc  EvalRunListParamsOrder = "asc"
+	EvalRunListParamsOrderDesc EvalRunListParamsOrder = "desc"
+)
@@ -0,0 +1,304 @@
+// File generated from our OpenAPI spec by Stainless. See CONTRIBUTING.md for details.
+
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apijson"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apiquery"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/pagination"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/respjson"
+	"github.com/Nordlys-Labs/openai-go/v3/shared/constant"
+)
+
+// EvalRunOutputItemService contains methods and other services that help with
+// interacting with the openai API.
+//
+// Note, unlike clients, this service does not read variables from the environment
+// automatically. You should not instantiate this service directly, and instead use
+// the [NewEvalRunOutputItemService] method instead.
+type EvalRunOutputItemService struct {
+	Options []option.RequestOption
+}
+
+// NewEvalRunOutputItemService generates a new service that applies the given
+// options to each request. These options are applied after the parent client's
+// options (if there is one), and before any request-specific options.
+func NewEvalRunOutputItemService(opts ...option.RequestOption) (r EvalRunOutputItemService) {
+	r = EvalRunOutputItemService{}
+	r.Options = opts
+	return
+}
+
+// Get an evaluation run output item by ID.
+func (r *EvalRunOutputItemService) Get(ctx context.Context, evalID string, runID string, outputItemID string, opts ...option.RequestOption) (res *EvalRunOutputItem, err error) {
+	opts = slices.Concat(r.Options, opts)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	if runID == "" {
+		err = errors.New("missing required run_id parameter")
+		return
+	}
+	if outputItemID == "" {
+		err = errors.New("missing required output_item_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s/runs/%s/output_items/%s", evalID, runID, outputItemID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
+	return
+}
+
+// Get a list of output items for an evaluation run.
+func (r *EvalRunOutputItemService) List(ctx context.Context, evalID string, runID string, query EvalRunOutputItemListParams, opts ...option.RequestOption) (res *pagination.CursorPage[EvalRunOutputItem], err error) {
+	var raw *http.Response
+	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
+	if evalID == "" {
+		err = errors.New("missing required eval_id parameter")
+		return
+	}
+	if runID == "" {
+		err = errors.New("missing required run_id parameter")
+		return
+	}
+	path := fmt.Sprintf("evals/%s/runs/%s/output_items", evalID, runID)
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Get a list of output items for an evaluation run.
+func (r *EvalRunOutputItemService) ListAutoPaging(ctx context.Context, evalID string, runID string, query EvalRunOutputItemListParams, opts ...option.RequestOption) *pagination.CursorPageAutoPager[EvalRunOutputItem] {
+	return pagination.NewCursorPageAutoPager(r.List(ctx, evalID, runID, query, opts...))
+}
+
+// A schema representing an evaluation run output item.
+type EvalRunOutputItem struct {
+	// Unique identifier for the evaluation run output item.
+	ID string `json:"id,required"`
+	// Unix timestamp (in seconds) when the evaluation run was created.
+	CreatedAt int64 `json:"created_at,required"`
+	// Details of the input data source item.
+	DatasourceItem map[string]any `json:"datasource_item,required"`
+	// The identifier for the data source item.
+	DatasourceItemID int64 `json:"datasource_item_id,required"`
+	// The identifier of the evaluation group.
+	EvalID string `json:"eval_id,required"`
+	// The type of the object. Always "eval.run.output_item".
+	Object constant.EvalRunOutputItem `json:"object,required"`
+	// A list of grader results for this output item.
+	Results []EvalRunOutputItemResult `json:"results,required"`
+	// The identifier of the evaluation run associated with this output item.
+	RunID string `json:"run_id,required"`
+	// A sample containing the input and output of the evaluation run.
+	Sample EvalRunOutputItemSample `json:"sample,required"`
+	// The status of the evaluation run.
+	Status string `json:"status,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID               respjson.Field
+		CreatedAt        respjson.Field
+		DatasourceItem   respjson.Field
+		DatasourceItemID respjson.Field
+		EvalID           respjson.Field
+		Object           respjson.Field
+		Results          respjson.Field
+		RunID            respjson.Field
+		Sample           respjson.Field
+		Status           respjson.Field
+		ExtraFields      map[string]respjson.Field
+		raw              string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunOutputItem) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunOutputItem) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A single grader result for an evaluation run output item.
+type EvalRunOutputItemResult struct {
+	// The name of the grader.
+	Name string `json:"name,required"`
+	// Whether the grader considered the output a pass.
+	Passed bool `json:"passed,required"`
+	// The numeric score produced by the grader.
+	Score float64 `json:"score,required"`
+	// Optional sample or intermediate data produced by the grader.
+	Sample map[string]any `json:"sample,nullable"`
+	// The grader type (for example, "string-check-grader").
+	Type string `json:"type"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Name        respjson.Field
+		Passed      respjson.Field
+		Score       respjson.Field
+		Sample      respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunOutputItemResult) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunOutputItemResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A sample containing the input and output of the evaluation run.
+type EvalRunOutputItemSample struct {
+	// An object representing an error response from the Eval API.
+	Error EvalAPIError `json:"error,required"`
+	// The reason why the sample generation was finished.
+	FinishReason string `json:"finish_reason,required"`
+	// An array of input messages.
+	Input []EvalRunOutputItemSampleMessage `json:"input,required"`
+	// The maximum number of tokens allowed for completion.
+	MaxCompletionTokens int64 `json:"max_completion_tokens,required"`
+	// The model used for generating the sample.
+	Model string `json:"model,required"`
+	// An array of output messages.
+	Output []EvalRunOutputItemSampleMessage `json:"output,required"`
+	// The seed used for generating the sample.
+	Seed int64 `json:"seed,required"`
+	// The sampling temperature used.
+	Temperature float64 `json:"temperature,required"`
+	// The top_p value used for sampling.
+	TopP float64 `json:"top_p,required"`
+	// Token usage details for the sample.
+	Usage EvalRunOutputItemSampleUsage `json:"usage,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Error               respjson.Field
+		FinishReason        respjson.Field
+		Input               respjson.Field
+		MaxCompletionTokens respjson.Field
+		Model               respjson.Field
+		Output              respjson.Field
+		Seed                respjson.Field
+		Temperature         respjson.Field
+		TopP                respjson.Field
+		Usage               respjson.Field
+		ExtraFields         map[string]respjson.Field
+		raw                 string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunOutputItemSample) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunOutputItemSample) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A message in the input or output of a sample.
+type EvalRunOutputItemSampleMessage struct {
+	// The content of the message.
+	Content string `json:"content"`
+	// The role of the message (e.g. "system", "assistant", "user").
+	Role string `json:"role"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Content     respjson.Field
+		Role        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunOutputItemSampleMessage) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunOutputItemSampleMessage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Token usage details for the sample.
+type EvalRunOutputItemSampleUsage struct {
+	// The number of tokens retrieved from cache.
+	CachedTokens int64 `json:"cached_tokens,required"`
+	// The number of completion tokens generated.
+	CompletionTokens int64 `json:"completion_tokens,required"`
+	// The number of prompt tokens used.
+	PromptTokens int64 `json:"prompt_tokens,required"`
+	// The total number of tokens used.
+	TotalTokens int64 `json:"total_tokens,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		CachedTokens     respjson.Field
+		CompletionTokens respjson.Field
+		PromptTokens     respjson.Field
+		TotalTokens      respjson.Field
+		ExtraFields      map[string]respjson.Field
+		raw              string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r EvalRunOutputItemSampleUsage) RawJSON() string { return r.JSON.raw }
+func (r *EvalRunOutputItemSampleUsage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type EvalRunOutputItemListParams struct {
+	// Identifier for the last output item from the previous pagination request.
+	After param.Opt[string] `query:"after,omitzero" json:"-"`
+	// Number of output items to retrieve.
+	Limit param.Opt[int64] `query:"limit,omitzero" json:"-"`
+	// Sort order for output items by timestamp. Use `asc` for ascending order or
+	// `desc` for descending order. Defaults to `asc`.
+	//
+	// Any of "asc", "desc".
+	Order EvalRunOutputItemListParamsOrder `query:"order,omitzero" json:"-"`
+	// Filter output items by status. Use `failed` to filter by failed output items or
+	// `pass` to filter by passed output items.
+	//
+	// Any of "fail", "pass".
+	Status EvalRunOutputItemListParamsStatus `query:"status,omitzero" json:"-"`
+	paramObj
+}
+
+// URLQuery serializes [EvalRunOutputItemListParams]'s query parameters as
+// `url.Values`.
+func (r EvalRunOutputItemListParams) URLQuery() (v url.Values, err error) {
+	return apiquery.MarshalWithSettings(r, apiquery.QuerySettings{
+		ArrayFormat:  apiquery.ArrayQueryFormatBrackets,
+		NestedFormat: apiquery.NestedQueryFormatBrackets,
+	})
+}
+
+// Sort order for output items by timestamp. Use `asc` for ascending order or
+// `desc` for descending order. Defaults to `asc`.
+type EvalRunOutputItemListParamsOrder string
+
+const (
+	EvalRunOutputItemListParamsOrderAsc  EvalRunOutputItemListParamsOrder = "asc"
+	EvalRunOutputItemListParamsOrderDesc EvalRunOutputItemListParamsOrder = "desc"
+)
+
+// Filter output items by status. Use `failed` to filter by failed output items or
+// `pass` to filter by passed output items.
+type EvalRunOutputItemListParamsStatus string
+
+const (
+	EvalRunOutputItemListParamsStatusFail EvalRunOutputItemListParamsStatus = "fail"
+	EvalRunOutputItemListParamsStatusPass EvalRunOutputItemListParamsStatus = "pass"
+)
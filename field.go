@@ -43,3 +43,9 @@ func (f file) Filename() string {
 func (f file) ContentType() string {
 	return f.contentType
 }
+
+// Unwrap returns the reader of the file, so that its size can be known and it
+// can be read again when the request is retried.
+func (f file) Unwrap() io.Reader {
+	return f.Reader
+}
@@ -0,0 +1,101 @@
+package openai_test
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+func TestFileUploadGetBody(t *testing.T) {
+	contents := strings.Repeat("streamed file contents\n", 1000)
+	path := filepath.Join(t.TempDir(), "data.jsonl")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if data, _ := io.ReadAll(file); string(data) != contents {
+			http.Error(w, "unexpected file contents", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id":"file-abc123","object":"file","purpose":"fine-tune"}`)
+	}))
+	defer srv.Close()
+
+	// A middleware reading the body again before the request is sent does not
+	// move the body being sent.
+	getBody := func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		io.Copy(io.Discard, body)
+		body.Close()
+		return next(req)
+	}
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"), option.WithMaxRetries(0), option.WithMiddleware(getBody))
+	file, err := client.Files.New(context.Background(), openai.FileNewParams{
+		File:    f,
+		Purpose: openai.FilePurposeFineTune,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.ID != "file-abc123" {
+		t.Fatalf("unexpected file %s", file.RawJSON())
+	}
+}
+
+func TestMarshalMultipartStream(t *testing.T) {
+	newParams := func() openai.FileNewParams {
+		params := openai.FileNewParams{
+			File:    openai.File(strings.NewReader("file contents"), "data.jsonl", "application/jsonl"),
+			Purpose: openai.FilePurposeFineTune,
+		}
+		params.SetExtraFields(map[string]any{"note": "extra"})
+		return params
+	}
+
+	want, contentType, err := newParams().MarshalMultipart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := newParams().MarshalMultipartStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The forms only differ by their random boundaries.
+	boundary := func(contentType string) string {
+		_, params, _ := mime.ParseMediaType(contentType)
+		return params["boundary"]
+	}
+	got = []byte(strings.ReplaceAll(string(got), boundary(stream.ContentType()), boundary(contentType)))
+	if string(got) != string(want) {
+		t.Fatalf("expected the streamed form to match MarshalMultipart:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,49 @@
+package openai
+
+import (
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apiform"
+)
+
+// The MarshalMultipartStream methods lay out the same forms as the
+// MarshalMultipart methods, with their files read as the request is sent instead
+// of being buffered in memory.
+
+func (r AudioTranscriptionNewParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
+
+func (r AudioTranslationNewParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
+
+func (r ContainerFileNewParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
+
+func (r FileNewParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
+
+func (r ImageEditParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
+
+func (r ImageNewVariationParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
+
+func (r SkillNewParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
+
+func (r SkillVersionNewParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
+
+func (r UploadPartNewParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
+
+func (r VideoNewParams) MarshalMultipartStream() (*apiform.Stream, error) {
+	return apiform.NewStream(r, r.ExtraFields())
+}
@@ -8,6 +8,8 @@ import (
 	"net/http/httputil"
 
 	"github.com/Nordlys-Labs/openai-go/v3/internal/apijson"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/respmeta"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ratelimit"
 	"github.com/Nordlys-Labs/openai-go/v3/packages/respjson"
 )
 
@@ -29,8 +31,12 @@ type Error struct {
 		raw         string
 	} `json:"-"`
 	StatusCode int
-	Request    *http.Request
-	Response   *http.Response
+	// The x-request-id header of the response, to share with OpenAI support.
+	RequestID string
+	// Meta holds the metadata of the response, such as its rate limits.
+	Meta     respmeta.Meta
+	Request  *http.Request
+	Response *http.Response
 }
 
 // Returns the unmodified JSON received from the API
@@ -41,7 +47,17 @@ func (r *Error) UnmarshalJSON(data []byte) error {
 
 func (r *Error) Error() string {
 	// Attempt to re-populate the response body
-	return fmt.Sprintf("%s %q: %d %s %s", r.Request.Method, r.Request.URL, r.Response.StatusCode, http.StatusText(r.Response.StatusCode), r.JSON.raw)
+	msg := fmt.Sprintf("%s %q: %d %s %s", r.Request.Method, r.Request.URL, r.Response.StatusCode, http.StatusText(r.Response.StatusCode), r.JSON.raw)
+	if r.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", r.RequestID)
+	}
+	return msg
+}
+
+// RateLimitInfo returns the rate limits reported by the headers of the error
+// response.
+func (r *Error) RateLimitInfo() ratelimit.Info {
+	return ratelimit.FromResponse(r.Response)
 }
 
 func (r *Error) DumpRequest(body bool) []byte {
@@ -0,0 +1,26 @@
+package apierror
+
+import "fmt"
+
+// RefusalError is returned when the model refuses to produce structured output.
+// The refusal message explains why.
+type RefusalError struct {
+	Refusal string
+}
+
+func (r *RefusalError) Error() string {
+	return fmt.Sprintf("the model refused to produce structured output: %s", r.Refusal)
+}
+
+// IncompleteOutputError is returned when structured output cannot be parsed
+// because generation stopped before the output was complete, for example after
+// reaching the token limit or being stopped by the content filter.
+type IncompleteOutputError struct {
+	// The finish reason of the chat completion choice, or the reason for the
+	// incomplete response.
+	Reason string
+}
+
+func (r *IncompleteOutputError) Error() string {
+	return fmt.Sprintf("the structured output is incomplete, generation stopped with reason %q", r.Reason)
+}
@@ -0,0 +1,120 @@
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Sentinels matching the errors of the API with [errors.Is]. An [Error] may match
+// several of them, for instance a context length error is also a bad request.
+var (
+	ErrBadRequest            = errors.New("openai: bad request")
+	ErrAuthentication        = errors.New("openai: authentication failed")
+	ErrPermissionDenied      = errors.New("openai: permission denied")
+	ErrNotFound              = errors.New("openai: not found")
+	ErrConflict              = errors.New("openai: conflict")
+	ErrUnprocessableEntity   = errors.New("openai: unprocessable entity")
+	ErrRateLimited           = errors.New("openai: rate limited")
+	ErrInsufficientQuota     = errors.New("openai: insufficient quota")
+	ErrContextLengthExceeded = errors.New("openai: context length exceeded")
+	ErrContentFilter         = errors.New("openai: content filtered")
+	ErrServerError           = errors.New("openai: server error")
+)
+
+// Sentinels matching the errors which happen before the API answers, see
+// [ConnectionError] and [TimeoutError].
+var (
+	ErrConnection = errors.New("openai: connection error")
+	ErrTimeout    = errors.New("openai: request timed out")
+)
+
+// Is reports whether the error matches one of the sentinels of the package,
+// from its status code, code and type.
+func (r *Error) Is(target error) bool {
+	return Matches(target, r.StatusCode, r.Code, r.Type)
+}
+
+// Matches reports whether an error with the given status code, code and type
+// matches a sentinel. The status code is 0 for errors received in a stream.
+func Matches(target error, statusCode int, code string, typ string) bool {
+	switch target {
+	case ErrBadRequest:
+		return statusCode == http.StatusBadRequest
+	case ErrAuthentication:
+		return statusCode == http.StatusUnauthorized || code == "invalid_api_key" || typ == "authentication_error"
+	case ErrPermissionDenied:
+		return statusCode == http.StatusForbidden
+	case ErrNotFound:
+		return statusCode == http.StatusNotFound
+	case ErrConflict:
+		return statusCode == http.StatusConflict
+	case ErrUnprocessableEntity:
+		return statusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return (statusCode == http.StatusTooManyRequests || code == "rate_limit_exceeded") && !isInsufficientQuota(code, typ)
+	case ErrInsufficientQuota:
+		return isInsufficientQuota(code, typ)
+	case ErrContextLengthExceeded:
+		return code == "context_length_exceeded"
+	case ErrContentFilter:
+		return code == "content_filter" || code == "content_policy_violation"
+	case ErrServerError:
+		return statusCode >= http.StatusInternalServerError || code == "server_error" || typ == "server_error"
+	}
+	return false
+}
+
+func isInsufficientQuota(code string, typ string) bool {
+	return code == "insufficient_quota" || typ == "insufficient_quota"
+}
+
+// ConnectionError is returned when a request could not be sent, or its response
+// could not be received, after every retry. It matches [ErrConnection], and
+// [ErrTimeout] when the connection timed out.
+type ConnectionError struct {
+	Request *http.Request
+	Err     error
+}
+
+func (e *ConnectionError) Error() string { return e.Err.Error() }
+func (e *ConnectionError) Unwrap() error { return e.Err }
+
+func (e *ConnectionError) Is(target error) bool {
+	var netErr net.Error
+	return target == ErrConnection ||
+		target == ErrTimeout && (errors.Is(e.Err, context.DeadlineExceeded) || errors.As(e.Err, &netErr) && netErr.Timeout())
+}
+
+// TimeoutError is returned when an attempt of a request exceeds the request
+// timeout of the client. It matches [ErrTimeout] and [context.DeadlineExceeded].
+type TimeoutError struct {
+	Request *http.Request
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s %q: request timed out after %s", e.Request.Method, e.Request.URL, e.Timeout)
+}
+
+func (e *TimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+func (e *TimeoutError) Is(target error) bool { return target == ErrTimeout }
+
+// StreamIdleTimeoutError is returned by a stream which received nothing, not
+// even a ping, for the idle timeout set with option.WithStreamIdleTimeout. It
+// matches [ErrTimeout], but not [context.DeadlineExceeded]: the server stopped
+// sending, rather than the request running out of time.
+type StreamIdleTimeoutError struct {
+	Request *http.Request
+	Timeout time.Duration
+}
+
+func (e *StreamIdleTimeoutError) Error() string {
+	return fmt.Sprintf("%s %q: stream received nothing for %s", e.Request.Method, e.Request.URL, e.Timeout)
+}
+
+func (e *StreamIdleTimeoutError) Is(target error) bool { return target == ErrTimeout }
@@ -23,7 +23,7 @@ func Marshal(value any, writer *multipart.Writer) error {
 		dateFormat: time.RFC3339,
 		arrayFmt:   "brackets",
 	}
-	return e.marshal(value, writer)
+	return e.marshal(value, &formWriter{Writer: writer})
 }
 
 func MarshalRoot(value any, writer *multipart.Writer) error {
@@ -32,7 +32,7 @@ func MarshalRoot(value any, writer *multipart.Writer) error {
 		dateFormat: time.RFC3339,
 		arrayFmt:   "brackets",
 	}
-	return e.marshal(value, writer)
+	return e.marshal(value, &formWriter{Writer: writer})
 }
 
 func MarshalWithSettings(value any, writer *multipart.Writer, arrayFormat string) error {
@@ -40,7 +40,14 @@ func MarshalWithSettings(value any, writer *multipart.Writer, arrayFormat string
 		arrayFmt:   arrayFormat,
 		dateFormat: time.RFC3339,
 	}
-	return e.marshal(value, writer)
+	return e.marshal(value, &formWriter{Writer: writer})
+}
+
+// formWriter is the writer of a form, and the [Stream] laying it out when the
+// files are streamed instead of copied into the writer.
+type formWriter struct {
+	*multipart.Writer
+	stream *Stream
 }
 
 type encoder struct {
@@ -49,7 +56,7 @@ type encoder struct {
 	root       bool
 }
 
-type encoderFunc func(key string, value reflect.Value, writer *multipart.Writer) error
+type encoderFunc func(key string, value reflect.Value, writer *formWriter) error
 
 type encoderField struct {
 	tag parsedStructTag
@@ -64,7 +71,7 @@ type encoderEntry struct {
 	root       bool
 }
 
-func (e *encoder) marshal(value any, writer *multipart.Writer) error {
+func (e *encoder) marshal(value any, writer *formWriter) error {
 	val := reflect.ValueOf(value)
 	if !val.IsValid() {
 		return nil
@@ -95,7 +102,7 @@ func (e *encoder) typeEncoder(t reflect.Type) encoderFunc {
 		f  encoderFunc
 	)
 	wg.Add(1)
-	fi, loaded := encoders.LoadOrStore(entry, encoderFunc(func(key string, v reflect.Value, writer *multipart.Writer) error {
+	fi, loaded := encoders.LoadOrStore(entry, encoderFunc(func(key string, v reflect.Value, writer *formWriter) error {
 		wg.Wait()
 		return f(key, v, writer)
 	}))
@@ -123,7 +130,7 @@ func (e *encoder) newTypeEncoder(t reflect.Type) encoderFunc {
 		inner := t.Elem()
 
 		innerEncoder := e.typeEncoder(inner)
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			if !v.IsValid() || v.IsNil() {
 				return nil
 			}
@@ -147,34 +154,34 @@ func (e *encoder) newPrimitiveTypeEncoder(t reflect.Type) encoderFunc {
 	// Note that we could use `gjson` to encode these types but it would complicate our
 	// code more and this current code shouldn't cause any issues
 	case reflect.String:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, v.String())
 		}
 	case reflect.Bool:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			if v.Bool() {
 				return writer.WriteField(key, "true")
 			}
 			return writer.WriteField(key, "false")
 		}
 	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, strconv.FormatInt(v.Int(), 10))
 		}
 	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, strconv.FormatUint(v.Uint(), 10))
 		}
 	case reflect.Float32:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, strconv.FormatFloat(v.Float(), 'f', -1, 32))
 		}
 	case reflect.Float64:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, strconv.FormatFloat(v.Float(), 'f', -1, 64))
 		}
 	default:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return fmt.Errorf("unknown type received at primitive encoder: %s", t.String())
 		}
 	}
@@ -183,7 +190,7 @@ func (e *encoder) newPrimitiveTypeEncoder(t reflect.Type) encoderFunc {
 func (e *encoder) newArrayTypeEncoder(t reflect.Type) encoderFunc {
 	itemEncoder := e.typeEncoder(t.Elem())
 	keyFn := e.arrayKeyEncoder()
-	return func(key string, v reflect.Value, writer *multipart.Writer) error {
+	return func(key string, v reflect.Value, writer *formWriter) error {
 		if keyFn == nil {
 			return fmt.Errorf("apiform: unsupported array format")
 		}
@@ -259,7 +266,7 @@ func (e *encoder) newStructTypeEncoder(t reflect.Type) encoderFunc {
 			var encoderFn encoderFunc
 			if ptag.omitzero {
 				typeEncoderFn := e.typeEncoder(field.Type)
-				encoderFn = func(key string, value reflect.Value, writer *multipart.Writer) error {
+				encoderFn = func(key string, value reflect.Value, writer *formWriter) error {
 					if value.IsZero() {
 						return nil
 					}
@@ -279,7 +286,7 @@ func (e *encoder) newStructTypeEncoder(t reflect.Type) encoderFunc {
 		return encoderFields[i].tag.name < encoderFields[j].tag.name
 	})
 
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		keyFn := e.objKeyEncoder(key)
 		for _, ef := range encoderFields {
 			field := value.FieldByIndex(ef.idx)
@@ -313,7 +320,7 @@ func (e *encoder) newStructUnionTypeEncoder(t reflect.Type) encoderFunc {
 		fieldEncoders = append(fieldEncoders, e.typeEncoder(field.Type))
 	}
 
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		for i := 0; i < t.NumField(); i++ {
 			if value.Field(i).Type() == paramUnionType {
 				continue
@@ -328,13 +335,13 @@ func (e *encoder) newStructUnionTypeEncoder(t reflect.Type) encoderFunc {
 
 func (e *encoder) newTimeTypeEncoder() encoderFunc {
 	format := e.dateFormat
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		return writer.WriteField(key, value.Convert(reflect.TypeOf(time.Time{})).Interface().(time.Time).Format(format))
 	}
 }
 
 func (e encoder) newInterfaceEncoder() encoderFunc {
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		value = value.Elem()
 		if !value.IsValid() {
 			return nil
@@ -350,7 +357,7 @@ func escapeQuotes(s string) string {
 }
 
 func (e *encoder) newReaderTypeEncoder() encoderFunc {
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		reader, ok := value.Convert(reflect.TypeOf((*io.Reader)(nil)).Elem()).Interface().(io.Reader)
 		if !ok {
 			return nil
@@ -374,6 +381,9 @@ func (e *encoder) newReaderTypeEncoder() encoderFunc {
 		if err != nil {
 			return err
 		}
+		if writer.stream != nil {
+			return writer.stream.addFile(reader)
+		}
 		_, err = io.Copy(filewriter, reader)
 		return err
 	}
@@ -418,7 +428,7 @@ func (e encoder) objKeyEncoder(parent string) func(string) string {
 
 // Given a []byte of json (may either be an empty object or an object that already contains entries)
 // encode all of the entries in the map to the json byte array.
-func (e *encoder) encodeMapEntries(key string, v reflect.Value, writer *multipart.Writer) error {
+func (e *encoder) encodeMapEntries(key string, v reflect.Value, writer *formWriter) error {
 	type mapPair struct {
 		key   string
 		value reflect.Value
@@ -453,7 +463,7 @@ func (e *encoder) encodeMapEntries(key string, v reflect.Value, writer *multipar
 }
 
 func (e *encoder) newMapEncoder(_ reflect.Type) encoderFunc {
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		return e.encodeMapEntries(key, value, writer)
 	}
 }
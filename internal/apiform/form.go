@@ -3,3 +3,9 @@ package apiform
 type Marshaler interface {
 	MarshalMultipart() ([]byte, string, error)
 }
+
+// StreamMarshaler is implemented by the params whose form can be streamed. The
+// stream must lay out the same form as MarshalMultipart.
+type StreamMarshaler interface {
+	MarshalMultipartStream() (*Stream, error)
+}
@@ -0,0 +1,185 @@
+package apiform
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"time"
+)
+
+// Stream is a multipart form whose files are read as the form is read, instead
+// of being buffered in memory. Its length is known when the size of every file
+// is, and it can be read again when every file can seek, such as an [*os.File].
+// Every reader of the form reads the files from their own position, so a reader
+// created for the GetBody of a request does not move the one being sent.
+type Stream struct {
+	contentType string
+	segments    []segment
+	buf         bytes.Buffer
+	read        bool
+	body        io.ReadCloser
+}
+
+// A segment is either the encoded bytes of the form, or a file.
+type segment struct {
+	data     []byte
+	file     io.Reader
+	size     int64
+	seeker   io.Seeker
+	readerAt io.ReaderAt
+	offset   int64
+}
+
+// NewStream lays out the multipart form of value and its extra fields, like
+// [MarshalRoot] and [WriteExtras], without reading its files.
+func NewStream(value any, extras map[string]any) (*Stream, error) {
+	s := &Stream{}
+	writer := multipart.NewWriter(&s.buf)
+	e := &encoder{
+		root:       true,
+		dateFormat: time.RFC3339,
+		arrayFmt:   "brackets",
+	}
+	err := e.marshal(value, &formWriter{Writer: writer, stream: s})
+	if err == nil {
+		err = WriteExtras(writer, extras)
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.flush()
+	s.contentType = writer.FormDataContentType()
+	return s, nil
+}
+
+// flush ends the segment of the bytes written so far.
+func (s *Stream) flush() {
+	if s.buf.Len() > 0 {
+		data := bytes.Clone(s.buf.Bytes())
+		s.segments = append(s.segments, segment{data: data, size: int64(len(data))})
+		s.buf.Reset()
+	}
+}
+
+// addFile adds a file to the form, after the header of its part.
+func (s *Stream) addFile(reader io.Reader) error {
+	s.flush()
+	file := segment{file: reader, size: -1}
+
+	inner := reader
+	for {
+		wrapper, ok := inner.(interface{ Unwrap() io.Reader })
+		if !ok {
+			break
+		}
+		inner = wrapper.Unwrap()
+	}
+	// The contents of a buffer are already in memory, and are read without
+	// draining it so that the form can be read again.
+	if buf, ok := inner.(*bytes.Buffer); ok {
+		inner = bytes.NewReader(buf.Bytes())
+		file.file = inner
+	}
+	if seeker, ok := inner.(io.Seeker); ok {
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err == nil {
+			end, err := seeker.Seek(0, io.SeekEnd)
+			if _, seekErr := seeker.Seek(offset, io.SeekStart); seekErr != nil {
+				return seekErr
+			}
+			if err == nil {
+				file.size, file.seeker, file.offset = end-offset, seeker, offset
+				file.readerAt, _ = inner.(io.ReaderAt)
+			}
+		}
+	}
+	s.segments = append(s.segments, file)
+	return nil
+}
+
+// ContentType returns the content type of the form, with its boundary.
+func (s *Stream) ContentType() string {
+	return s.contentType
+}
+
+// Len returns the length of the form, or -1 when the size of a file is unknown.
+func (s *Stream) Len() int64 {
+	var length int64
+	for _, segment := range s.segments {
+		if segment.size < 0 {
+			return -1
+		}
+		length += segment.size
+	}
+	return length
+}
+
+// Rewindable reports whether the form can be read again, which requires every
+// file to seek.
+func (s *Stream) Rewindable() bool {
+	for _, segment := range s.segments {
+		if segment.file != nil && segment.seeker == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Reader returns a reader of the form, which reads the files from their
+// position when the form was laid out. Only the first reader can be created
+// when the form is not [Stream.Rewindable].
+func (s *Stream) Reader() (io.ReadCloser, error) {
+	if s.read && !s.Rewindable() {
+		return nil, errors.New("apiform: the form has a file which cannot be read again")
+	}
+	readers := make([]io.Reader, 0, len(s.segments))
+	for _, segment := range s.segments {
+		switch {
+		case segment.file == nil:
+			readers = append(readers, bytes.NewReader(segment.data))
+		case segment.readerAt != nil:
+			readers = append(readers, io.NewSectionReader(segment.readerAt, segment.offset, segment.size))
+		case segment.seeker != nil:
+			readers = append(readers, &seekingReader{segment: segment})
+		default:
+			readers = append(readers, segment.file)
+		}
+	}
+	s.read = true
+	return io.NopCloser(io.MultiReader(readers...)), nil
+}
+
+// Read reads the form from its first reader, so that a stream can be used as
+// any other body.
+func (s *Stream) Read(p []byte) (int, error) {
+	if s.body == nil {
+		body, err := s.Reader()
+		if err != nil {
+			return 0, err
+		}
+		s.body = body
+	}
+	return s.body.Read(p)
+}
+
+// seekingReader reads a file which can seek but not read at an offset. It seeks
+// the file to the position of the segment on its first read, rather than when
+// the reader is created, so that another reader may read the file in between.
+type seekingReader struct {
+	segment
+	reader io.Reader
+}
+
+func (r *seekingReader) Read(p []byte) (int, error) {
+	if r.reader == nil {
+		if _, err := r.seeker.Seek(r.offset, io.SeekStart); err != nil {
+			return 0, err
+		}
+		r.reader = io.LimitReader(r.file, r.size)
+	}
+	return r.reader.Read(p)
+}
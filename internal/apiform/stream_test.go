@@ -0,0 +1,118 @@
+package apiform
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type StreamStruct struct {
+	Purpose string    `form:"purpose"`
+	File    io.Reader `form:"file"`
+	Other   io.Reader `form:"other"`
+}
+
+// marshal returns the buffered form of value, with the boundary of contentType.
+func marshal(t *testing.T, value any, extras map[string]any, contentType string) string {
+	t.Helper()
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := bytes.NewBuffer(nil)
+	writer := multipart.NewWriter(buf)
+	writer.SetBoundary(params["boundary"])
+	if err := MarshalRoot(value, writer); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteExtras(writer, extras); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func readStream(t *testing.T, s *Stream) string {
+	t.Helper()
+	body, err := s.Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.jsonl")
+	if err := os.WriteFile(path, []byte(`{"prompt": "hi"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	open := func() *os.File {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+	extras := map[string]any{"extra": "value"}
+
+	value := StreamStruct{Purpose: "fine-tune", File: open(), Other: bytes.NewBufferString("other contents")}
+	s, err := NewStream(value, extras)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := marshal(t, StreamStruct{Purpose: "fine-tune", File: open(), Other: bytes.NewBufferString("other contents")}, extras, s.ContentType())
+	if got := readStream(t, s); got != want {
+		t.Errorf("expected the stream to match the buffered form\n%s\ngot\n%s", want, got)
+	}
+	if s.Len() != int64(len(want)) || !s.Rewindable() {
+		t.Errorf("expected a rewindable stream of length %d, got %d", len(want), s.Len())
+	}
+	if got := readStream(t, s); got != want {
+		t.Errorf("expected the stream to be read again, got\n%s", got)
+	}
+
+	// Every reader has its own position, also for files which can only seek.
+	seeker := struct{ io.ReadSeeker }{open()}
+	s, err = NewStream(StreamStruct{Purpose: "fine-tune", File: seeker, Other: bytes.NewBufferString("other contents")}, extras)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = marshal(t, StreamStruct{Purpose: "fine-tune", File: struct{ io.ReadSeeker }{open()}, Other: bytes.NewBufferString("other contents")}, extras, s.ContentType())
+	first, err := s.Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readStream(t, s); got != want {
+		t.Errorf("expected the second reader to read the form, got\n%s", got)
+	}
+	if got, _ := io.ReadAll(first); string(got) != want {
+		t.Errorf("expected the first reader to read the form after the second, got\n%s", got)
+	}
+
+	// A reader which cannot seek is streamed once, with an unknown length.
+	s, err = NewStream(StreamStruct{File: io.MultiReader(strings.NewReader("piped"))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readStream(t, s); !strings.Contains(got, "\r\n\r\npiped\r\n") {
+		t.Errorf("expected the file in the form, got\n%s", got)
+	}
+	if s.Len() != -1 || s.Rewindable() {
+		t.Errorf("expected a stream of unknown length which cannot be read again")
+	}
+	if _, err := s.Reader(); err == nil {
+		t.Error("expected the stream not to be read again")
+	}
+}
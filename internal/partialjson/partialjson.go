@@ -0,0 +1,147 @@
+// Package partialjson decodes JSON documents which are still being streamed,
+// such as the content of a structured output that the model has not finished
+// generating.
+package partialjson
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Complete turns a prefix of a JSON document into a valid document by dropping
+// any trailing incomplete key, number or literal and closing the open strings,
+// arrays and objects. It returns an empty string when nothing can be
+// recovered.
+func Complete(s string) string {
+	type frame struct {
+		object    bool
+		expectKey bool
+	}
+	var stack []frame
+
+	closers := func() string {
+		var b strings.Builder
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].object {
+				b.WriteByte('}')
+			} else {
+				b.WriteByte(']')
+			}
+		}
+		return b.String()
+	}
+
+	// The longest prefix which can be completed by appending closers.
+	good, goodClosers := 0, ""
+	mark := func(pos int) {
+		good, goodClosers = pos, closers()
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			i++
+		case '{', '[':
+			stack = append(stack, frame{object: c == '{', expectKey: c == '{'})
+			i++
+			mark(i)
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			i++
+			mark(i)
+		case ',':
+			if n := len(stack); n > 0 && stack[n-1].object {
+				stack[n-1].expectKey = true
+			}
+			i++
+		case ':':
+			if n := len(stack); n > 0 {
+				stack[n-1].expectKey = false
+			}
+			i++
+		case '"':
+			isKey := len(stack) > 0 && stack[len(stack)-1].object && stack[len(stack)-1].expectKey
+			end := stringEnd(s, i+1)
+			if end < 0 {
+				if isKey {
+					return completed(s, good, goodClosers)
+				}
+				return s[:i] + closeString(s[i:]) + closers()
+			}
+			i = end
+			if !isKey {
+				mark(i)
+			}
+		default:
+			start := i
+			for i < len(s) && !strings.ContainsRune(" \t\n\r,:]}", rune(s[i])) {
+				i++
+			}
+			if !json.Valid([]byte(s[start:i])) {
+				return completed(s, good, goodClosers)
+			}
+			// A number at the very end of the input may still be growing, but it
+			// is a valid value as it stands.
+			mark(i)
+		}
+	}
+	return completed(s, good, goodClosers)
+}
+
+// Unmarshal decodes the completed prefix of a JSON document into v.
+func Unmarshal(data string, v any) error {
+	completed := Complete(data)
+	if completed == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(completed), v)
+}
+
+func completed(s string, good int, closers string) string {
+	if good == 0 {
+		return ""
+	}
+	return s[:good] + closers
+}
+
+// stringEnd returns the index just past the closing quote of a string whose
+// contents start at i, or -1 if the string is unterminated.
+func stringEnd(s string, i int) int {
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			i += 2
+		case '"':
+			return i + 1
+		default:
+			i++
+		}
+	}
+	return -1
+}
+
+// closeString terminates an unterminated string, dropping an incomplete escape
+// sequence at its end.
+func closeString(s string) string {
+	if i := strings.LastIndexByte(s, '\\'); i >= 0 {
+		// Count the run of backslashes ending at i to know whether the last one
+		// starts an escape or is itself escaped.
+		n := 0
+		for j := i; j >= 0 && s[j] == '\\'; j-- {
+			n++
+		}
+		if n%2 == 1 {
+			rest := s[i+1:]
+			switch {
+			case rest == "":
+				s = s[:i]
+			case rest[0] == 'u' && len(rest) < 5:
+				s = s[:i]
+			}
+		}
+	}
+	return s + `"`
+}
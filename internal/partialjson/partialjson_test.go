@@ -0,0 +1,46 @@
+package partialjson
+
+import "testing"
+
+func TestComplete(t *testing.T) {
+	cases := map[string]string{
+		``:                                   ``,
+		`{`:                                  `{}`,
+		`{"na`:                               `{}`,
+		`{"name"`:                            `{}`,
+		`{"name":`:                           `{}`,
+		`{"name": "Ada`:                      `{"name": "Ada"}`,
+		`{"name": "Ada",`:                    `{"name": "Ada"}`,
+		`{"name": "Ada", "tags": ["a", "b`:   `{"name": "Ada", "tags": ["a", "b"]}`,
+		`{"year": 19`:                        `{"year": 19}`,
+		`{"year": 1.`:                        `{}`,
+		`{"year": -`:                         `{}`,
+		`{"ok": tr`:                          `{}`,
+		`{"ok": true, "n": nul`:              `{"ok": true}`,
+		`{"a": {"b": [1, {"c": "d`:           `{"a": {"b": [1, {"c": "d"}]}}`,
+		`{"s": "line\`:                       `{"s": "line"}`,
+		`{"s": "quote \"`:                    `{"s": "quote \""}`,
+		`{"s": "\u00`:                        `{"s": ""}`,
+		`{"s": "back\\`:                      `{"s": "back\\"}`,
+		`{"done": [1, 2]}`:                   `{"done": [1, 2]}`,
+		`{"items": [{"a": 1}, {"b": 2}], "c`: `{"items": [{"a": 1}, {"b": 2}]}`,
+	}
+	for input, expected := range cases {
+		if got := Complete(input); got != expected {
+			t.Errorf("Complete(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	var v struct {
+		Name  string   `json:"name"`
+		Facts []string `json:"facts"`
+	}
+	if err := Unmarshal(`{"name": "ENIAC", "facts": ["first", "big comp`, &v); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if v.Name != "ENIAC" || len(v.Facts) != 2 || v.Facts[1] != "big comp" {
+		t.Fatalf("unexpected value %+v", v)
+	}
+}
@@ -0,0 +1,142 @@
+// Package polling implements the loop shared by the PollStatus helpers.
+package polling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+// ErrTimeout is returned when a resource is still pending after the maximum
+// wait set with [option.WithPollMaxWait].
+var ErrTimeout = errors.New("polling timed out")
+
+// DefaultInterval is used when neither the caller nor the API set an interval.
+const DefaultInterval = time.Second
+
+// Target describes the resource to poll.
+type Target[T any] struct {
+	// Name is used in error messages, e.g. "batch".
+	Name string
+	// Get fetches the resource with the given request options.
+	Get func(opts ...option.RequestOption) (*T, error)
+	// Status returns the status of the resource and whether it is still pending.
+	// ok is false when the status is unknown to the helper.
+	Status func(res *T) (status string, pending bool, ok bool)
+}
+
+// Options returns the headers sent by the polling helpers.
+func Options(interval time.Duration) []option.RequestOption {
+	options := []option.RequestOption{option.WithHeader("X-Stainless-Poll-Helper", "true")}
+	if interval > 0 {
+		options = append(options, option.WithHeader("X-Stainless-Poll-Interval", strconv.FormatInt(interval.Milliseconds(), 10)))
+	}
+	return options
+}
+
+// SuggestedInterval returns the interval set by the openai-poll-after-ms header
+// of raw, or [DefaultInterval].
+func SuggestedInterval(raw *http.Response) time.Duration {
+	if raw != nil {
+		if ms, err := strconv.Atoi(raw.Header.Get("openai-poll-after-ms")); err == nil && ms >= 0 {
+			return time.Duration(ms) * time.Millisecond
+		}
+	}
+	return DefaultInterval
+}
+
+// Poll fetches the target until it is no longer pending and returns it.
+//
+// The polling configuration is read from serviceOpts and opts, only opts are
+// passed to Get. A positive pollIntervalMs takes precedence over the configured
+// interval, which takes precedence over the interval suggested by the API.
+//
+// Poll waits for ctx while sleeping. When the maximum wait is exceeded, the last
+// fetched resource is returned with an error wrapping [ErrTimeout].
+func Poll[T any](ctx context.Context, target Target[T], pollIntervalMs int, serviceOpts []option.RequestOption, opts []option.RequestOption) (*T, error) {
+	cfg, err := requestconfig.PreRequestOptions(slices.Concat(serviceOpts, opts)...)
+	if err != nil {
+		return nil, err
+	}
+	config := cfg.Polling
+
+	interval := time.Duration(pollIntervalMs) * time.Millisecond
+	if interval <= 0 {
+		interval = config.Interval
+	}
+
+	var raw *http.Response
+	opts = slices.Concat(opts, Options(interval), []option.RequestOption{option.WithResponseInto(&raw)})
+
+	start := time.Now()
+	var wait time.Duration
+	for attempt := 1; ; attempt++ {
+		res, err := target.Get(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%s poll: received %w", target.Name, err)
+		}
+
+		status, pending, ok := target.Status(res)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s status during polling: received %s", target.Name, status)
+		}
+
+		elapsed := time.Since(start)
+		if !pending {
+			progress(config, status, attempt, elapsed, 0)
+			return res, nil
+		}
+
+		wait = next(config, interval, wait, raw)
+		if config.MaxWait > 0 {
+			if elapsed >= config.MaxWait {
+				progress(config, status, attempt, elapsed, 0)
+				return res, fmt.Errorf("%s poll: still %s after %s: %w", target.Name, status, elapsed.Round(time.Millisecond), ErrTimeout)
+			}
+			wait = min(wait, config.MaxWait-elapsed)
+		}
+		progress(config, status, attempt, elapsed, wait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// next returns the wait before the next request, given the previous one.
+func next(config requestconfig.PollingConfig, interval time.Duration, prev time.Duration, raw *http.Response) time.Duration {
+	base := interval
+	if base <= 0 {
+		base = SuggestedInterval(raw)
+	}
+	if prev == 0 || config.Multiplier <= 1 {
+		return base
+	}
+	wait := max(base, time.Duration(float64(prev)*config.Multiplier))
+	if config.MaxInterval > 0 {
+		wait = min(wait, max(base, config.MaxInterval))
+	}
+	return wait
+}
+
+func progress(config requestconfig.PollingConfig, status string, attempt int, elapsed time.Duration, next time.Duration) {
+	if config.OnProgress != nil {
+		config.OnProgress(requestconfig.PollProgress{
+			Status:  status,
+			Attempt: attempt,
+			Elapsed: elapsed,
+			Next:    next,
+		})
+	}
+}
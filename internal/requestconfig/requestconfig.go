@@ -6,22 +6,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"math"
-	"math/rand"
 	"mime"
 	"net/http"
 	"net/url"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/Nordlys-Labs/openai-go/v3/internal"
 	"github.com/Nordlys-Labs/openai-go/v3/internal/apierror"
 	"github.com/Nordlys-Labs/openai-go/v3/internal/apiform"
 	"github.com/Nordlys-Labs/openai-go/v3/internal/apiquery"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/respmeta"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/streamresume"
 	"github.com/tidwall/gjson"
 )
 
@@ -103,15 +105,25 @@ func NewRequestConfig(ctx context.Context, method string, u string, body any, ds
 		hasSerializationFunc = true
 	}
 	if body, ok := body.(apiform.Marshaler); ok {
-		var (
-			content []byte
-			err     error
-		)
-		content, contentType, err = body.MarshalMultipart()
-		if err != nil {
-			return nil, err
+		// The forms of the params are streamed when they can be, so that their
+		// files are not buffered in memory.
+		if streamer, ok := body.(apiform.StreamMarshaler); ok {
+			stream, err := streamer.MarshalMultipartStream()
+			if err != nil {
+				return nil, err
+			}
+			reader, contentType = stream, stream.ContentType()
+		} else {
+			var (
+				content []byte
+				err     error
+			)
+			content, contentType, err = body.MarshalMultipart()
+			if err != nil {
+				return nil, err
+			}
+			reader = bytes.NewBuffer(content)
 		}
-		reader = bytes.NewBuffer(content)
 		hasSerializationFunc = true
 	}
 	if body, ok := body.(apiquery.Queryer); ok {
@@ -202,11 +214,18 @@ type HTTPDoer interface {
 // Editing the variables inside RequestConfig directly is unstable api. Prefer
 // composing the RequestOption instead if possible.
 type RequestConfig struct {
-	MaxRetries     int
+	MaxRetries int
+	// RetryPolicy decides which attempts are retried, see [BackoffRetryPolicy]
+	// for the default one.
+	RetryPolicy    RetryPolicy
 	RequestTimeout time.Duration
-	Context        context.Context
-	Request        *http.Request
-	BaseURL        *url.URL
+	// StreamIdleTimeout is the longest a streamed response, with the
+	// text/event-stream content type, may receive nothing before it fails with a
+	// [apierror.StreamIdleTimeoutError].
+	StreamIdleTimeout time.Duration
+	Context           context.Context
+	Request           *http.Request
+	BaseURL           *url.URL
 	// DefaultBaseURL will be used if BaseURL is not explicitly overridden using
 	// WithBaseURL.
 	DefaultBaseURL *url.URL
@@ -217,6 +236,21 @@ type RequestConfig struct {
 	Organization   string
 	Project        string
 	WebhookSecret  string
+	// WebhookSecrets are all the secrets accepted when verifying webhooks, the
+	// current one first, while a secret is rotated. WebhookSecret is used when empty.
+	WebhookSecrets []string
+	// Polling configures the PollStatus helpers. It does not affect requests.
+	Polling PollingConfig
+	// Upload configures the UploadFile helper. It does not affect requests.
+	Upload UploadConfig
+	// StreamReconnects is the number of times a background response stream is
+	// resumed after its connection drops.
+	StreamReconnects int
+	// PagePrefetch is the number of pages an auto pager fetches in the
+	// background, ahead of the page being read. It does not affect requests.
+	PagePrefetch int
+	// Telemetry configures the logs, spans and metrics of requests.
+	Telemetry Telemetry
 	// If ResponseBodyInto not nil, then we will attempt to deserialize into
 	// ResponseBodyInto. If Destination is a []byte, then it will return the body as
 	// is.
@@ -224,7 +258,73 @@ type RequestConfig struct {
 	// ResponseInto copies the \*http.Response of the corresponding request into the
 	// given address
 	ResponseInto **http.Response
-	Body         io.Reader
+	// ResponseMetaInto copies the metadata of the final response into the given
+	// address.
+	ResponseMetaInto *respmeta.Meta
+	Body             io.Reader
+}
+
+// PollingConfig configures how the polling helpers wait between requests.
+type PollingConfig struct {
+	// Interval is the wait between two requests. When zero, the interval
+	// suggested by the openai-poll-after-ms header is used, or one second.
+	Interval time.Duration
+	// Multiplier increases the interval after every request. Values below 1
+	// disable the backoff.
+	Multiplier float64
+	// MaxInterval caps the interval increased by Multiplier. Zero means no cap.
+	MaxInterval time.Duration
+	// MaxWait is the maximum time spent polling. Zero means no limit.
+	MaxWait time.Duration
+	// OnProgress is called after every request.
+	OnProgress func(PollProgress)
+}
+
+// PollProgress describes the state of a resource being polled.
+type PollProgress struct {
+	// The status of the resource, as returned by the last request.
+	Status string
+	// The number of requests made so far.
+	Attempt int
+	// The time spent polling so far.
+	Elapsed time.Duration
+	// The wait before the next request, or zero when the resource reached a
+	// terminal status.
+	Next time.Duration
+}
+
+// UploadConfig configures how the UploadFile helper splits and sends a file.
+type UploadConfig struct {
+	// Filename is the name of the uploaded file. When empty, the name of the
+	// reader is used if it has one.
+	Filename string
+	// PartSize is the size of every part but the last. When zero, the maximum
+	// part size of 64 MB is used.
+	PartSize int64
+	// Concurrency is the number of parts uploaded at the same time. When zero,
+	// 4 parts are uploaded at the same time.
+	Concurrency int
+	// PartRetries is the number of additional attempts made for a failed part.
+	// When zero, a part is retried twice. A negative value disables the retries.
+	PartRetries int
+	// MD5 sends the MD5 checksum of the file when completing the upload.
+	MD5 bool
+	// OnProgress is called every time a part is uploaded.
+	OnProgress func(UploadProgress)
+}
+
+// UploadProgress describes the state of a file being uploaded.
+type UploadProgress struct {
+	// The ID of the Upload.
+	UploadID string
+	// The number of bytes uploaded so far.
+	BytesUploaded int64
+	// The size of the file.
+	TotalBytes int64
+	// The number of parts uploaded so far.
+	PartsUploaded int
+	// The number of parts of the file.
+	TotalParts int
 }
 
 // middleware is exactly the same type as the Middleware type found in the [option] package,
@@ -241,33 +341,6 @@ func applyMiddleware(middleware middleware, next middlewareNext) middlewareNext
 	}
 }
 
-func shouldRetry(req *http.Request, res *http.Response) bool {
-	// If there is no way to recover the Body, then we shouldn't retry.
-	if req.Body != nil && req.GetBody == nil {
-		return false
-	}
-
-	// If there is no response, that indicates that there is a connection error
-	// so we retry the request.
-	if res == nil {
-		return true
-	}
-
-	// If the header explicitly wants a retry behavior, respect that over the
-	// http status code.
-	if res.Header.Get("x-should-retry") == "true" {
-		return true
-	}
-	if res.Header.Get("x-should-retry") == "false" {
-		return false
-	}
-
-	return res.StatusCode == http.StatusRequestTimeout ||
-		res.StatusCode == http.StatusConflict ||
-		res.StatusCode == http.StatusTooManyRequests ||
-		res.StatusCode >= http.StatusInternalServerError
-}
-
 func parseRetryAfterHeader(resp *http.Response) (time.Duration, bool) {
 	if resp == nil {
 		return 0, false
@@ -339,6 +412,12 @@ func isBeforeContextDeadline(t time.Time, ctx context.Context) bool {
 type bodyWithTimeout struct {
 	stop func() // stops the time.Timer waiting to cancel the request
 	rc   io.ReadCloser
+
+	// The context bound by the request timeout, to report its expiry as a
+	// [apierror.TimeoutError].
+	ctx     context.Context
+	req     *http.Request
+	timeout time.Duration
 }
 
 func (b *bodyWithTimeout) Read(p []byte) (n int, err error) {
@@ -349,6 +428,10 @@ func (b *bodyWithTimeout) Read(p []byte) (n int, err error) {
 	if err == io.EOF {
 		return n, err
 	}
+	// The context of the caller is intact, so the request timeout expired.
+	if b.ctx != nil && errors.Is(b.ctx.Err(), context.DeadlineExceeded) && b.req.Context().Err() == nil {
+		return n, &apierror.TimeoutError{Request: b.req, Timeout: b.timeout}
+	}
 	return n, err
 }
 
@@ -358,23 +441,44 @@ func (b *bodyWithTimeout) Close() error {
 	return err
 }
 
-func retryDelay(res *http.Response, retryCount int) time.Duration {
-	// If the API asks us to wait a certain amount of time (and it's a reasonable amount),
-	// just do what it says.
+// bodyWithIdleTimeout is an io.ReadCloser which fails when a read waits for
+// longer than the idle timeout. Only the time spent waiting for the server is
+// counted, not the time between reads. It wraps an existing io.ReadCloser.
+type bodyWithIdleTimeout struct {
+	rc       io.ReadCloser
+	req      *http.Request
+	timeout  time.Duration
+	timer    *time.Timer
+	timedOut atomic.Bool
+}
 
-	if retryAfterDelay, ok := parseRetryAfterHeader(res); ok && 0 <= retryAfterDelay && retryAfterDelay < time.Minute {
-		return retryAfterDelay
-	}
+func newBodyWithIdleTimeout(rc io.ReadCloser, req *http.Request, timeout time.Duration) *bodyWithIdleTimeout {
+	b := &bodyWithIdleTimeout{rc: rc, req: req, timeout: timeout}
+	// Closing the body interrupts the read waiting for the server. The timer
+	// only runs during a read.
+	b.timer = time.AfterFunc(timeout, func() {
+		b.timedOut.Store(true)
+		b.rc.Close()
+	})
+	b.timer.Stop()
+	return b
+}
 
-	maxDelay := 8 * time.Second
-	delay := time.Duration(0.5 * float64(time.Second) * math.Pow(2, float64(retryCount)))
-	if delay > maxDelay {
-		delay = maxDelay
+func (b *bodyWithIdleTimeout) Read(p []byte) (n int, err error) {
+	if b.timedOut.Load() {
+		return 0, &apierror.StreamIdleTimeoutError{Request: b.req, Timeout: b.timeout}
 	}
+	b.timer.Reset(b.timeout)
+	n, err = b.rc.Read(p)
+	if !b.timer.Stop() && b.timedOut.Load() {
+		return n, &apierror.StreamIdleTimeoutError{Request: b.req, Timeout: b.timeout}
+	}
+	return n, err
+}
 
-	jitter := rand.Int63n(int64(delay / 4))
-	delay -= time.Duration(jitter)
-	return delay
+func (b *bodyWithIdleTimeout) Close() error {
+	b.timer.Stop()
+	return b.rc.Close()
 }
 
 func (cfg *RequestConfig) Execute() (err error) {
@@ -405,6 +509,15 @@ func (cfg *RequestConfig) Execute() (err error) {
 				return io.NopCloser(body), err
 			}
 			cfg.Request.Body, _ = cfg.Request.GetBody()
+		case *apiform.Stream:
+			cfg.Request.ContentLength = body.Len()
+			if body.Rewindable() {
+				cfg.Request.GetBody = body.Reader
+			}
+			cfg.Request.Body, err = body.Reader()
+			if err != nil {
+				return err
+			}
 		default:
 			if rc, ok := body.(io.ReadCloser); ok {
 				cfg.Request.Body = rc
@@ -414,10 +527,14 @@ func (cfg *RequestConfig) Execute() (err error) {
 		}
 	}
 
+	call := cfg.startCall()
+	defer func() { call.end(err) }()
+
 	handler := cfg.HTTPClient.Do
 	if cfg.CustomHTTPDoer != nil {
 		handler = cfg.CustomHTTPDoer.Do
 	}
+	handler = transportErrors(handler)
 	for i := len(cfg.Middlewares) - 1; i >= 0; i -= 1 {
 		handler = applyMiddleware(cfg.Middlewares[i], handler)
 	}
@@ -425,12 +542,22 @@ func (cfg *RequestConfig) Execute() (err error) {
 	// Don't send the current retry count in the headers if the caller modified the header defaults.
 	shouldSendRetryCount := cfg.Request.Header.Get("X-Stainless-Retry-Count") == "0"
 
+	policy := cfg.RetryPolicy
+	if policy == nil {
+		policy = BackoffRetryPolicy{}
+	}
+
 	var res *http.Response
 	var cancel context.CancelFunc
+	var timeoutCtx context.Context
+	var retries int
+	start := time.Now()
 	for retryCount := 0; retryCount <= cfg.MaxRetries; retryCount += 1 {
+		retries = retryCount
 		ctx := cfg.Request.Context()
 		if cfg.RequestTimeout != time.Duration(0) && isBeforeContextDeadline(time.Now().Add(cfg.RequestTimeout), ctx) {
 			ctx, cancel = context.WithTimeout(ctx, cfg.RequestTimeout)
+			timeoutCtx = ctx
 			defer func() {
 				// The cancel function is nil if it was handed off to be handled in a different scope.
 				if cancel != nil {
@@ -444,11 +571,45 @@ func (cfg *RequestConfig) Execute() (err error) {
 			req.Header.Set("X-Stainless-Retry-Count", strconv.Itoa(retryCount))
 		}
 
+		req, attempt := call.startAttempt(req, retryCount)
 		res, err = handler(req)
+		attempt.end(res, err)
 		if ctx != nil && ctx.Err() != nil {
+			// The context of the caller is intact, so the request timeout expired.
+			if cfg.Request.Context().Err() == nil {
+				return &apierror.TimeoutError{Request: cfg.Request, Timeout: cfg.RequestTimeout}
+			}
 			return ctx.Err()
 		}
-		if !shouldRetry(cfg.Request, res) || retryCount >= cfg.MaxRetries {
+
+		// A body which cannot be sent again leaves no retries, so the policy
+		// does not decide on a retry which would not be made.
+		remaining := cfg.MaxRetries - retryCount
+		if cfg.Request.Body != nil && cfg.Request.GetBody == nil {
+			remaining = 0
+		}
+		retryAttempt := RetryAttempt{
+			Request:   req,
+			Response:  res,
+			Err:       err,
+			Attempt:   retryCount,
+			Remaining: remaining,
+			Elapsed:   time.Since(start),
+		}
+		if res != nil && res.StatusCode >= 400 && res.Body != nil {
+			// Buffer the error so the policy can inspect it, and it can still be
+			// read afterwards.
+			retryAttempt.Body, _ = io.ReadAll(res.Body)
+			res.Body.Close()
+			res.Body = io.NopCloser(bytes.NewReader(retryAttempt.Body))
+		}
+		retry, delay := policy.Retry(retryAttempt)
+		if !retry || retryCount >= cfg.MaxRetries {
+			break
+		}
+
+		// If there is no way to recover the Body, then we shouldn't retry.
+		if cfg.Request.Body != nil && cfg.Request.GetBody == nil {
 			break
 		}
 
@@ -470,7 +631,14 @@ func (cfg *RequestConfig) Execute() (err error) {
 			res.Body.Close()
 		}
 
-		time.Sleep(retryDelay(res, retryCount))
+		attempt.retry(res, err, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-cfg.Request.Context().Done():
+			timer.Stop()
+			return cfg.Request.Context().Err()
+		case <-timer.C:
+		}
 	}
 
 	// Save *http.Response if it is requested to, even if there was an error making the request. This is
@@ -482,9 +650,15 @@ func (cfg *RequestConfig) Execute() (err error) {
 	if responseBodyInto, ok := cfg.ResponseBodyInto.(**http.Response); ok {
 		*responseBodyInto = res
 	}
+	if cfg.ResponseMetaInto != nil && res != nil {
+		*cfg.ResponseMetaInto = respmeta.FromResponse(res)
+		cfg.ResponseMetaInto.Retries = retries
+	}
 
 	// If there was a connection error in the final request or any other transport error,
-	// return that early without trying to coerce into an APIError.
+	// return that early without trying to coerce into an APIError. The errors of the
+	// transport are already a [apierror.ConnectionError], and the errors of the
+	// middlewares are returned as is.
 	if err != nil {
 		return err
 	}
@@ -499,6 +673,7 @@ func (cfg *RequestConfig) Execute() (err error) {
 		// If there is an APIError, re-populate the response body so that debugging
 		// utilities can conveniently dump the response without issue.
 		res.Body = io.NopCloser(bytes.NewBuffer(contents))
+		call.response(res, contents)
 
 		// Load the contents into the error format if it is provided.
 		aerr := apierror.Error{Request: cfg.Request, Response: res, StatusCode: res.StatusCode}
@@ -507,6 +682,9 @@ func (cfg *RequestConfig) Execute() (err error) {
 		if err != nil {
 			return err
 		}
+		aerr.Meta = respmeta.FromResponse(res)
+		aerr.Meta.Retries = retries
+		aerr.RequestID = aerr.Meta.RequestID
 		return &aerr
 	}
 
@@ -515,10 +693,33 @@ func (cfg *RequestConfig) Execute() (err error) {
 		// We aren't reading the response body in this scope, but whoever is will need the
 		// cancel func from the context to observe request timeouts.
 		// Put the cancel function in the response body so it can be handled elsewhere.
-		if cancel != nil {
-			res.Body = &bodyWithTimeout{rc: res.Body, stop: cancel}
+		// Upgraded connections are not bound by the request timeout, which only
+		// applies to the handshake.
+		// Only event streams are bound by the idle timeout, not downloads.
+		var idle func(io.ReadCloser) io.ReadCloser
+		if mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); cfg.StreamIdleTimeout > 0 && mediaType == "text/event-stream" {
+			idle = func(rc io.ReadCloser) io.ReadCloser {
+				return newBodyWithIdleTimeout(rc, cfg.Request, cfg.StreamIdleTimeout)
+			}
+		}
+		// The idle timeout applies to every connection of a resumable stream, so
+		// an idle connection is resumed like a dropped one.
+		if cfg.StreamReconnects > 0 {
+			resumeCtx := cfg.Request.Context()
+			if timeoutCtx != nil {
+				resumeCtx = timeoutCtx
+			}
+			res.Body = streamresume.Wrap(handler, cfg.Request.WithContext(resumeCtx), res, cfg.StreamReconnects, idle)
+		} else if idle != nil {
+			res.Body = idle(res.Body)
+		}
+		if cancel != nil && res.StatusCode != http.StatusSwitchingProtocols {
+			res.Body = &bodyWithTimeout{rc: res.Body, stop: cancel, ctx: timeoutCtx, req: cfg.Request, timeout: cfg.RequestTimeout}
 			cancel = nil
 		}
+		if intoCustomResponseBody {
+			call.stream(res)
+		}
 		return nil
 	}
 
@@ -527,6 +728,7 @@ func (cfg *RequestConfig) Execute() (err error) {
 	if err != nil {
 		return fmt.Errorf("error reading response body: %w", err)
 	}
+	call.response(res, contents)
 
 	// If we are not json, return plaintext
 	contentType := res.Header.Get("content-type")
@@ -561,6 +763,18 @@ func (cfg *RequestConfig) Execute() (err error) {
 	return nil
 }
 
+// transportErrors wraps the errors of the HTTP client in a
+// [apierror.ConnectionError], before the middlewares see them.
+func transportErrors(do middlewareNext) middlewareNext {
+	return func(req *http.Request) (*http.Response, error) {
+		res, err := do(req)
+		if err != nil {
+			err = &apierror.ConnectionError{Request: req, Err: err}
+		}
+		return res, err
+	}
+}
+
 func ExecuteNewRequest(ctx context.Context, method string, u string, body any, dst any, opts ...RequestOption) error {
 	cfg, err := NewRequestConfig(ctx, method, u, body, dst, opts...)
 	if err != nil {
@@ -582,17 +796,25 @@ func (cfg *RequestConfig) Clone(ctx context.Context) *RequestConfig {
 		return nil
 	}
 	new := &RequestConfig{
-		MaxRetries:     cfg.MaxRetries,
-		RequestTimeout: cfg.RequestTimeout,
-		Context:        ctx,
-		Request:        req,
-		BaseURL:        cfg.BaseURL,
-		HTTPClient:     cfg.HTTPClient,
-		Middlewares:    cfg.Middlewares,
-		APIKey:         cfg.APIKey,
-		Organization:   cfg.Organization,
-		Project:        cfg.Project,
-		WebhookSecret:  cfg.WebhookSecret,
+		MaxRetries:        cfg.MaxRetries,
+		RequestTimeout:    cfg.RequestTimeout,
+		StreamIdleTimeout: cfg.StreamIdleTimeout,
+		Context:           ctx,
+		Request:           req,
+		BaseURL:           cfg.BaseURL,
+		HTTPClient:        cfg.HTTPClient,
+		Middlewares:       cfg.Middlewares,
+		APIKey:            cfg.APIKey,
+		Organization:      cfg.Organization,
+		Project:           cfg.Project,
+		WebhookSecret:     cfg.WebhookSecret,
+		WebhookSecrets:    cfg.WebhookSecrets,
+		Polling:           cfg.Polling,
+		Upload:            cfg.Upload,
+		StreamReconnects:  cfg.StreamReconnects,
+		PagePrefetch:      cfg.PagePrefetch,
+		RetryPolicy:       cfg.RetryPolicy,
+		Telemetry:         cfg.Telemetry,
 	}
 
 	return new
@@ -0,0 +1,151 @@
+package requestconfig
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// RetryPolicy decides whether a request is retried, and how long to wait before
+// the next attempt.
+type RetryPolicy interface {
+	// Retry is called after every attempt of a request, including successful
+	// ones, unless the context of the request is done. A request which has no
+	// retries left is not retried even if Retry returns true.
+	Retry(attempt RetryAttempt) (retry bool, delay time.Duration)
+}
+
+// RetryAttempt describes an attempt of a request.
+type RetryAttempt struct {
+	// The request which was sent.
+	Request *http.Request
+	// The response, or nil when the request could not be sent.
+	Response *http.Response
+	// The body of an error response, when the status code is at least 400.
+	Body []byte
+	// The error returned by the HTTP client, such as a connection error.
+	Err error
+	// The number of retries made before this attempt, 0 for the first one.
+	Attempt int
+	// The number of retries left after this attempt, 0 when the body of the
+	// request cannot be sent again.
+	Remaining int
+	// The time elapsed since the first attempt was sent.
+	Elapsed time.Duration
+}
+
+// StatusCode returns the status code of the response, or 0 when there is none.
+func (a RetryAttempt) StatusCode() int {
+	if a.Response == nil {
+		return 0
+	}
+	return a.Response.StatusCode
+}
+
+// ErrorCode returns the `error.code` of an error response, such as
+// "insufficient_quota".
+func (a RetryAttempt) ErrorCode() string {
+	return gjson.GetBytes(a.Body, "error.code").String()
+}
+
+// BackoffRetryPolicy is a [RetryPolicy] with an exponential backoff. Its zero
+// value is the default policy of the client.
+type BackoffRetryPolicy struct {
+	// ShouldRetry reports whether an attempt should be retried. Defaults to
+	// [DefaultShouldRetry].
+	ShouldRetry func(attempt RetryAttempt) bool
+	// BaseDelay is the wait before the first retry, doubled for every retry.
+	// Defaults to 0.5 seconds.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between two attempts. Defaults to 8 seconds.
+	MaxDelay time.Duration
+	// Jitter is the fraction of the wait which is randomly removed, between 0
+	// and 1. Defaults to 0.25, a negative value disables the jitter.
+	Jitter float64
+	// MaxElapsed caps the time spent retrying a request. A retry which would
+	// start after it is not made. Zero means no limit.
+	MaxElapsed time.Duration
+	// IgnoreRetryAfter makes the policy ignore the Retry-After headers sent by
+	// the API, which are otherwise honored when shorter than a minute.
+	IgnoreRetryAfter bool
+	// OnAttempt is called after every attempt with the decision of the policy.
+	OnAttempt func(attempt RetryAttempt, retry bool, delay time.Duration)
+}
+
+func (p BackoffRetryPolicy) Retry(attempt RetryAttempt) (retry bool, delay time.Duration) {
+	shouldRetry := p.ShouldRetry
+	if shouldRetry == nil {
+		shouldRetry = DefaultShouldRetry
+	}
+	retry = attempt.Remaining > 0 && shouldRetry(attempt)
+	if retry {
+		delay = p.delay(attempt)
+		if p.MaxElapsed > 0 && attempt.Elapsed+delay > p.MaxElapsed {
+			retry, delay = false, 0
+		}
+	}
+	if p.OnAttempt != nil {
+		p.OnAttempt(attempt, retry, delay)
+	}
+	return retry, delay
+}
+
+func (p BackoffRetryPolicy) delay(attempt RetryAttempt) time.Duration {
+	// If the API asks us to wait a certain amount of time (and it's a reasonable amount),
+	// just do what it says.
+	if !p.IgnoreRetryAfter {
+		if retryAfterDelay, ok := parseRetryAfterHeader(attempt.Response); ok && 0 <= retryAfterDelay && retryAfterDelay < time.Minute {
+			return retryAfterDelay
+		}
+	}
+
+	baseDelay, maxDelay, jitter := p.BaseDelay, p.MaxDelay, p.Jitter
+	if baseDelay <= 0 {
+		baseDelay = 500 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 8 * time.Second
+	}
+	if jitter == 0 {
+		jitter = 0.25
+	}
+
+	delay := time.Duration(float64(baseDelay) * math.Pow(2, float64(attempt.Attempt)))
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if jitter > 0 && delay > 0 {
+		delay -= time.Duration(rand.Int63n(int64(float64(delay)*min(jitter, 1)) + 1))
+	}
+	return delay
+}
+
+// DefaultShouldRetry retries connection errors, and responses with the status
+// codes 408, 409, 429 and 5xx, unless the API says otherwise with the
+// x-should-retry header.
+func DefaultShouldRetry(attempt RetryAttempt) bool {
+	res := attempt.Response
+
+	// If there is no response, that indicates that there is a connection error
+	// so we retry the request.
+	if res == nil {
+		return attempt.Err != nil
+	}
+
+	// If the header explicitly wants a retry behavior, respect that over the
+	// http status code.
+	if res.Header.Get("x-should-retry") == "true" {
+		return true
+	}
+	if res.Header.Get("x-should-retry") == "false" {
+		return false
+	}
+
+	return res.StatusCode == http.StatusRequestTimeout ||
+		res.StatusCode == http.StatusConflict ||
+		res.StatusCode == http.StatusTooManyRequests ||
+		res.StatusCode >= http.StatusInternalServerError
+}
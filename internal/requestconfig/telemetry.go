@@ -0,0 +1,525 @@
+package requestconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// Tracer starts the spans of requests. It follows the tracer of OpenTelemetry,
+// which can be adapted in a few lines.
+type Tracer interface {
+	// Start starts a span, as a child of the span in ctx if there is one.
+	Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, Span)
+}
+
+// Span is a span started by a [Tracer].
+type Span interface {
+	SetAttributes(attrs ...slog.Attr)
+	RecordError(err error)
+	End()
+}
+
+// Meter records the metrics of requests, such as the histograms of OpenTelemetry.
+type Meter interface {
+	Record(ctx context.Context, name string, value float64, attrs ...slog.Attr)
+}
+
+// Telemetry configures the logs, spans and metrics of requests.
+type Telemetry struct {
+	Logger *slog.Logger
+	Tracer Tracer
+	Meter  Meter
+	// The request and response headers to record. Credentials are always
+	// redacted.
+	Headers []string
+	// The maximum number of bytes of JSON request and response bodies to record.
+	// Bodies are not recorded when 0.
+	BodyLimit int
+	// Redact rewrites the recorded headers and bodies. The key is the attribute
+	// name, such as "http.request.header.x-custom" or "http.response.body".
+	Redact func(key string, value string) string
+}
+
+func (t Telemetry) enabled() bool {
+	return t.Logger != nil || t.Tracer != nil || t.Meter != nil
+}
+
+// The names of the metrics recorded for generative AI calls, from the semantic
+// conventions of OpenTelemetry.
+const (
+	MetricOperationDuration = "gen_ai.client.operation.duration"
+	MetricTokenUsage        = "gen_ai.client.token.usage"
+	MetricTimeToFirstChunk  = "gen_ai.client.operation.time_to_first_chunk"
+)
+
+const redacted = "REDACTED"
+
+var (
+	credentialHeaders = []string{"Authorization", "Api-Key", "Cookie", "Set-Cookie"}
+	apiKeyPattern     = regexp.MustCompile(`sk-[A-Za-z0-9_\-]{8,}`)
+)
+
+// call observes a request and its attempts. A nil call observes nothing.
+type call struct {
+	t       Telemetry
+	ctx     context.Context
+	span    Span
+	start   time.Time
+	attrs   []slog.Attr
+	metrics []slog.Attr
+	generic bool
+
+	attempts  int
+	res       *http.Response
+	body      []byte
+	summary   summary
+	streaming bool
+	once      sync.Once
+}
+
+// startCall starts observing the request of cfg, whose context then carries the
+// span of the call.
+func (cfg *RequestConfig) startCall() *call {
+	if !cfg.Telemetry.enabled() {
+		return nil
+	}
+	req := cfg.Request
+	var body []byte
+	if req.GetBody != nil && isJSON(req.Header) {
+		if rc, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+
+	c := &call{t: cfg.Telemetry, ctx: req.Context(), start: time.Now()}
+	operation := operationName(req)
+	model := gjson.GetBytes(body, "model").String()
+	c.generic = operation == ""
+	if !c.generic {
+		c.metrics = []slog.Attr{
+			slog.String("gen_ai.provider.name", "openai"),
+			slog.String("gen_ai.operation.name", operation),
+			slog.String("gen_ai.request.model", model),
+			slog.String("server.address", req.URL.Hostname()),
+		}
+	}
+	c.attrs = append(c.attrs, c.metrics...)
+	c.attrs = append(c.attrs,
+		slog.String("http.request.method", req.Method),
+		slog.String("url.path", req.URL.Path),
+	)
+	request := gjson.ParseBytes(body)
+	for _, param := range []struct {
+		key   string
+		paths []string
+	}{
+		{"gen_ai.request.max_tokens", []string{"max_completion_tokens", "max_output_tokens", "max_tokens"}},
+		{"gen_ai.request.temperature", []string{"temperature"}},
+		{"gen_ai.request.top_p", []string{"top_p"}},
+	} {
+		if v := first(request, param.paths...); v.Exists() {
+			c.attrs = append(c.attrs, slog.Float64(param.key, v.Float()))
+		}
+	}
+	if stream := gjson.GetBytes(body, "stream"); stream.Bool() {
+		c.attrs = append(c.attrs, slog.Bool("gen_ai.request.stream", true))
+	}
+	c.attrs = append(c.attrs, c.headerAttrs("http.request.header.", req.Header)...)
+	c.attrs = append(c.attrs, c.bodyAttrs("http.request.body", body)...)
+
+	name := req.Method + " " + req.URL.Path
+	if !c.generic {
+		name = strings.TrimSpace(operation + " " + model)
+	}
+	if c.t.Tracer != nil {
+		c.ctx, c.span = c.t.Tracer.Start(c.ctx, name, c.attrs...)
+		cfg.Request = req.WithContext(c.ctx)
+	}
+	return c
+}
+
+// operationName returns the generative AI operation of a request, or "" for the
+// other requests.
+func operationName(req *http.Request) string {
+	if req.Method != http.MethodPost {
+		return ""
+	}
+	switch path := strings.TrimSuffix(req.URL.Path, "/"); {
+	case strings.HasSuffix(path, "/chat/completions"), strings.HasSuffix(path, "/responses"):
+		return "chat"
+	case strings.HasSuffix(path, "/completions"):
+		return "text_completion"
+	case strings.HasSuffix(path, "/embeddings"):
+		return "embeddings"
+	}
+	return ""
+}
+
+// attempt observes an attempt of a call.
+type attempt struct {
+	c     *call
+	ctx   context.Context
+	span  Span
+	n     int
+	start time.Time
+}
+
+// startAttempt starts observing an attempt, and returns the request carrying its
+// span.
+func (c *call) startAttempt(req *http.Request, n int) (*http.Request, *attempt) {
+	if c == nil {
+		return req, nil
+	}
+	c.attempts = n + 1
+	a := &attempt{c: c, ctx: req.Context(), n: n, start: time.Now()}
+	if c.t.Tracer != nil {
+		a.ctx, a.span = c.t.Tracer.Start(a.ctx, req.Method, slog.Int("http.request.resend_count", n))
+		req = req.WithContext(a.ctx)
+	}
+	return req, a
+}
+
+// end ends an attempt once its response headers are received.
+func (a *attempt) end(res *http.Response, err error) {
+	if a == nil {
+		return
+	}
+	attrs := []slog.Attr{slog.Int("http.request.resend_count", a.n), slog.Duration("duration", time.Since(a.start))}
+	if res != nil {
+		attrs = append(attrs, slog.Int("http.response.status_code", res.StatusCode))
+		if id := res.Header.Get("X-Request-Id"); id != "" {
+			attrs = append(attrs, slog.String("openai.request.id", id))
+		}
+		attrs = append(attrs, a.c.headerAttrs("http.response.header.", res.Header)...)
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error.type", errorType(res, err)))
+	}
+	if a.span != nil {
+		a.span.SetAttributes(attrs...)
+		if err != nil {
+			a.span.RecordError(err)
+		}
+		a.span.End()
+	}
+	if a.c.t.Logger != nil {
+		a.c.t.Logger.LogAttrs(a.ctx, slog.LevelDebug, "openai: request attempt", attrs...)
+	}
+}
+
+// retry reports that the attempt is retried after delay.
+func (a *attempt) retry(res *http.Response, err error, delay time.Duration) {
+	if a == nil || a.c.t.Logger == nil {
+		return
+	}
+	attrs := []slog.Attr{slog.Int("http.request.resend_count", a.n), slog.Duration("delay", delay)}
+	if res != nil {
+		attrs = append(attrs, slog.Int("http.response.status_code", res.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	a.c.t.Logger.LogAttrs(a.ctx, slog.LevelWarn, "openai: retrying request", attrs...)
+}
+
+// response records the final response of the call, and its body when it was
+// read.
+func (c *call) response(res *http.Response, body []byte) {
+	if c == nil {
+		return
+	}
+	c.res, c.body = res, body
+	if isJSON(res.Header) {
+		c.summary.observe(gjson.ParseBytes(body))
+	}
+}
+
+// stream observes the body of a response read by the caller, and ends the call
+// once it is read or closed. The call of an upgraded connection ends at the
+// handshake, and its connection is left as is.
+func (c *call) stream(res *http.Response) {
+	if c == nil || res.Body == nil {
+		return
+	}
+	if res.StatusCode == http.StatusSwitchingProtocols {
+		c.res = res
+		return
+	}
+	c.res, c.streaming = res, true
+	res.Body = &observedBody{ReadCloser: res.Body, c: c, sse: strings.HasPrefix(res.Header.Get("Content-Type"), "text/event-stream")}
+}
+
+// end ends the call with err, unless its body is still being read.
+func (c *call) end(err error) {
+	if c == nil || (c.streaming && err == nil) {
+		return
+	}
+	c.finish(err)
+}
+
+func (c *call) finish(err error) {
+	c.once.Do(func() {
+		duration := time.Since(c.start)
+		attrs := []slog.Attr{slog.Int("http.request.resend_count", max(c.attempts-1, 0))}
+		if c.res != nil {
+			attrs = append(attrs, slog.Int("http.response.status_code", c.res.StatusCode))
+			if id := c.res.Header.Get("X-Request-Id"); id != "" {
+				attrs = append(attrs, slog.String("openai.request.id", id))
+			}
+		}
+		attrs = append(attrs, c.summary.attrs()...)
+		if !c.streaming && c.res != nil && c.res.StatusCode < 400 {
+			attrs = append(attrs, c.bodyAttrs("http.response.body", c.body)...)
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error.type", errorType(c.res, err)))
+		}
+
+		if c.span != nil {
+			c.span.SetAttributes(attrs...)
+			if err != nil {
+				c.span.RecordError(err)
+			}
+			c.span.End()
+		}
+
+		if c.t.Meter != nil && !c.generic {
+			metrics := c.metrics
+			if c.summary.model != "" {
+				metrics = append(metrics[:len(metrics):len(metrics)], slog.String("gen_ai.response.model", c.summary.model))
+			}
+			errorAttrs := metrics
+			if err != nil {
+				errorAttrs = append(metrics[:len(metrics):len(metrics)], slog.String("error.type", errorType(c.res, err)))
+			}
+			c.t.Meter.Record(c.ctx, MetricOperationDuration, duration.Seconds(), errorAttrs...)
+			if c.summary.usage {
+				c.t.Meter.Record(c.ctx, MetricTokenUsage, float64(c.summary.inputTokens), append(metrics[:len(metrics):len(metrics)], slog.String("gen_ai.token.type", "input"))...)
+				c.t.Meter.Record(c.ctx, MetricTokenUsage, float64(c.summary.outputTokens), append(metrics[:len(metrics):len(metrics)], slog.String("gen_ai.token.type", "output"))...)
+			}
+			if c.summary.firstChunk > 0 {
+				c.t.Meter.Record(c.ctx, MetricTimeToFirstChunk, c.summary.firstChunk.Seconds(), metrics...)
+			}
+		}
+
+		if c.t.Logger != nil {
+			level, msg := slog.LevelInfo, "openai: request"
+			if err != nil {
+				level, msg = slog.LevelError, "openai: request failed"
+			}
+			all := append(append(c.attrs[:len(c.attrs):len(c.attrs)], attrs...), slog.Duration("duration", duration))
+			if err != nil {
+				all = append(all, slog.String("error", err.Error()))
+			}
+			c.t.Logger.LogAttrs(c.ctx, level, msg, all...)
+		}
+	})
+}
+
+func errorType(res *http.Response, err error) string {
+	if res != nil && res.StatusCode >= 400 {
+		return http.StatusText(res.StatusCode)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	if errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+	return "connection_error"
+}
+
+func (c *call) headerAttrs(prefix string, header http.Header) []slog.Attr {
+	var attrs []slog.Attr
+	for _, name := range c.t.Headers {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		key := prefix + strings.ToLower(name)
+		value := strings.Join(values, ",")
+		for _, credential := range credentialHeaders {
+			if strings.EqualFold(name, credential) {
+				value = redacted
+			}
+		}
+		attrs = append(attrs, slog.String(key, c.redact(key, value)))
+	}
+	return attrs
+}
+
+func (c *call) bodyAttrs(key string, body []byte) []slog.Attr {
+	if c.t.BodyLimit <= 0 || len(body) == 0 {
+		return nil
+	}
+	if len(body) > c.t.BodyLimit {
+		body = body[:c.t.BodyLimit]
+	}
+	return []slog.Attr{slog.String(key, c.redact(key, string(body)))}
+}
+
+func (c *call) redact(key string, value string) string {
+	value = apiKeyPattern.ReplaceAllString(value, redacted)
+	if c.t.Redact != nil {
+		value = c.t.Redact(key, value)
+	}
+	return value
+}
+
+func isJSON(header http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// summary collects the attributes of a generated response, from its body or from
+// the events of its stream.
+type summary struct {
+	id, model    string
+	usage        bool
+	inputTokens  int64
+	outputTokens int64
+	finish       []string
+	firstChunk   time.Duration
+}
+
+func (s *summary) observe(data gjson.Result) {
+	// Response stream events carry the response being generated.
+	if res := data.Get("response"); res.IsObject() {
+		data = res
+	}
+	if id := data.Get("id"); id.Type == gjson.String {
+		s.id = id.String()
+	}
+	if model := data.Get("model"); model.Type == gjson.String {
+		s.model = model.String()
+	}
+	if usage := data.Get("usage"); usage.IsObject() {
+		s.usage = true
+		s.inputTokens = first(usage, "prompt_tokens", "input_tokens").Int()
+		s.outputTokens = first(usage, "completion_tokens", "output_tokens").Int()
+	}
+	data.Get("choices").ForEach(func(_, choice gjson.Result) bool {
+		if reason := choice.Get("finish_reason").String(); reason != "" {
+			index := int(choice.Get("index").Int())
+			for len(s.finish) <= index {
+				s.finish = append(s.finish, "")
+			}
+			s.finish[index] = reason
+		}
+		return true
+	})
+	if data.Get("object").String() == "response" {
+		switch status := data.Get("status").String(); status {
+		case "", "queued", "in_progress":
+		default:
+			s.finish = []string{status}
+		}
+	}
+}
+
+// first returns the first of the paths which exists in data.
+func first(data gjson.Result, paths ...string) gjson.Result {
+	for _, path := range paths {
+		if v := data.Get(path); v.Exists() {
+			return v
+		}
+	}
+	return gjson.Result{}
+}
+
+func (s summary) attrs() []slog.Attr {
+	var attrs []slog.Attr
+	if s.id != "" {
+		attrs = append(attrs, slog.String("gen_ai.response.id", s.id))
+	}
+	if s.model != "" {
+		attrs = append(attrs, slog.String("gen_ai.response.model", s.model))
+	}
+	if s.usage {
+		attrs = append(attrs, slog.Int64("gen_ai.usage.input_tokens", s.inputTokens), slog.Int64("gen_ai.usage.output_tokens", s.outputTokens))
+	}
+	if len(s.finish) > 0 {
+		attrs = append(attrs, slog.Any("gen_ai.response.finish_reasons", s.finish))
+	}
+	if s.firstChunk > 0 {
+		attrs = append(attrs, slog.Float64("gen_ai.response.time_to_first_chunk", s.firstChunk.Seconds()))
+	}
+	return attrs
+}
+
+// observedBody reads the events of a stream as they pass, and ends its call once
+// the stream is read or closed.
+type observedBody struct {
+	io.ReadCloser
+	c    *call
+	sse  bool
+	line []byte
+}
+
+func (b *observedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if b.sse {
+		b.scan(p[:n])
+	}
+	if err == io.EOF {
+		b.c.finish(nil)
+	} else if err != nil {
+		b.c.finish(err)
+	}
+	return n, err
+}
+
+func (b *observedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.c.finish(nil)
+	return err
+}
+
+func (b *observedBody) scan(p []byte) {
+	b.line = append(b.line, p...)
+	consumed := 0
+	for {
+		i := bytes.IndexByte(b.line[consumed:], '\n')
+		if i < 0 {
+			break
+		}
+		line := bytes.TrimRight(b.line[consumed:consumed+i], "\r")
+		consumed += i + 1
+		data, ok := bytes.CutPrefix(line, []byte("data:"))
+		if !ok {
+			continue
+		}
+		event := gjson.ParseBytes(bytes.TrimSpace(data))
+		if b.c.summary.firstChunk == 0 && isChunk(event) {
+			b.c.summary.firstChunk = max(time.Since(b.c.start), 1)
+		}
+		b.c.summary.observe(event)
+	}
+	b.line = append(b.line[:0], b.line[consumed:]...)
+}
+
+// isChunk reports whether an event carries generated content.
+func isChunk(event gjson.Result) bool {
+	if strings.HasSuffix(event.Get("type").String(), ".delta") {
+		return true
+	}
+	chunk := false
+	event.Get("choices").ForEach(func(_, choice gjson.Result) bool {
+		delta := choice.Get("delta")
+		chunk = delta.Get("content").String() != "" || delta.Get("refusal").String() != "" || delta.Get("tool_calls").Exists()
+		return !chunk
+	})
+	return chunk
+}
@@ -0,0 +1,58 @@
+// Package respmeta describes the response behind a result of the API.
+package respmeta
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ratelimit"
+)
+
+// Meta holds the metadata of an API response.
+type Meta struct {
+	// The x-request-id header, to share with OpenAI support.
+	RequestID string
+	// The time the API spent processing the request, from the
+	// openai-processing-ms header.
+	ProcessingTime time.Duration
+	// The organization and project of the request, from the openai-organization
+	// and openai-project headers.
+	Organization string
+	Project      string
+	// The openai-version header.
+	Version string
+	// The model which served the request, from the openai-model header.
+	Model string
+	// The rate limits reported by the x-ratelimit-* headers.
+	RateLimit ratelimit.Info
+	// The number of times the request was retried before this response.
+	Retries    int
+	StatusCode int
+	Header     http.Header
+}
+
+// FromResponse reads the metadata of res, which may be nil. The retries are read
+// from the X-Stainless-Retry-Count header of its request.
+func FromResponse(res *http.Response) Meta {
+	if res == nil {
+		return Meta{}
+	}
+	meta := Meta{
+		RequestID:    res.Header.Get("X-Request-Id"),
+		Organization: res.Header.Get("Openai-Organization"),
+		Project:      res.Header.Get("Openai-Project"),
+		Version:      res.Header.Get("Openai-Version"),
+		Model:        res.Header.Get("Openai-Model"),
+		RateLimit:    ratelimit.Parse(res.Header),
+		StatusCode:   res.StatusCode,
+		Header:       res.Header,
+	}
+	if ms, err := strconv.ParseFloat(res.Header.Get("Openai-Processing-Ms"), 64); err == nil {
+		meta.ProcessingTime = time.Duration(ms * float64(time.Millisecond))
+	}
+	if res.Request != nil {
+		meta.Retries, _ = strconv.Atoi(res.Request.Header.Get("X-Stainless-Retry-Count"))
+	}
+	return meta
+}
@@ -0,0 +1,255 @@
+// Package streamresume resumes the event streams of background responses of the
+// Responses API after their connection drops.
+package streamresume
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// body is the body of a response stream which reconnects to the stream of a
+// background response when the connection drops before the response finished,
+// and skips the events it has already received.
+type body struct {
+	send       func(*http.Request) (*http.Response, error)
+	wrap       func(io.ReadCloser) io.ReadCloser
+	req        *http.Request
+	reconnects int
+	attempts   int
+
+	mu     sync.Mutex
+	rc     io.ReadCloser
+	closed bool
+
+	r       *bufio.Reader
+	event   bytes.Buffer
+	pending []byte
+	err     error
+
+	responseID   string
+	background   bool
+	lastSequence int64
+	done         bool
+}
+
+// Wrap returns the body of res, which reconnects up to reconnects times when
+// res streams a response of the Responses API. The stream is resumed by sending
+// a request derived from req with send.
+//
+// wrap, when not nil, wraps the body of every connection, such as with an idle
+// timeout. A read error of a wrapped body drops the connection, which is then
+// resumed like any other.
+func Wrap(send func(*http.Request) (*http.Response, error), req *http.Request, res *http.Response, reconnects int, wrap func(io.ReadCloser) io.ReadCloser) io.ReadCloser {
+	if wrap == nil {
+		wrap = func(rc io.ReadCloser) io.ReadCloser { return rc }
+	}
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if reconnects <= 0 || res.StatusCode >= 300 || mediaType != "text/event-stream" {
+		return wrap(res.Body)
+	}
+	rc := wrap(res.Body)
+	b := &body{
+		send:         send,
+		wrap:         wrap,
+		req:          req,
+		reconnects:   reconnects,
+		rc:           rc,
+		r:            bufio.NewReader(rc),
+		lastSequence: -1,
+	}
+	dir, base := path.Split(path.Clean(req.URL.Path))
+	switch {
+	case req.Method == http.MethodPost && base == "responses":
+	case req.Method == http.MethodGet && path.Base(dir) == "responses":
+		// The response is known, and already in the background.
+		b.responseID, b.background = base, true
+		if after, err := strconv.ParseInt(req.URL.Query().Get("starting_after"), 10, 64); err == nil {
+			b.lastSequence = after
+		}
+	default:
+		return rc
+	}
+	return b
+}
+
+func (b *body) Read(p []byte) (int, error) {
+	for len(b.pending) == 0 {
+		if b.err != nil {
+			return 0, b.err
+		}
+		b.next()
+	}
+	n := copy(p, b.pending)
+	b.pending = b.pending[n:]
+	return n, nil
+}
+
+// next reads the next event which was not received yet into pending, or sets
+// err once the stream ended and cannot be resumed.
+func (b *body) next() {
+	b.event.Reset()
+	for {
+		line, err := b.r.ReadBytes('\n')
+		if err != nil {
+			if b.done || !b.resume(err) {
+				// An event cut by the end of the stream is given as is.
+				b.pending = append(b.event.Bytes(), line...)
+				if b.err == nil {
+					b.err = err
+				}
+				return
+			}
+			// The event cut by the connection is sent again.
+			b.event.Reset()
+			continue
+		}
+		b.event.Write(line)
+		if len(bytes.TrimRight(line, "\r\n")) > 0 {
+			continue
+		}
+		if !b.seen(b.event.Bytes()) {
+			b.pending = b.event.Bytes()
+			return
+		}
+		b.event.Reset()
+	}
+}
+
+// seen records the sequence number and state of the response carried by event,
+// and reports whether event was already received before a reconnection.
+func (b *body) seen(event []byte) bool {
+	var data []byte
+	for _, line := range bytes.SplitAfter(event, []byte("\n")) {
+		value, ok := bytes.CutPrefix(bytes.TrimRight(line, "\r\n"), []byte("data:"))
+		if !ok {
+			continue
+		}
+		if len(data) > 0 {
+			data = append(data, '\n')
+		}
+		data = append(data, bytes.TrimPrefix(value, []byte(" "))...)
+	}
+	parsed := gjson.ParseBytes(data)
+	if !parsed.IsObject() {
+		return false
+	}
+	if seq := parsed.Get("sequence_number"); seq.Exists() {
+		if seq.Int() <= b.lastSequence {
+			return true
+		}
+		b.lastSequence = seq.Int()
+		b.attempts = 0
+	}
+
+	switch parsed.Get("type").String() {
+	case "response.created", "response.queued", "response.in_progress":
+		b.responseID = parsed.Get("response.id").String()
+		b.background = parsed.Get("response.background").Bool()
+	case "response.completed", "response.failed", "response.incomplete", "error":
+		b.done = true
+	}
+	if parsed.Get("error").Exists() && !parsed.Get("type").Exists() {
+		b.done = true
+	}
+	return false
+}
+
+// resume replaces the dropped connection with a new one which starts after the
+// last received sequence number.
+func (b *body) resume(cause error) bool {
+	ctx := b.req.Context()
+	if !b.background || b.responseID == "" || ctx.Err() != nil || b.isClosed() {
+		return false
+	}
+	if cause == io.EOF {
+		cause = io.ErrUnexpectedEOF
+	}
+	b.mu.Lock()
+	b.rc.Close()
+	b.mu.Unlock()
+
+	backoff := 500 * time.Millisecond
+	for b.attempts < b.reconnects {
+		if b.attempts > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				b.err = ctx.Err()
+				return false
+			case <-timer.C:
+			}
+			backoff = min(2*backoff, 8*time.Second)
+		}
+		b.attempts++
+
+		req := b.request()
+		res, err := b.send(req)
+		if err == nil && res.StatusCode >= 300 {
+			res.Body.Close()
+			err = fmt.Errorf("%s %q: %s", req.Method, req.URL, res.Status)
+		}
+		if err == nil {
+			b.mu.Lock()
+			if b.closed {
+				b.mu.Unlock()
+				res.Body.Close()
+				return false
+			}
+			b.rc = b.wrap(res.Body)
+			b.r = bufio.NewReader(b.rc)
+			b.mu.Unlock()
+			return true
+		}
+		if ctx.Err() != nil {
+			b.err = ctx.Err()
+			return false
+		}
+		cause = err
+	}
+	b.err = fmt.Errorf("resuming response %s after sequence number %d: %w", b.responseID, b.lastSequence, cause)
+	return false
+}
+
+// request returns the request of the stream of the response, after the last
+// received sequence number.
+func (b *body) request() *http.Request {
+	req := b.req.Clone(b.req.Context())
+	if req.Method == http.MethodGet {
+		req.URL.Path = path.Join(path.Dir(req.URL.Path), b.responseID)
+	} else {
+		req.URL.Path = path.Join(req.URL.Path, b.responseID)
+	}
+	req.Method = http.MethodGet
+	req.Body, req.GetBody, req.ContentLength = nil, nil, 0
+	req.Header.Del("Content-Type")
+	req.URL.RawPath = ""
+	query := req.URL.Query()
+	query.Set("stream", "true")
+	query.Set("starting_after", strconv.FormatInt(b.lastSequence, 10))
+	req.URL.RawQuery = query.Encode()
+	return req
+}
+
+func (b *body) isClosed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+func (b *body) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return b.rc.Close()
+}
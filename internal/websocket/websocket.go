@@ -0,0 +1,362 @@
+// Package websocket implements the subset of RFC 6455 needed by the realtime
+// client: the opening handshake helpers, message framing with fragmentation and
+// control frames, and the closing handshake.
+//
+// The opening handshake itself is performed by the caller over net/http, which
+// hands back the upgraded connection as the body of a "101 Switching Protocols"
+// response. A server side [Upgrade] is provided for tests.
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// The message types defined by RFC 6455.
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+// Close codes defined by RFC 6455.
+const (
+	CloseNormalClosure    = 1000
+	CloseGoingAway        = 1001
+	CloseProtocolError    = 1002
+	CloseNoStatusReceived = 1005
+	CloseMessageTooBig    = 1009
+)
+
+// DefaultMaxMessageSize is the largest message that a [Conn] will buffer before
+// failing the connection.
+const DefaultMaxMessageSize = 32 << 20
+
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// CloseError is returned by [Conn.ReadMessage] once the peer has closed the
+// connection.
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("websocket: closed with code %d", e.Code)
+	}
+	return fmt.Sprintf("websocket: closed with code %d: %s", e.Code, e.Reason)
+}
+
+// ErrClosed is returned when writing to a connection that has already been
+// closed locally.
+var ErrClosed = errors.New("websocket: use of closed connection")
+
+// NewKey returns a random value for the Sec-WebSocket-Key header.
+func NewKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// AcceptKey computes the Sec-WebSocket-Accept value for the given key.
+func AcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// SetHandshakeHeaders adds the headers required on a client opening handshake.
+func SetHandshakeHeaders(h http.Header, key string) {
+	h.Set("Connection", "Upgrade")
+	h.Set("Upgrade", "websocket")
+	h.Set("Sec-WebSocket-Version", "13")
+	h.Set("Sec-WebSocket-Key", key)
+}
+
+// CheckHandshake validates the server's response to an opening handshake that
+// was sent with the given key.
+func CheckHandshake(res *http.Response, key string) error {
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		return fmt.Errorf("websocket: unexpected handshake status %d %s", res.StatusCode, http.StatusText(res.StatusCode))
+	}
+	if !headerContainsToken(res.Header, "Connection", "upgrade") || !strings.EqualFold(res.Header.Get("Upgrade"), "websocket") {
+		return errors.New("websocket: handshake response is missing upgrade headers")
+	}
+	if res.Header.Get("Sec-WebSocket-Accept") != AcceptKey(key) {
+		return errors.New("websocket: handshake response has an invalid Sec-WebSocket-Accept header")
+	}
+	return nil
+}
+
+// Upgrade performs the server side of the opening handshake by hijacking the
+// HTTP connection.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" || !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		http.Error(w, "websocket: not a websocket handshake", http.StatusBadRequest)
+		return nil, errors.New("websocket: not a websocket handshake")
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket: response does not implement http.Hijacker", http.StatusInternalServerError)
+		return nil, errors.New("websocket: response does not implement http.Hijacker")
+	}
+	nc, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	resp := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + AcceptKey(key) + "\r\n\r\n"
+	if _, err := rw.WriteString(resp); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return NewConn(nc, rw.Reader, false), nil
+}
+
+// Conn is a WebSocket connection. Writes are safe for concurrent use, reads
+// must happen from a single goroutine.
+type Conn struct {
+	rwc    io.ReadWriteCloser
+	br     *bufio.Reader
+	client bool
+
+	// MaxMessageSize bounds the size of a single (possibly fragmented) message.
+	MaxMessageSize int64
+
+	wmu       sync.Mutex
+	closeOnce sync.Once
+	closed    bool
+	closeErr  error
+}
+
+// NewConn wraps an upgraded connection. When br is nil, reads are buffered
+// directly from rwc. Client connections mask the frames that they send, as
+// required by RFC 6455.
+func NewConn(rwc io.ReadWriteCloser, br *bufio.Reader, client bool) *Conn {
+	if br == nil {
+		br = bufio.NewReader(rwc)
+	}
+	return &Conn{rwc: rwc, br: br, client: client, MaxMessageSize: DefaultMaxMessageSize}
+}
+
+// ReadMessage returns the next data message. Ping frames are answered
+// automatically and pong frames are discarded. When the peer closes the
+// connection, a [*CloseError] is returned.
+func (c *Conn) ReadMessage() (messageType int, data []byte, err error) {
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err := c.writeFrame(PongMessage, payload); err != nil && !errors.Is(err, ErrClosed) {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			cerr := &CloseError{Code: CloseNoStatusReceived}
+			if len(payload) >= 2 {
+				cerr.Code = int(binary.BigEndian.Uint16(payload))
+				cerr.Reason = string(payload[2:])
+			}
+			// Echo the close frame to complete the closing handshake.
+			c.close(cerr.Code, "")
+			return 0, nil, cerr
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, c.fail("websocket: new message started before the previous one finished")
+			}
+			messageType = opcode
+		case 0:
+			if messageType == 0 {
+				return 0, nil, c.fail("websocket: continuation frame without a message")
+			}
+		default:
+			return 0, nil, c.fail(fmt.Sprintf("websocket: unknown opcode %d", opcode))
+		}
+
+		if int64(len(data))+int64(len(payload)) > c.MaxMessageSize {
+			c.close(CloseMessageTooBig, "")
+			return 0, nil, fmt.Errorf("websocket: message exceeds the maximum size of %d bytes", c.MaxMessageSize)
+		}
+		data = append(data, payload...)
+		if fin {
+			return messageType, data, nil
+		}
+	}
+}
+
+// WriteMessage sends a single unfragmented message.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage && messageType != PingMessage {
+		return fmt.Errorf("websocket: cannot write message of type %d", messageType)
+	}
+	return c.writeFrame(messageType, data)
+}
+
+// Close sends a close frame with the given code and reason and closes the
+// underlying connection. It is safe to call Close multiple times.
+func (c *Conn) Close(code int, reason string) error {
+	return c.close(code, reason)
+}
+
+func (c *Conn) close(code int, reason string) error {
+	c.closeOnce.Do(func() {
+		payload := make([]byte, 2+len(reason))
+		binary.BigEndian.PutUint16(payload, uint16(code))
+		copy(payload[2:], reason)
+		// The peer may already be gone, in which case there is nobody to notify.
+		_ = c.writeFrame(CloseMessage, payload)
+
+		c.wmu.Lock()
+		c.closed = true
+		c.wmu.Unlock()
+		c.closeErr = c.rwc.Close()
+	})
+	return c.closeErr
+}
+
+func (c *Conn) fail(msg string) error {
+	c.close(CloseProtocolError, "")
+	return errors.New(msg)
+}
+
+func (c *Conn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.br, header[:]); err != nil {
+		return
+	}
+	fin = header[0]&0x80 != 0
+	if header[0]&0x70 != 0 {
+		err = c.fail("websocket: reserved bits set without a negotiated extension")
+		return
+	}
+	opcode = int(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	length := int64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+
+	if opcode >= CloseMessage && (length > 125 || !fin) {
+		err = c.fail("websocket: invalid control frame")
+		return
+	}
+	if length < 0 || length > c.MaxMessageSize {
+		c.close(CloseMessageTooBig, "")
+		err = fmt.Errorf("websocket: frame exceeds the maximum size of %d bytes", c.MaxMessageSize)
+		return
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+			return
+		}
+	}
+
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+	if masked {
+		maskBytes(mask, payload)
+	}
+	return
+}
+
+func (c *Conn) writeFrame(opcode int, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|byte(opcode))
+
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+
+	if c.client {
+		var mask [4]byte
+		if _, err := io.ReadFull(rand.Reader, mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		maskBytes(mask, frame[start:])
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	_, err := c.rwc.Write(frame)
+	return err
+}
+
+func maskBytes(mask [4]byte, b []byte) {
+	for i := range b {
+		b[i] ^= mask[i%4]
+	}
+}
+
+func headerContainsToken(h http.Header, name string, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
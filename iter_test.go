@@ -0,0 +1,90 @@
+//go:build go1.23
+
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/iterutil"
+)
+
+func TestAutoPagerAll(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		requests = append(requests, after)
+		w.Header().Set("Content-Type", "application/json")
+		switch after {
+		case "":
+			fmt.Fprint(w, `{"object": "list", "data": [{"id": "file-1", "object": "file", "purpose": "batch"}, {"id": "file-2", "object": "file", "purpose": "fine-tune"}], "has_more": true}`)
+		case "file-2":
+			fmt.Fprint(w, `{"object": "list", "data": [{"id": "file-3", "object": "file", "purpose": "batch"}], "has_more": false}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"message": "unexpected cursor"}}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+	ctx := context.Background()
+
+	var ids []string
+	for file, err := range client.Files.ListAutoPaging(ctx, openai.FileListParams{}).All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, file.ID)
+	}
+	if !slices.Equal(ids, []string{"file-1", "file-2", "file-3"}) || !slices.Equal(requests, []string{"", "file-2"}) {
+		t.Errorf("unexpected files %v from the requests %q", ids, requests)
+	}
+
+	requests = nil
+	files, err := iterutil.Collect(client.Files.ListAutoPaging(ctx, openai.FileListParams{}).All(), 2)
+	if err != nil || len(files) != 2 || len(requests) != 1 {
+		t.Errorf("expected 2 files from a single page, got %d files from %d requests: %v", len(files), len(requests), err)
+	}
+
+	batch := iterutil.Filter(client.Files.ListAutoPaging(ctx, openai.FileListParams{}).All(), func(file openai.FileObject) bool {
+		return file.Purpose == openai.FileObjectPurposeBatch
+	})
+	ids, err = iterutil.Collect(iterutil.Map(batch, func(file openai.FileObject) string { return file.ID }), 0)
+	if err != nil || !slices.Equal(ids, []string{"file-1", "file-3"}) {
+		t.Errorf("unexpected batch files %v: %v", ids, err)
+	}
+
+	ids = nil
+	for item := range iterutil.Chan(ctx, client.Files.ListAutoPaging(ctx, openai.FileListParams{}).All()) {
+		if item.Err != nil {
+			t.Fatal(item.Err)
+		}
+		ids = append(ids, item.Value.ID)
+	}
+	if len(ids) != 3 {
+		t.Errorf("expected every file from the channel, got %v", ids)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	requests = nil
+	for item := range iterutil.Chan(cancelled, client.Files.ListAutoPaging(ctx, openai.FileListParams{}).All()) {
+		t.Errorf("expected no item once the context is cancelled, got %v", item)
+	}
+	if len(requests) > 1 {
+		t.Errorf("expected the iteration to stop with the context, got the requests %q", requests)
+	}
+
+	_, err = iterutil.Collect(client.Files.ListAutoPaging(ctx, openai.FileListParams{After: openai.String("file-9")}).All(), 0)
+	if err == nil || !strings.Contains(err.Error(), "unexpected cursor") {
+		t.Errorf("expected the error of the first page, got %v", err)
+	}
+}
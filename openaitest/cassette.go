@@ -0,0 +1,325 @@
+package openaitest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+// CassetteMode is whether a [Cassette] records or replays requests.
+type CassetteMode int
+
+const (
+	// CassetteRecord sends requests to the API and records them.
+	CassetteRecord CassetteMode = iota
+	// CassetteReplay answers requests with recorded responses, without sending
+	// them.
+	CassetteReplay
+)
+
+// Redacted replaces the API keys and credentials of a cassette.
+const Redacted = "REDACTED"
+
+// RecordEnv is the environment variable which makes [UseCassette] record again
+// cassettes which already exist, when set to a non-empty value.
+const RecordEnv = "OPENAITEST_RECORD"
+
+// redactedHeaders are the credential headers which are never recorded.
+var redactedHeaders = []string{"Authorization", "Api-Key", "Openai-Organization", "Openai-Project"}
+
+// apiKeys matches the OpenAI API keys left in URLs and bodies.
+var apiKeys = regexp.MustCompile(`sk-[A-Za-z0-9_\-]{8,}`)
+
+// Cassette records the requests sent through its middleware and their
+// responses, to replay them later without network access. Streamed responses
+// and multipart uploads are recorded as well. API keys and credential headers,
+// such as Authorization and Api-Key, are redacted before they are recorded.
+//
+// Replayed requests are matched by method, path, query and body, so a cassette
+// can be replayed against any host. JSON bodies are normalized, so the order of
+// their fields and their whitespace do not matter, and the random boundaries of
+// multipart bodies are ignored. Each recorded interaction is replayed once, in
+// the order of the recording.
+//
+// A Cassette is safe for concurrent use.
+type Cassette struct {
+	// The file of the cassette.
+	Path string
+	Mode CassetteMode
+	// Additional headers to redact, such as custom credentials.
+	RedactHeaders []string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// Interaction is a request and its response, as stored in a cassette file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request stored in a cassette file.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body"`
+}
+
+// RecordedResponse is a response stored in a cassette file.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body"`
+}
+
+// Body is a recorded body. It is stored as a string when it is valid UTF-8, and
+// in base64 otherwise.
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	*b = decoded
+	return err
+}
+
+// RecordCassette returns a cassette recording to the file at path. The file is
+// written by [Cassette.Save].
+func RecordCassette(path string) *Cassette {
+	return &Cassette{Path: path, Mode: CassetteRecord}
+}
+
+// ReplayCassette returns a cassette replaying the file at path.
+func ReplayCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{Path: path, Mode: CassetteReplay}
+	if err := json.Unmarshal(data, &c.interactions); err != nil {
+		return nil, fmt.Errorf("openaitest: invalid cassette %s: %w", path, err)
+	}
+	c.replayed = make([]bool, len(c.interactions))
+	return c, nil
+}
+
+// UseCassette returns the option recording or replaying the requests of a test.
+// The cassette at path is replayed when it exists, and recorded and saved at the
+// end of the test otherwise, or when the [RecordEnv] environment variable is
+// set:
+//
+//	client := openai.NewClient(openaitest.UseCassette(t, "testdata/chat.json"))
+func UseCassette(tb testing.TB, path string) option.RequestOption {
+	tb.Helper()
+	_, err := os.Stat(path)
+	if os.Getenv(RecordEnv) != "" || errors.Is(err, fs.ErrNotExist) {
+		c := RecordCassette(path)
+		tb.Cleanup(func() {
+			if tb.Failed() {
+				return
+			}
+			if err := c.Save(); err != nil {
+				tb.Errorf("openaitest: saving cassette: %v", err)
+			}
+		})
+		return option.WithMiddleware(c.Middleware)
+	}
+	c, err := ReplayCassette(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return option.WithMiddleware(c.Middleware)
+}
+
+// Interactions returns the interactions of the cassette.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Save writes the recorded interactions to the file of the cassette, creating
+// its directory if needed.
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, append(data, '\n'), 0o644)
+}
+
+// Middleware records or replays a request, to use with [option.WithMiddleware].
+func (c *Cassette) Middleware(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    c.redact(req.URL.String()),
+		Header: c.redactHeader(req.Header),
+		Body:   Body(c.redact(string(body))),
+	}
+
+	if c.Mode == CassetteReplay {
+		return c.replay(req, recorded)
+	}
+
+	res, err := next(req)
+	if err != nil {
+		return res, err
+	}
+	// The body is recorded as it is read, so streams are still delivered as they
+	// arrive.
+	res.Body = &recordingBody{
+		ReadCloser: res.Body,
+		done: func(data []byte) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.interactions = append(c.interactions, Interaction{
+				Request: recorded,
+				Response: RecordedResponse{
+					StatusCode: res.StatusCode,
+					Header:     c.redactHeader(res.Header),
+					Body:       Body(c.redact(string(data))),
+				},
+			})
+		},
+	}
+	return res, nil
+}
+
+func (c *Cassette) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	key := matchKey(recorded)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, interaction := range c.interactions {
+		if c.replayed[i] || matchKey(interaction.Request) != key {
+			continue
+		}
+		c.replayed[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("openaitest: no recorded interaction left in %s for %s %s", c.Path, recorded.Method, recorded.URL)
+}
+
+// matchKey returns the parts of a request which must match to replay it.
+func matchKey(r RecordedRequest) string {
+	body := []byte(r.Body)
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json":
+		var v any
+		if json.Unmarshal(body, &v) == nil {
+			// Maps are encoded with sorted keys and without whitespace.
+			body, _ = json.Marshal(v)
+		}
+	case strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "":
+		body = bytes.ReplaceAll(body, []byte(params["boundary"]), []byte("boundary"))
+	}
+	target := r.URL
+	if u, err := url.Parse(r.URL); err == nil {
+		target = u.RequestURI()
+	}
+	return r.Method + " " + target + "\n" + string(body)
+}
+
+func (c *Cassette) redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for key, values := range redacted {
+		for i, v := range values {
+			values[i] = c.redact(v)
+		}
+		redacted[key] = values
+	}
+	for _, key := range append(redactedHeaders, c.RedactHeaders...) {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, Redacted)
+		}
+	}
+	return redacted
+}
+
+func (c *Cassette) redact(s string) string {
+	return apiKeys.ReplaceAllString(s, Redacted)
+}
+
+// recordingBody copies what is read from a response body, and reports it once
+// the body is read or closed.
+type recordingBody struct {
+	io.ReadCloser
+	buf  bytes.Buffer
+	once sync.Once
+	done func([]byte)
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF {
+		b.once.Do(func() { b.done(b.buf.Bytes()) })
+	}
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	b.once.Do(func() { b.done(b.buf.Bytes()) })
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,84 @@
+package openaitest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/openaitest"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+func TestCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	params := openai.ChatCompletionNewParams{
+		Model:    openai.ChatModelGPT4o,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hello")},
+	}
+	run := func(client openai.Client) (string, string, string) {
+		ctx := context.Background()
+		completion, err := client.Chat.Completions.New(ctx, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream := client.Chat.Completions.NewStreaming(ctx, params)
+		acc := openai.ChatCompletionAccumulator{}
+		for stream.Next() {
+			acc.AddChunk(stream.Current())
+		}
+		if err := stream.Err(); err != nil {
+			t.Fatal(err)
+		}
+		file, err := client.Files.New(ctx, openai.FileNewParams{
+			File:    openai.File(strings.NewReader("hello"), "hello.txt", "text/plain"),
+			Purpose: openai.FilePurposeAssistants,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return completion.Choices[0].Message.Content, acc.Choices[0].Message.Content, file.ID
+	}
+
+	srv := openaitest.NewServer()
+	srv.Text = "Recorded."
+	recorder := openaitest.RecordCassette(path)
+	content, streamed, fileID := run(srv.Client(option.WithMiddleware(recorder.Middleware)))
+	srv.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(recorder.Interactions()); n != 3 {
+		t.Fatalf("expected 3 recorded interactions, got %d", n)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "sk-openaitest") || !strings.Contains(string(data), openaitest.Redacted) {
+		t.Fatalf("expected the API key to be redacted:\n%s", data)
+	}
+
+	player, err := openaitest.ReplayCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The server is closed, so every response comes from the cassette.
+	client := openai.NewClient(
+		option.WithBaseURL("http://replay.invalid/"),
+		option.WithAPIKey("sk-another-key"),
+		option.WithMaxRetries(0),
+		option.WithMiddleware(player.Middleware),
+	)
+	replayed, replayedStream, replayedFileID := run(client)
+	if replayed != content || replayedStream != streamed || replayedFileID != fileID {
+		t.Fatalf("expected the recorded responses, got %q, %q, %q", replayed, replayedStream, replayedFileID)
+	}
+
+	_, err = client.Chat.Completions.New(context.Background(), params)
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Fatalf("expected an error once the cassette is exhausted, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package openaitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Done is the event which ends a chat completion stream.
+const Done = "[DONE]"
+
+// Reply is a reply of a [Server].
+type Reply struct {
+	// The status code. Defaults to 200.
+	StatusCode int
+	// Additional headers.
+	Header http.Header
+	// The JSON body. A string, []byte or [json.RawMessage] is sent as is, other
+	// values are encoded.
+	Body any
+	// The events of a server-sent event stream, encoded as Body. When set, the
+	// reply is a stream and Body is ignored.
+	Events []any
+}
+
+// JSON returns a successful reply with the given JSON body.
+func JSON(body any) Reply {
+	return Reply{Body: body}
+}
+
+// Stream returns a reply streaming the given events. A chat completion stream
+// should end with [Done].
+func Stream(events ...any) Reply {
+	return Reply{Events: events}
+}
+
+// Error returns an error reply, with the error format of the API.
+func Error(statusCode int, code string, message string) Reply {
+	return Reply{
+		StatusCode: statusCode,
+		Body: map[string]any{"error": map[string]any{
+			"code":    code,
+			"message": message,
+			"param":   nil,
+			"type":    errorType(statusCode),
+		}},
+	}
+}
+
+// RateLimited returns a 429 reply asking the client to retry after the given
+// delay, with exhausted x-ratelimit-* headers.
+func RateLimited(retryAfter time.Duration) Reply {
+	reply := Error(http.StatusTooManyRequests, "rate_limit_exceeded", "Rate limit reached, please try again later.")
+	reply.Header = http.Header{
+		"Retry-After-Ms":                 []string{strconv.FormatInt(retryAfter.Milliseconds(), 10)},
+		"X-Ratelimit-Remaining-Requests": []string{"0"},
+		"X-Ratelimit-Reset-Requests":     []string{retryAfter.String()},
+	}
+	return reply
+}
+
+// InsufficientQuota returns the 429 reply sent when the quota of the account is
+// exhausted, which should not be retried.
+func InsufficientQuota() Reply {
+	return Error(http.StatusTooManyRequests, "insufficient_quota", "You exceeded your current quota, please check your plan and billing details.")
+}
+
+func errorType(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return "authentication_error"
+	case statusCode == http.StatusTooManyRequests:
+		return "requests"
+	case statusCode >= http.StatusInternalServerError:
+		return "server_error"
+	}
+	return "invalid_request_error"
+}
+
+func encode(v any) []byte {
+	switch v := v.(type) {
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	case json.RawMessage:
+		return v
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return []byte(fmt.Sprintf(`{"error":{"message":%q}}`, err.Error()))
+	}
+	return data
+}
+
+func (r Reply) write(w http.ResponseWriter) {
+	for key, values := range r.Header {
+		w.Header()[key] = values
+	}
+	statusCode := r.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	if r.Events != nil {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(statusCode)
+		flusher, _ := w.(http.Flusher)
+		for _, event := range r.Events {
+			fmt.Fprintf(w, "data: %s\n\n", encode(event))
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(statusCode)
+	if r.Body != nil {
+		w.Write(encode(r.Body))
+	}
+}
@@ -0,0 +1,958 @@
+package openaitest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type object = map[string]any
+
+// state holds the objects created on a [Server], guarded by its mutex.
+type state struct {
+	next             int
+	files            map[string]object
+	fileContents     map[string][]byte
+	batches          map[string]object
+	batchPolls       map[string]int
+	vectorStores     map[string]object
+	vectorStoreFiles map[string][]object
+	uploads          map[string]object
+	uploadParts      map[string][]byte
+	responses        map[string]object
+	responsePolls    map[string]int
+	order            []string
+}
+
+func newState() state {
+	return state{
+		files:            map[string]object{},
+		fileContents:     map[string][]byte{},
+		batches:          map[string]object{},
+		batchPolls:       map[string]int{},
+		vectorStores:     map[string]object{},
+		vectorStoreFiles: map[string][]object{},
+		uploads:          map[string]object{},
+		uploadParts:      map[string][]byte{},
+		responses:        map[string]object{},
+		responsePolls:    map[string]int{},
+	}
+}
+
+func (s *state) id(prefix string) string {
+	s.next++
+	id := fmt.Sprintf("%s%d", prefix, s.next)
+	s.order = append(s.order, id)
+	return id
+}
+
+// list returns the objects of m, most recently created first.
+func (s *state) list(m map[string]object) object {
+	data := []object{}
+	for i := len(s.order) - 1; i >= 0; i-- {
+		if obj, ok := m[s.order[i]]; ok {
+			data = append(data, obj)
+		}
+	}
+	return page(data)
+}
+
+func page(data []object) object {
+	var firstID, lastID any
+	if len(data) > 0 {
+		firstID, lastID = data[0]["id"], data[len(data)-1]["id"]
+	}
+	return object{"object": "list", "data": data, "first_id": firstID, "last_id": lastID, "has_more": false}
+}
+
+func now() int64 { return time.Now().Unix() }
+
+func notFound(kind string, id string) Reply {
+	return Error(http.StatusNotFound, "not_found", fmt.Sprintf("No %s found with id '%s'.", kind, id))
+}
+
+func invalid(message string) Reply {
+	return Error(http.StatusBadRequest, "invalid_request_error", message)
+}
+
+func (s *Server) defaultHandlers() map[string]func(Request) Reply {
+	return map[string]func(Request) Reply{
+		RouteChatCompletionNew:   s.chatCompletionNew,
+		RouteResponseNew:         s.responseNew,
+		RouteResponseGet:         s.responseGet,
+		RouteResponseDelete:      s.responseDelete,
+		RouteResponseCancel:      s.responseCancel,
+		RouteResponseCompact:     s.responseCompact,
+		RouteResponseInputTokens: s.responseInputTokens,
+		RouteEmbeddingNew:        s.embeddingNew,
+		RouteFileNew:             s.fileNew,
+		RouteFileList:            s.fileList,
+		RouteFileGet:             s.fileGet,
+		RouteFileDelete:          s.fileDelete,
+		RouteFileContent:         s.fileContent,
+		RouteBatchNew:            s.batchNew,
+		RouteBatchList:           s.batchList,
+		RouteBatchGet:            s.batchGet,
+		RouteBatchCancel:         s.batchCancel,
+		RouteVectorStoreNew:      s.vectorStoreNew,
+		RouteVectorStoreList:     s.vectorStoreList,
+		RouteVectorStoreGet:      s.vectorStoreGet,
+		RouteVectorStoreDelete:   s.vectorStoreDelete,
+		RouteVectorStoreSearch:   s.vectorStoreSearch,
+		RouteVectorStoreFileNew:  s.vectorStoreFileNew,
+		RouteVectorStoreFileGet:  s.vectorStoreFileGet,
+		RouteUploadNew:           s.uploadNew,
+		RouteUploadPartNew:       s.uploadPartNew,
+		RouteUploadComplete:      s.uploadComplete,
+		RouteUploadCancel:        s.uploadCancel,
+	}
+}
+
+func (s *Server) text() string {
+	if s.Text != "" {
+		return s.Text
+	}
+	return DefaultText
+}
+
+// words splits the text into the deltas of a stream.
+func (s *Server) words() []string {
+	return strings.SplitAfter(s.text(), " ")
+}
+
+// Chat completions
+
+func (s *Server) chatCompletionNew(r Request) Reply {
+	var params struct {
+		Model         string `json:"model"`
+		N             int    `json:"n"`
+		Stream        bool   `json:"stream"`
+		StreamOptions struct {
+			IncludeUsage bool `json:"include_usage"`
+		} `json:"stream_options"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	id := s.state.id("chatcmpl-")
+	s.mu.Unlock()
+
+	n := max(params.N, 1)
+	words := s.words()
+	usage := object{
+		"prompt_tokens":     (len(r.Body) + 3) / 4,
+		"completion_tokens": len(words) * n,
+		"total_tokens":      (len(r.Body)+3)/4 + len(words)*n,
+	}
+	base := object{"id": id, "created": now(), "model": params.Model}
+
+	if !params.Stream {
+		choices := []object{}
+		for i := range n {
+			choices = append(choices, object{
+				"index":         i,
+				"message":       object{"role": "assistant", "content": s.text(), "refusal": nil},
+				"finish_reason": "stop",
+				"logprobs":      nil,
+			})
+		}
+		return JSON(with(base, object{"object": "chat.completion", "choices": choices, "usage": usage}))
+	}
+
+	chunk := func(choices []object) object {
+		return with(base, object{"object": "chat.completion.chunk", "choices": choices})
+	}
+	var events []any
+	for i := range n {
+		events = append(events, chunk([]object{{"index": i, "delta": object{"role": "assistant", "content": ""}}}))
+	}
+	for _, word := range words {
+		for i := range n {
+			events = append(events, chunk([]object{{"index": i, "delta": object{"content": word}}}))
+		}
+	}
+	for i := range n {
+		events = append(events, chunk([]object{{"index": i, "delta": object{}, "finish_reason": "stop"}}))
+	}
+	if params.StreamOptions.IncludeUsage {
+		events = append(events, with(chunk([]object{}), object{"usage": usage}))
+	}
+	return Stream(append(events, Done)...)
+}
+
+func with(obj object, fields object) object {
+	merged := object{}
+	for k, v := range obj {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Responses
+
+func (s *Server) responseNew(r Request) Reply {
+	var params struct {
+		Model              string `json:"model"`
+		Stream             bool   `json:"stream"`
+		Background         bool   `json:"background"`
+		Store              *bool  `json:"store"`
+		PreviousResponseID string `json:"previous_response_id"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if params.PreviousResponseID != "" {
+		if _, ok := s.state.responses[params.PreviousResponseID]; !ok {
+			return notFound("response", params.PreviousResponseID)
+		}
+	}
+
+	inputTokens := (len(r.Body) + 3) / 4
+	res := object{
+		"id":                   s.state.id("resp_"),
+		"object":               "response",
+		"created_at":           now(),
+		"model":                params.Model,
+		"background":           params.Background,
+		"previous_response_id": nilIfEmpty(params.PreviousResponseID),
+		"status":               "completed",
+		"output":               []object{},
+		"parallel_tool_calls":  true,
+		"tool_choice":          "auto",
+		"tools":                []object{},
+		"error":                nil,
+		"incomplete_details":   nil,
+		"usage":                nil,
+	}
+	message := object{
+		"id":      s.state.id("msg_"),
+		"type":    "message",
+		"role":    "assistant",
+		"status":  "completed",
+		"content": []object{{"type": "output_text", "text": s.text(), "annotations": []object{}}},
+	}
+	completed := with(res, object{
+		"output": []object{message},
+		"usage":  object{"input_tokens": inputTokens, "output_tokens": len(s.words()), "total_tokens": inputTokens + len(s.words())},
+	})
+	if params.Store == nil || *params.Store || params.Background {
+		s.state.responses[res["id"].(string)] = completed
+	}
+
+	if params.Background && !params.Stream {
+		return JSON(with(res, object{"status": "queued"}))
+	}
+	if !params.Stream {
+		return JSON(completed)
+	}
+
+	var events []any
+	event := func(typ string, fields object) {
+		events = append(events, with(fields, object{"type": typ, "sequence_number": len(events)}))
+	}
+	inProgress := with(res, object{"status": "in_progress"})
+	event("response.created", object{"response": inProgress})
+	event("response.in_progress", object{"response": inProgress})
+	event("response.output_item.added", object{"output_index": 0, "item": with(message, object{"status": "in_progress", "content": []object{}})})
+	event("response.content_part.added", object{"output_index": 0, "content_index": 0, "item_id": message["id"], "part": object{"type": "output_text", "text": "", "annotations": []object{}}})
+	for _, word := range s.words() {
+		event("response.output_text.delta", object{"output_index": 0, "content_index": 0, "item_id": message["id"], "delta": word})
+	}
+	event("response.output_text.done", object{"output_index": 0, "content_index": 0, "item_id": message["id"], "text": s.text()})
+	event("response.content_part.done", object{"output_index": 0, "content_index": 0, "item_id": message["id"], "part": message["content"].([]object)[0]})
+	event("response.output_item.done", object{"output_index": 0, "item": message})
+	event("response.completed", object{"response": completed})
+	return Stream(events...)
+}
+
+func nilIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (s *Server) responseGet(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("response_id")
+	res, ok := s.state.responses[id]
+	if !ok {
+		return notFound("response", id)
+	}
+	// A background response is queued, then in progress, then done.
+	if res["background"] == true && res["status"] == "completed" {
+		s.state.responsePolls[id]++
+		switch s.state.responsePolls[id] {
+		case 1:
+			return JSON(with(res, object{"status": "queued", "output": []object{}, "usage": nil}))
+		case 2:
+			return JSON(with(res, object{"status": "in_progress", "output": []object{}, "usage": nil}))
+		}
+	}
+	return JSON(res)
+}
+
+func (s *Server) responseDelete(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("response_id")
+	if _, ok := s.state.responses[id]; !ok {
+		return notFound("response", id)
+	}
+	delete(s.state.responses, id)
+	return Reply{StatusCode: http.StatusOK}
+}
+
+func (s *Server) responseCancel(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("response_id")
+	res, ok := s.state.responses[id]
+	if !ok {
+		return notFound("response", id)
+	}
+	if res["background"] != true {
+		return invalid("Only background responses can be cancelled.")
+	}
+	res = with(res, object{"status": "cancelled", "output": []object{}, "usage": nil})
+	s.state.responses[id] = res
+	return JSON(res)
+}
+
+// responseInputTokens counts the tokens of the input as those of a response, adding
+// the tokens of the responses it follows.
+func (s *Server) responseInputTokens(r Request) Reply {
+	var params struct {
+		PreviousResponseID string `json:"previous_response_id"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens := (len(r.Body) + 3) / 4
+	for id := params.PreviousResponseID; id != ""; {
+		res, ok := s.state.responses[id]
+		if !ok {
+			return notFound("response", id)
+		}
+		// A cancelled response has no usage.
+		if usage, ok := res["usage"].(object); ok {
+			total, _ := usage["total_tokens"].(int)
+			tokens += total
+		}
+		id, _ = res["previous_response_id"].(string)
+	}
+	return JSON(object{"object": "response.input_tokens", "input_tokens": tokens})
+}
+
+// responseCompact keeps the messages of the user of the input, followed by a
+// compaction item.
+func (s *Server) responseCompact(r Request) Reply {
+	var params struct {
+		Model              string          `json:"model"`
+		PreviousResponseID string          `json:"previous_response_id"`
+		Input              json.RawMessage `json:"input"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if params.PreviousResponseID != "" {
+		if _, ok := s.state.responses[params.PreviousResponseID]; !ok {
+			return notFound("response", params.PreviousResponseID)
+		}
+	}
+
+	var output []object
+	var text string
+	var items []object
+	if json.Unmarshal(params.Input, &text) == nil {
+		items = []object{{"role": "user", "content": text}}
+	} else {
+		json.Unmarshal(params.Input, &items)
+	}
+	for _, item := range items {
+		if item["role"] == "user" {
+			output = append(output, with(item, object{"id": s.state.id("msg_"), "type": "message", "status": "completed"}))
+		}
+	}
+	output = append(output, object{"id": s.state.id("cmp_"), "type": "compaction", "encrypted_content": "openaitest"})
+
+	inputTokens := (len(r.Body) + 3) / 4
+	return JSON(object{
+		"id":         s.state.id("resp_"),
+		"object":     "response.compaction",
+		"created_at": now(),
+		"output":     output,
+		"usage":      object{"input_tokens": inputTokens, "output_tokens": 1, "total_tokens": inputTokens + 1},
+	})
+}
+
+// Embeddings
+
+func (s *Server) embeddingNew(r Request) Reply {
+	var params struct {
+		Model      string          `json:"model"`
+		Input      json.RawMessage `json:"input"`
+		Dimensions int             `json:"dimensions"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	inputs := 1
+	var array []json.RawMessage
+	if json.Unmarshal(params.Input, &array) == nil && len(array) > 0 && array[0][0] != '-' && (array[0][0] < '0' || array[0][0] > '9') {
+		inputs = len(array)
+	}
+	dimensions := params.Dimensions
+	if dimensions <= 0 {
+		dimensions = 8
+	}
+
+	data := []object{}
+	for i := range inputs {
+		embedding := make([]float64, dimensions)
+		for j := range embedding {
+			embedding[j] = float64((i+j)%10) / 10
+		}
+		data = append(data, object{"object": "embedding", "index": i, "embedding": embedding})
+	}
+	tokens := (len(params.Input) + 3) / 4
+	return JSON(object{
+		"object": "list",
+		"model":  params.Model,
+		"data":   data,
+		"usage":  object{"prompt_tokens": tokens, "total_tokens": tokens},
+	})
+}
+
+// Files
+
+func parseMultipart(r Request) (*multipart.Form, error) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	return multipart.NewReader(bytes.NewReader(r.Body), params["boundary"]).ReadForm(32 << 20)
+}
+
+func formFile(form *multipart.Form, name string) (filename string, content []byte, err error) {
+	if len(form.File[name]) == 0 {
+		return "", nil, fmt.Errorf("missing required parameter: '%s'", name)
+	}
+	header := form.File[name][0]
+	f, err := header.Open()
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+	content, err = io.ReadAll(f)
+	return header.Filename, content, err
+}
+
+// newFile stores a file, the mutex must be held.
+func (s *Server) newFile(filename string, purpose string, content []byte) object {
+	file := object{
+		"id":         s.state.id("file-"),
+		"object":     "file",
+		"bytes":      len(content),
+		"created_at": now(),
+		"filename":   filename,
+		"purpose":    purpose,
+		"status":     "processed",
+	}
+	s.state.files[file["id"].(string)] = file
+	s.state.fileContents[file["id"].(string)] = content
+	return file
+}
+
+func (s *Server) fileNew(r Request) Reply {
+	form, err := parseMultipart(r)
+	if err != nil {
+		return invalid(err.Error())
+	}
+	filename, content, err := formFile(form, "file")
+	if err != nil {
+		return invalid(err.Error())
+	}
+	if len(form.Value["purpose"]) == 0 {
+		return invalid("missing required parameter: 'purpose'")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return JSON(s.newFile(filename, form.Value["purpose"][0], content))
+}
+
+func (s *Server) fileList(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := map[string]object{}
+	for id, file := range s.state.files {
+		if purpose := r.Query.Get("purpose"); purpose == "" || file["purpose"] == purpose {
+			files[id] = file
+		}
+	}
+	return JSON(s.state.list(files))
+}
+
+func (s *Server) fileGet(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("file_id")
+	if file, ok := s.state.files[id]; ok {
+		return JSON(file)
+	}
+	return notFound("file", id)
+}
+
+func (s *Server) fileDelete(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("file_id")
+	if _, ok := s.state.files[id]; !ok {
+		return notFound("file", id)
+	}
+	delete(s.state.files, id)
+	delete(s.state.fileContents, id)
+	return JSON(object{"id": id, "object": "file", "deleted": true})
+}
+
+func (s *Server) fileContent(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("file_id")
+	content, ok := s.state.fileContents[id]
+	if !ok {
+		return notFound("file", id)
+	}
+	return Reply{Header: http.Header{"Content-Type": []string{"application/octet-stream"}}, Body: content}
+}
+
+// Batches
+
+func (s *Server) batchNew(r Request) Reply {
+	var params struct {
+		InputFileID      string         `json:"input_file_id"`
+		Endpoint         string         `json:"endpoint"`
+		CompletionWindow string         `json:"completion_window"`
+		Metadata         map[string]any `json:"metadata"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.state.files[params.InputFileID]; !ok {
+		return invalid(fmt.Sprintf("Invalid 'input_file_id': '%s'.", params.InputFileID))
+	}
+	batch := object{
+		"id":                s.state.id("batch_"),
+		"object":            "batch",
+		"endpoint":          params.Endpoint,
+		"input_file_id":     params.InputFileID,
+		"completion_window": params.CompletionWindow,
+		"status":            "validating",
+		"created_at":        now(),
+		"metadata":          params.Metadata,
+		"request_counts":    object{"total": 0, "completed": 0, "failed": 0},
+	}
+	s.state.batches[batch["id"].(string)] = batch
+	return JSON(batch)
+}
+
+func (s *Server) batchList(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return JSON(s.state.list(s.state.batches))
+}
+
+// batchGet moves the batch forward on every poll: it is in progress on the
+// first one and completed on the second one.
+func (s *Server) batchGet(r Request) Reply {
+	s.mu.Lock()
+	id := r.PathValue("batch_id")
+	batch, ok := s.state.batches[id]
+	if !ok {
+		s.mu.Unlock()
+		return notFound("batch", id)
+	}
+	if batch["status"] != "validating" && batch["status"] != "in_progress" {
+		s.mu.Unlock()
+		return JSON(batch)
+	}
+	s.state.batchPolls[id]++
+	if s.state.batchPolls[id] == 1 {
+		batch["status"] = "in_progress"
+		batch["in_progress_at"] = now()
+		s.mu.Unlock()
+		return JSON(batch)
+	}
+	input := s.state.fileContents[batch["input_file_id"].(string)]
+	s.mu.Unlock()
+
+	// The requests are run without the mutex, as custom handlers may use the server.
+	var output, errors bytes.Buffer
+	total, failed := 0, 0
+	for _, line := range bytes.Split(bytes.TrimSpace(input), []byte("\n")) {
+		var req struct {
+			CustomID string          `json:"custom_id"`
+			Method   string          `json:"method"`
+			URL      string          `json:"url"`
+			Body     json.RawMessage `json:"body"`
+		}
+		if err := json.Unmarshal(line, &req); err != nil {
+			continue
+		}
+		total++
+		route := req.Method + " " + strings.TrimPrefix(req.URL, "/v1")
+		reply := Error(http.StatusNotFound, "not_found", fmt.Sprintf("Batches do not support %s", req.URL))
+		if handler := s.handler(route); handler != nil {
+			reply = handler(Request{Route: route, Method: req.Method, Path: strings.TrimPrefix(req.URL, "/v1/"), Header: http.Header{"Content-Type": []string{"application/json"}}, Body: req.Body})
+		}
+		statusCode := max(reply.StatusCode, http.StatusOK)
+		result, _ := json.Marshal(object{
+			"id":        "batch_req_" + req.CustomID,
+			"custom_id": req.CustomID,
+			"response":  object{"status_code": statusCode, "request_id": "req_" + req.CustomID, "body": json.RawMessage(encode(reply.Body))},
+			"error":     nil,
+		})
+		if statusCode >= http.StatusBadRequest {
+			failed++
+			errors.Write(append(result, '\n'))
+		} else {
+			output.Write(append(result, '\n'))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	batch["status"] = "completed"
+	batch["completed_at"] = now()
+	batch["request_counts"] = object{"total": total, "completed": total - failed, "failed": failed}
+	if output.Len() > 0 {
+		batch["output_file_id"] = s.newFile(id+"_output.jsonl", "batch_output", output.Bytes())["id"]
+	}
+	if errors.Len() > 0 {
+		batch["error_file_id"] = s.newFile(id+"_error.jsonl", "batch_output", errors.Bytes())["id"]
+	}
+	return JSON(batch)
+}
+
+// handler returns the custom or default handler of a route.
+func (s *Server) handler(route string) func(Request) Reply {
+	s.mu.Lock()
+	custom := s.handlers[route]
+	s.mu.Unlock()
+	if custom != nil {
+		return custom
+	}
+	return s.defaultHandlers()[route]
+}
+
+func (s *Server) batchCancel(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("batch_id")
+	batch, ok := s.state.batches[id]
+	if !ok {
+		return notFound("batch", id)
+	}
+	if batch["status"] == "validating" || batch["status"] == "in_progress" {
+		batch["status"] = "cancelled"
+		batch["cancelled_at"] = now()
+	}
+	return JSON(batch)
+}
+
+// Vector stores
+
+func (s *Server) vectorStoreNew(r Request) Reply {
+	var params struct {
+		Name     string         `json:"name"`
+		FileIDs  []string       `json:"file_ids"`
+		Metadata map[string]any `json:"metadata"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, fileID := range params.FileIDs {
+		if _, ok := s.state.files[fileID]; !ok {
+			return notFound("file", fileID)
+		}
+	}
+	store := object{
+		"id":             s.state.id("vs_"),
+		"object":         "vector_store",
+		"created_at":     now(),
+		"last_active_at": now(),
+		"name":           params.Name,
+		"metadata":       params.Metadata,
+		"status":         "completed",
+		"usage_bytes":    0,
+	}
+	s.state.vectorStores[store["id"].(string)] = store
+	for _, fileID := range params.FileIDs {
+		s.addVectorStoreFile(store, fileID)
+	}
+	s.countVectorStoreFiles(store)
+	return JSON(store)
+}
+
+// addVectorStoreFile adds a file to a vector store, the mutex must be held.
+func (s *Server) addVectorStoreFile(store object, fileID string) object {
+	file := object{
+		"id":              fileID,
+		"object":          "vector_store.file",
+		"created_at":      now(),
+		"vector_store_id": store["id"],
+		"status":          "completed",
+		"usage_bytes":     len(s.state.fileContents[fileID]),
+		"last_error":      nil,
+	}
+	id := store["id"].(string)
+	s.state.vectorStoreFiles[id] = append(s.state.vectorStoreFiles[id], file)
+	return file
+}
+
+func (s *Server) countVectorStoreFiles(store object) {
+	files := s.state.vectorStoreFiles[store["id"].(string)]
+	usage := 0
+	for _, file := range files {
+		usage += file["usage_bytes"].(int)
+	}
+	store["usage_bytes"] = usage
+	store["file_counts"] = object{"in_progress": 0, "completed": len(files), "failed": 0, "cancelled": 0, "total": len(files)}
+}
+
+func (s *Server) vectorStoreList(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return JSON(s.state.list(s.state.vectorStores))
+}
+
+func (s *Server) vectorStoreGet(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("vector_store_id")
+	if store, ok := s.state.vectorStores[id]; ok {
+		return JSON(store)
+	}
+	return notFound("vector store", id)
+}
+
+func (s *Server) vectorStoreDelete(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("vector_store_id")
+	if _, ok := s.state.vectorStores[id]; !ok {
+		return notFound("vector store", id)
+	}
+	delete(s.state.vectorStores, id)
+	delete(s.state.vectorStoreFiles, id)
+	return JSON(object{"id": id, "object": "vector_store.deleted", "deleted": true})
+}
+
+// vectorStoreSearch returns the files of the vector store which contain the
+// query, ignoring case.
+func (s *Server) vectorStoreSearch(r Request) Reply {
+	var params struct {
+		Query json.RawMessage `json:"query"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	var queries []string
+	var query string
+	if json.Unmarshal(params.Query, &query) == nil {
+		queries = []string{query}
+	} else {
+		json.Unmarshal(params.Query, &queries)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("vector_store_id")
+	if _, ok := s.state.vectorStores[id]; !ok {
+		return notFound("vector store", id)
+	}
+	results := []object{}
+	for _, file := range s.state.vectorStoreFiles[id] {
+		fileID := file["id"].(string)
+		content := string(s.state.fileContents[fileID])
+		for _, q := range queries {
+			if strings.Contains(strings.ToLower(content), strings.ToLower(q)) {
+				results = append(results, object{
+					"file_id":    fileID,
+					"filename":   s.state.files[fileID]["filename"],
+					"score":      1,
+					"attributes": object{},
+					"content":    []object{{"type": "text", "text": content}},
+				})
+				break
+			}
+		}
+	}
+	return JSON(object{"object": "vector_store.search_results.page", "search_query": queries, "data": results, "has_more": false, "next_page": nil})
+}
+
+func (s *Server) vectorStoreFileNew(r Request) Reply {
+	var params struct {
+		FileID string `json:"file_id"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("vector_store_id")
+	store, ok := s.state.vectorStores[id]
+	if !ok {
+		return notFound("vector store", id)
+	}
+	if _, ok := s.state.files[params.FileID]; !ok {
+		return notFound("file", params.FileID)
+	}
+	file := s.addVectorStoreFile(store, params.FileID)
+	s.countVectorStoreFiles(store)
+	return JSON(file)
+}
+
+func (s *Server) vectorStoreFileGet(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, fileID := r.PathValue("vector_store_id"), r.PathValue("file_id")
+	for _, file := range s.state.vectorStoreFiles[id] {
+		if file["id"] == fileID {
+			return JSON(file)
+		}
+	}
+	return notFound("vector store file", fileID)
+}
+
+// Uploads
+
+func (s *Server) uploadNew(r Request) Reply {
+	var params struct {
+		Bytes    int    `json:"bytes"`
+		Filename string `json:"filename"`
+		MimeType string `json:"mime_type"`
+		Purpose  string `json:"purpose"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	upload := object{
+		"id":         s.state.id("upload_"),
+		"object":     "upload",
+		"bytes":      params.Bytes,
+		"created_at": now(),
+		"expires_at": now() + 3600,
+		"filename":   params.Filename,
+		"purpose":    params.Purpose,
+		"status":     "pending",
+		"file":       nil,
+	}
+	s.state.uploads[upload["id"].(string)] = upload
+	return JSON(upload)
+}
+
+// pendingUpload returns the upload of the request if it can still be changed,
+// the mutex must be held.
+func (s *Server) pendingUpload(r Request) (object, *Reply) {
+	id := r.PathValue("upload_id")
+	upload, ok := s.state.uploads[id]
+	if !ok {
+		reply := notFound("upload", id)
+		return nil, &reply
+	}
+	if upload["status"] != "pending" {
+		reply := invalid(fmt.Sprintf("Upload %s is %s.", id, upload["status"]))
+		return nil, &reply
+	}
+	return upload, nil
+}
+
+func (s *Server) uploadPartNew(r Request) Reply {
+	form, err := parseMultipart(r)
+	if err != nil {
+		return invalid(err.Error())
+	}
+	_, content, err := formFile(form, "data")
+	if err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	upload, reply := s.pendingUpload(r)
+	if reply != nil {
+		return *reply
+	}
+	part := object{"id": s.state.id("part_"), "object": "upload.part", "created_at": now(), "upload_id": upload["id"]}
+	s.state.uploadParts[part["id"].(string)] = content
+	return JSON(part)
+}
+
+func (s *Server) uploadComplete(r Request) Reply {
+	var params struct {
+		PartIDs []string `json:"part_ids"`
+		MD5     string   `json:"md5"`
+	}
+	if err := json.Unmarshal(r.Body, &params); err != nil {
+		return invalid(err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	upload, reply := s.pendingUpload(r)
+	if reply != nil {
+		return *reply
+	}
+	var content []byte
+	for _, partID := range params.PartIDs {
+		part, ok := s.state.uploadParts[partID]
+		if !ok {
+			return notFound("part", partID)
+		}
+		content = append(content, part...)
+	}
+	if len(content) != upload["bytes"].(int) {
+		return invalid(fmt.Sprintf("The upload expects %d bytes, but its parts have %d bytes.", upload["bytes"], len(content)))
+	}
+	if sum := md5.Sum(content); params.MD5 != "" && params.MD5 != hex.EncodeToString(sum[:]) {
+		return invalid("The md5 checksum does not match the uploaded bytes.")
+	}
+	upload["status"] = "completed"
+	upload["file"] = s.newFile(upload["filename"].(string), upload["purpose"].(string), content)
+	return JSON(upload)
+}
+
+func (s *Server) uploadCancel(r Request) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	upload, reply := s.pendingUpload(r)
+	if reply != nil {
+		return *reply
+	}
+	upload["status"] = "cancelled"
+	return JSON(upload)
+}
@@ -0,0 +1,284 @@
+// Package openaitest provides an in-process fake of the OpenAI API, for tests of
+// code using an [openai.Client].
+//
+// The fake serves the chat completions, responses, embeddings, files, batches,
+// vector stores and uploads routes with plausible default replies, and keeps the
+// files, batches, vector stores, uploads and stored responses it creates. Tests
+// can queue replies for a route, such as errors or rate limits, and inspect the
+// requests the fake received:
+//
+//	srv := openaitest.NewServer()
+//	defer srv.Close()
+//	srv.Enqueue(openaitest.RouteChatCompletionNew, openaitest.RateLimited(time.Millisecond))
+//
+//	client := srv.Client()
+//	completion, err := client.Chat.Completions.New(ctx, params)
+//	...
+//	sent := srv.ChatCompletionRequests()
+//
+// A [Cassette] records the traffic of a client with the real API, to replay it
+// in later runs of the tests.
+package openaitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/responses"
+)
+
+// The routes served by a [Server], as method and path patterns relative to the
+// base URL of the client.
+const (
+	RouteChatCompletionNew   = "POST /chat/completions"
+	RouteResponseNew         = "POST /responses"
+	RouteResponseGet         = "GET /responses/{response_id}"
+	RouteResponseDelete      = "DELETE /responses/{response_id}"
+	RouteResponseCancel      = "POST /responses/{response_id}/cancel"
+	RouteResponseCompact     = "POST /responses/compact"
+	RouteResponseInputTokens = "POST /responses/input_tokens"
+	RouteEmbeddingNew        = "POST /embeddings"
+	RouteFileNew             = "POST /files"
+	RouteFileList            = "GET /files"
+	RouteFileGet             = "GET /files/{file_id}"
+	RouteFileDelete          = "DELETE /files/{file_id}"
+	RouteFileContent         = "GET /files/{file_id}/content"
+	RouteBatchNew            = "POST /batches"
+	RouteBatchList           = "GET /batches"
+	RouteBatchGet            = "GET /batches/{batch_id}"
+	RouteBatchCancel         = "POST /batches/{batch_id}/cancel"
+	RouteVectorStoreNew      = "POST /vector_stores"
+	RouteVectorStoreList     = "GET /vector_stores"
+	RouteVectorStoreGet      = "GET /vector_stores/{vector_store_id}"
+	RouteVectorStoreDelete   = "DELETE /vector_stores/{vector_store_id}"
+	RouteVectorStoreSearch   = "POST /vector_stores/{vector_store_id}/search"
+	RouteVectorStoreFileNew  = "POST /vector_stores/{vector_store_id}/files"
+	RouteVectorStoreFileGet  = "GET /vector_stores/{vector_store_id}/files/{file_id}"
+	RouteUploadNew           = "POST /uploads"
+	RouteUploadPartNew       = "POST /uploads/{upload_id}/parts"
+	RouteUploadComplete      = "POST /uploads/{upload_id}/complete"
+	RouteUploadCancel        = "POST /uploads/{upload_id}/cancel"
+)
+
+// DefaultText is the text generated by the default chat completion and response
+// replies.
+const DefaultText = "This is a test."
+
+// Server is a fake OpenAI API served over HTTP. Its replies carry an X-Request-Id
+// header, "req_1" for the first request it received, unless the reply sets one.
+// It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the fake, which ends with a slash.
+	URL string
+	// Text is the text generated by the default chat completion and response
+	// replies. Defaults to [DefaultText].
+	Text string
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	queued   map[string][]Reply
+	handlers map[string]func(Request) Reply
+	requests []Request
+	state    state
+}
+
+// Request is a request received by a [Server].
+type Request struct {
+	// The route which served the request, such as [RouteChatCompletionNew].
+	Route  string
+	Method string
+	// The path of the request, relative to the base URL.
+	Path   string
+	Query  url.Values
+	Header http.Header
+	// The body of the request, which is JSON or multipart.
+	Body []byte
+
+	pathValues map[string]string
+}
+
+// PathValue returns the value of a wildcard of the route, such as "file_id".
+func (r Request) PathValue(name string) string {
+	return r.pathValues[name]
+}
+
+// Decode decodes the JSON body of a request into params, such as
+// [openai.ChatCompletionNewParams].
+func Decode[P any](r Request) (params P, err error) {
+	err = json.Unmarshal(r.Body, &params)
+	return params, err
+}
+
+// NewServer starts a fake OpenAI API. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		queued:   map[string][]Reply{},
+		handlers: map[string]func(Request) Reply{},
+		state:    newState(),
+	}
+	mux := http.NewServeMux()
+	for route, handler := range s.defaultHandlers() {
+		mux.HandleFunc(route, s.serve(route, handler))
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		Error(http.StatusNotFound, "not_found", fmt.Sprintf("openaitest: no route for %s %s", r.Method, r.URL.Path)).write(w)
+	})
+	s.srv = httptest.NewServer(mux)
+	s.URL = s.srv.URL + "/"
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Options returns the request options pointing a client to the server.
+func (s *Server) Options() []option.RequestOption {
+	return []option.RequestOption{
+		option.WithBaseURL(s.URL),
+		option.WithHTTPClient(s.srv.Client()),
+		option.WithAPIKey("sk-openaitest"),
+	}
+}
+
+// Client returns a client of the server. The options are applied after the
+// [Server.Options].
+func (s *Server) Client(opts ...option.RequestOption) openai.Client {
+	return openai.NewClient(slices.Concat(s.Options(), opts)...)
+}
+
+// Enqueue queues replies for a route. Each request of the route is answered with
+// the next queued reply, and by the default handler of the route once there is
+// none left.
+func (s *Server) Enqueue(route string, replies ...Reply) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queued[route] = append(s.queued[route], replies...)
+}
+
+// Handle replaces the default handler of a route. Queued replies are still sent
+// first.
+func (s *Server) Handle(route string, handler func(Request) Reply) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[route] = handler
+}
+
+// Requests returns the requests received so far. When routes are given, only the
+// requests of those routes are returned.
+func (s *Server) Requests(routes ...string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var requests []Request
+	for _, r := range s.requests {
+		if len(routes) == 0 || slices.Contains(routes, r.Route) {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// Reset forgets the received requests, queued replies, custom handlers and every
+// object created on the server.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queued = map[string][]Reply{}
+	s.handlers = map[string]func(Request) Reply{}
+	s.requests = nil
+	s.state = newState()
+}
+
+// ChatCompletionRequests returns the params of the chat completions received so far.
+func (s *Server) ChatCompletionRequests() []openai.ChatCompletionNewParams {
+	return decodeAll[openai.ChatCompletionNewParams](s.Requests(RouteChatCompletionNew))
+}
+
+// ResponseRequests returns the params of the responses received so far.
+func (s *Server) ResponseRequests() []responses.ResponseNewParams {
+	return decodeAll[responses.ResponseNewParams](s.Requests(RouteResponseNew))
+}
+
+// EmbeddingRequests returns the params of the embeddings received so far.
+func (s *Server) EmbeddingRequests() []openai.EmbeddingNewParams {
+	return decodeAll[openai.EmbeddingNewParams](s.Requests(RouteEmbeddingNew))
+}
+
+// BatchRequests returns the params of the batches created so far.
+func (s *Server) BatchRequests() []openai.BatchNewParams {
+	return decodeAll[openai.BatchNewParams](s.Requests(RouteBatchNew))
+}
+
+// VectorStoreRequests returns the params of the vector stores created so far.
+func (s *Server) VectorStoreRequests() []openai.VectorStoreNewParams {
+	return decodeAll[openai.VectorStoreNewParams](s.Requests(RouteVectorStoreNew))
+}
+
+// UploadRequests returns the params of the uploads created so far.
+func (s *Server) UploadRequests() []openai.UploadNewParams {
+	return decodeAll[openai.UploadNewParams](s.Requests(RouteUploadNew))
+}
+
+func decodeAll[P any](requests []Request) []P {
+	params := make([]P, 0, len(requests))
+	for _, r := range requests {
+		p, _ := Decode[P](r)
+		params = append(params, p)
+	}
+	return params
+}
+
+func (s *Server) serve(route string, handler func(Request) Reply) http.HandlerFunc {
+	_, pattern, _ := strings.Cut(route, " ")
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req := Request{
+			Route:      route,
+			Method:     r.Method,
+			Path:       strings.TrimPrefix(r.URL.Path, "/"),
+			Query:      r.URL.Query(),
+			Header:     r.Header.Clone(),
+			Body:       body,
+			pathValues: map[string]string{},
+		}
+		for _, segment := range strings.Split(pattern, "/") {
+			if name, ok := strings.CutPrefix(segment, "{"); ok {
+				name = strings.TrimSuffix(name, "}")
+				req.pathValues[name] = r.PathValue(name)
+			}
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		w.Header().Set("X-Request-Id", fmt.Sprintf("req_%d", len(s.requests)))
+		queued := s.queued[route]
+		var reply *Reply
+		if len(queued) > 0 {
+			reply = &queued[0]
+			s.queued[route] = queued[1:]
+		}
+		custom := s.handlers[route]
+		s.mu.Unlock()
+
+		switch {
+		case reply != nil:
+			reply.write(w)
+		case custom != nil:
+			reply := custom(req)
+			reply.write(w)
+		default:
+			reply := handler(req)
+			reply.write(w)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package openaitest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/openaitest"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/responses"
+)
+
+func TestChatCompletion(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	params := openai.ChatCompletionNewParams{
+		Model:    openai.ChatModelGPT4o,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Say this is a test")},
+	}
+	completion, err := client.Chat.Completions.New(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content := completion.Choices[0].Message.Content; content != openaitest.DefaultText {
+		t.Errorf("expected %q, got %q", openaitest.DefaultText, content)
+	}
+
+	params.StreamOptions.IncludeUsage = openai.Bool(true)
+	stream := client.Chat.Completions.NewStreaming(ctx, params)
+	acc := openai.ChatCompletionAccumulator{}
+	for stream.Next() {
+		acc.AddChunk(stream.Current())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if content := acc.Choices[0].Message.Content; content != openaitest.DefaultText {
+		t.Errorf("expected %q, got %q", openaitest.DefaultText, content)
+	}
+	if acc.Usage.CompletionTokens == 0 {
+		t.Errorf("expected usage in the stream")
+	}
+
+	sent := srv.ChatCompletionRequests()
+	if len(sent) != 2 || sent[0].Model != openai.ChatModelGPT4o || sent[1].StreamOptions.IncludeUsage.Value != true {
+		t.Fatalf("unexpected recorded requests: %+v", sent)
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	srv.Enqueue(openaitest.RouteChatCompletionNew,
+		openaitest.RateLimited(time.Millisecond),
+		openaitest.JSON(map[string]any{"id": "chatcmpl-1", "choices": []any{map[string]any{"message": map[string]any{"content": "scripted"}}}}),
+	)
+	srv.Enqueue(openaitest.RouteEmbeddingNew, openaitest.InsufficientQuota())
+	client := srv.Client()
+	ctx := context.Background()
+
+	completion, err := client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Model:    openai.ChatModelGPT4o,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hello")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content := completion.Choices[0].Message.Content; content != "scripted" {
+		t.Errorf("expected the queued reply after a retry, got %q", content)
+	}
+	if n := len(srv.Requests(openaitest.RouteChatCompletionNew)); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+
+	_, err = client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: openai.EmbeddingModelTextEmbedding3Small,
+		Input: openai.EmbeddingNewParamsInputUnion{OfString: openai.String("Hello")},
+	}, option.WithMaxRetries(0))
+	var apierr *openai.Error
+	if !errors.As(err, &apierr) || apierr.StatusCode != http.StatusTooManyRequests || apierr.Code != "insufficient_quota" {
+		t.Fatalf("expected an insufficient_quota error, got %v", err)
+	}
+
+	srv.Handle(openaitest.RouteEmbeddingNew, func(r openaitest.Request) openaitest.Reply {
+		return openaitest.Error(http.StatusBadRequest, "invalid_model", "handled")
+	})
+	_, err = client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: openai.EmbeddingModelTextEmbedding3Small,
+		Input: openai.EmbeddingNewParamsInputUnion{OfString: openai.String("Hello")},
+	})
+	if !errors.As(err, &apierr) || apierr.Code != "invalid_model" {
+		t.Fatalf("expected the custom handler error, got %v", err)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	input := `{"custom_id":"a","method":"POST","url":"/v1/chat/completions","body":{"model":"gpt-4o","messages":[{"role":"user","content":"Hello"}]}}
+{"custom_id":"b","method":"POST","url":"/v1/embeddings","body":{"model":"text-embedding-3-small","input":["a","b"]}}
+`
+	file, err := client.Files.New(ctx, openai.FileNewParams{
+		File:    openai.File(strings.NewReader(input), "input.jsonl", "application/jsonl"),
+		Purpose: openai.FilePurposeBatch,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch, err := client.Batches.NewAndPoll(ctx, openai.BatchNewParams{
+		InputFileID:      file.ID,
+		Endpoint:         openai.BatchNewParamsEndpointV1ChatCompletions,
+		CompletionWindow: openai.BatchNewParamsCompletionWindow24h,
+	}, 0, option.WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batch.Status != openai.BatchStatusCompleted || batch.RequestCounts.Completed != 2 {
+		t.Fatalf("unexpected batch: %s", batch.RawJSON())
+	}
+
+	res, err := client.Files.Content(ctx, batch.OutputFileID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	output, _ := io.ReadAll(res.Body)
+	if lines := strings.Count(string(output), "\n"); lines != 2 || !strings.Contains(string(output), openaitest.DefaultText) {
+		t.Fatalf("unexpected batch output: %s", output)
+	}
+
+	if sent := srv.BatchRequests(); len(sent) != 1 || sent[0].InputFileID != file.ID {
+		t.Fatalf("unexpected recorded batches: %+v", sent)
+	}
+}
+
+func TestUploadAndVectorStore(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	content := bytes.Repeat([]byte("The quick brown fox. "), 100)
+	upload, err := client.Uploads.UploadFile(ctx, bytes.NewReader(content), int64(len(content)), openai.FilePurposeAssistants, "text/plain",
+		option.WithUploadFilename("fox.txt"), option.WithUploadPartSize(512), option.WithUploadMD5())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upload.Status != openai.UploadStatusCompleted || upload.File.Bytes != int64(len(content)) {
+		t.Fatalf("unexpected upload: %s", upload.RawJSON())
+	}
+
+	store, err := client.VectorStores.New(ctx, openai.VectorStoreNewParams{
+		Name:    openai.String("foxes"),
+		FileIDs: []string{upload.File.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.FileCounts.Completed != 1 {
+		t.Errorf("expected 1 completed file, got %d", store.FileCounts.Completed)
+	}
+
+	page, err := client.VectorStores.Search(ctx, store.ID, openai.VectorStoreSearchParams{
+		Query: openai.VectorStoreSearchParamsQueryUnion{OfString: openai.String("brown fox")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Data) != 1 || page.Data[0].FileID != upload.File.ID {
+		t.Fatalf("unexpected search results: %s", page.RawJSON())
+	}
+
+	_, err = client.VectorStores.Get(ctx, "vs_missing")
+	var apierr *openai.Error
+	if !errors.As(err, &apierr) || apierr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestResponses(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	srv.Text = "Streamed from the fake."
+	client := srv.Client()
+	ctx := context.Background()
+
+	stream := client.Responses.NewStreaming(ctx, responses.ResponseNewParams{
+		Model: openai.ChatModelGPT4o,
+		Input: responses.ResponseNewParamsInputUnion{OfString: openai.String("Hello")},
+	})
+	var text strings.Builder
+	var completed responses.Response
+	for stream.Next() {
+		switch event := stream.Current(); event.Type {
+		case "response.output_text.delta":
+			text.WriteString(event.Delta)
+		case "response.completed":
+			completed = event.Response
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if text.String() != srv.Text || completed.OutputText() != srv.Text {
+		t.Fatalf("unexpected stream: %q, %q", text.String(), completed.OutputText())
+	}
+
+	res, err := client.Responses.NewAndPoll(ctx, responses.ResponseNewParams{
+		Model:              openai.ChatModelGPT4o,
+		Input:              responses.ResponseNewParamsInputUnion{OfString: openai.String("Again")},
+		PreviousResponseID: openai.String(completed.ID),
+	}, 0, option.WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != responses.ResponseStatusCompleted || res.OutputText() != srv.Text {
+		t.Fatalf("unexpected response: %s", res.RawJSON())
+	}
+
+	if sent := srv.ResponseRequests(); len(sent) != 2 || sent[1].PreviousResponseID.Value != completed.ID {
+		t.Fatalf("unexpected recorded responses: %+v", sent)
+	}
+
+	// A cancelled response has no usage to count.
+	background, err := client.Responses.New(ctx, responses.ResponseNewParams{
+		Model:      openai.ChatModelGPT4o,
+		Background: openai.Bool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Responses.Cancel(ctx, background.ID); err != nil {
+		t.Fatal(err)
+	}
+	count, err := client.Responses.InputTokens.Count(ctx, responses.InputTokenCountParams{
+		PreviousResponseID: openai.String(background.ID),
+	})
+	if err != nil || count.InputTokens == 0 {
+		t.Fatalf("unexpected input tokens %+v: %v", count, err)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/respmeta"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ratelimit"
 	"github.com/tidwall/sjson"
 )
 
@@ -229,6 +231,16 @@ func WithResponseInto(dst **http.Response) RequestOption {
 	})
 }
 
+// WithResponseMetaInto returns a RequestOption that copies the metadata of the
+// response, such as its request ID, processing time and rate limits, into the
+// given address. It is also set when the request fails with an API error.
+func WithResponseMetaInto(dst *respmeta.Meta) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.ResponseMetaInto = dst
+		return nil
+	})
+}
+
 // WithRequestBody returns a RequestOption that provides a custom serialized body with the given
 // content type.
 //
@@ -259,6 +271,18 @@ func WithRequestTimeout(dur time.Duration) RequestOption {
 	})
 }
 
+// WithStreamIdleTimeout returns a RequestOption that fails a streamed response
+// with an openai.StreamIdleTimeoutError when it receives nothing for the
+// duration, including the pings of the server. Unlike [WithRequestTimeout], it
+// does not bound the whole stream, so long streams are not cut short. Other
+// responses, such as file downloads, are not affected.
+func WithStreamIdleTimeout(dur time.Duration) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.StreamIdleTimeout = dur
+		return nil
+	})
+}
+
 // WithEnvironmentProduction returns a RequestOption that sets the current
 // environment to be the "production" environment. An environment specifies which base URL
 // to use by default.
@@ -294,6 +318,183 @@ func WithProject(value string) RequestOption {
 func WithWebhookSecret(value string) requestconfig.PreRequestOptionFunc {
 	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
 		r.WebhookSecret = value
+		r.WebhookSecrets = nil
+		return nil
+	})
+}
+
+// WithWebhookSecrets returns a RequestOption that sets the webhook secrets
+// accepted when verifying webhooks, for rotating the secret: a webhook signed with
+// any of them is valid. The first secret is the current one.
+func WithWebhookSecrets(secrets ...string) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.WebhookSecret = ""
+		if len(secrets) > 0 {
+			r.WebhookSecret = secrets[0]
+		}
+		r.WebhookSecrets = secrets
+		return nil
+	})
+}
+
+// PollProgress describes the state of a resource being polled, see [WithPollProgress].
+type PollProgress = requestconfig.PollProgress
+
+// WithPollInterval returns a RequestOption that sets the wait between two requests
+// of the PollStatus and NewAndPoll helpers. By default the interval suggested by
+// the API is used, or one second.
+func WithPollInterval(interval time.Duration) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Polling.Interval = interval
+		return nil
+	})
+}
+
+// WithPollBackoff returns a RequestOption that multiplies the wait of the polling
+// helpers by multiplier after every request, up to maxInterval. A maxInterval of
+// 0 means the wait is not capped.
+func WithPollBackoff(multiplier float64, maxInterval time.Duration) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		if multiplier < 1 {
+			return fmt.Errorf("poll backoff multiplier must be at least 1, got %v", multiplier)
+		}
+		r.Polling.Multiplier = multiplier
+		r.Polling.MaxInterval = maxInterval
+		return nil
+	})
+}
+
+// WithPollMaxWait returns a RequestOption that limits the time spent by the polling
+// helpers. Once exceeded, they return the last fetched resource with an error
+// wrapping ErrPollTimeout.
+func WithPollMaxWait(maxWait time.Duration) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Polling.MaxWait = maxWait
+		return nil
+	})
+}
+
+// WithPollProgress returns a RequestOption that calls fn after every request made by
+// the polling helpers.
+func WithPollProgress(fn func(PollProgress)) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Polling.OnProgress = fn
+		return nil
+	})
+}
+
+// UploadProgress describes the state of a file being uploaded, see [WithUploadProgress].
+type UploadProgress = requestconfig.UploadProgress
+
+// WithUploadFilename returns a RequestOption that sets the name of the file uploaded
+// by the UploadFile helper.
+func WithUploadFilename(filename string) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Upload.Filename = filename
+		return nil
+	})
+}
+
+// WithUploadPartSize returns a RequestOption that sets the size of the parts sent by
+// the UploadFile helper. Parts are 64 MB by default, which is also the maximum.
+func WithUploadPartSize(size int64) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		if size <= 0 {
+			return fmt.Errorf("upload part size must be positive, got %d", size)
+		}
+		r.Upload.PartSize = size
+		return nil
+	})
+}
+
+// WithUploadConcurrency returns a RequestOption that sets the number of parts the
+// UploadFile helper sends at the same time. Each of them is held in memory.
+func WithUploadConcurrency(n int) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		if n <= 0 {
+			return fmt.Errorf("upload concurrency must be positive, got %d", n)
+		}
+		r.Upload.Concurrency = n
 		return nil
 	})
 }
+
+// WithUploadPartRetries returns a RequestOption that sets the number of additional
+// attempts the UploadFile helper makes for a failed part, on top of the retries of
+// every request.
+func WithUploadPartRetries(n int) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		if n <= 0 {
+			n = -1
+		}
+		r.Upload.PartRetries = n
+		return nil
+	})
+}
+
+// WithUploadMD5 returns a RequestOption that makes the UploadFile helper send the MD5
+// checksum of the file, so the API can verify the uploaded bytes.
+func WithUploadMD5() requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Upload.MD5 = true
+		return nil
+	})
+}
+
+// WithUploadProgress returns a RequestOption that calls fn every time the UploadFile
+// helper has sent a part. Calls are never concurrent.
+func WithUploadProgress(fn func(UploadProgress)) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Upload.OnProgress = fn
+		return nil
+	})
+}
+
+// WithStreamReconnects returns a RequestOption that resumes the stream of a
+// background response up to n times when its connection drops. The stream is
+// resumed after the last received sequence number, so no event is repeated.
+//
+// Only responses created with `background` set to true can be resumed. With
+// [WithStreamIdleTimeout], a connection which receives nothing for the idle
+// timeout is resumed too.
+func WithStreamReconnects(n int) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.StreamReconnects = n
+		return nil
+	})
+}
+
+// WithPagePrefetch returns a RequestOption that makes the auto pagers of cursor
+// pages fetch up to n pages in the background, ahead of the page being read, so
+// that the requests overlap the processing of the items. The pages are still
+// fetched one after the other, and at most n of them are held in memory.
+//
+// An auto pager which is not read any more stops fetching once n pages are
+// waiting to be read. Call its Close method to stop the prefetching right away,
+// and cancel the request in flight, when the items are not all read.
+func WithPagePrefetch(n int) requestconfig.PreRequestOptionFunc {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.PagePrefetch = n
+		return nil
+	})
+}
+
+// WithRateLimitInto returns a RequestOption that copies the rate limits reported by
+// the response headers into dst. The rate limits of a failed request are also
+// available from the RateLimitInfo method of its error.
+func WithRateLimitInto(dst *ratelimit.Info) RequestOption {
+	return WithMiddleware(func(req *http.Request, next MiddlewareNext) (*http.Response, error) {
+		res, err := next(req)
+		if res != nil {
+			*dst = ratelimit.Parse(res.Header)
+		}
+		return res, err
+	})
+}
+
+// WithRateLimiter returns a RequestOption that waits for limiter before sending a
+// request, so the requests and tokens sent per minute stay under its limits. Share
+// one limiter between every client using the same API key.
+func WithRateLimiter(limiter *ratelimit.Limiter) RequestOption {
+	return WithMiddleware(limiter.Middleware())
+}
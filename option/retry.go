@@ -0,0 +1,51 @@
+package option
+
+import (
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+)
+
+// RetryPolicy decides whether a request is retried, and how long to wait before
+// the next attempt. See [WithRetryPolicy].
+type RetryPolicy = requestconfig.RetryPolicy
+
+// RetryAttempt describes an attempt of a request, as passed to a [RetryPolicy].
+type RetryAttempt = requestconfig.RetryAttempt
+
+// BackoffRetryPolicy is a [RetryPolicy] with an exponential backoff. Its zero
+// value is the default policy of the client, and every field can be customized:
+//
+//	option.WithRetryPolicy(option.BackoffRetryPolicy{
+//		ShouldRetry: func(attempt option.RetryAttempt) bool {
+//			// A 429 is also sent when the quota is exhausted, which retrying does not fix.
+//			if attempt.ErrorCode() == "insufficient_quota" {
+//				return false
+//			}
+//			return option.DefaultShouldRetry(attempt)
+//		},
+//		MaxElapsed: 30 * time.Second,
+//	})
+type BackoffRetryPolicy = requestconfig.BackoffRetryPolicy
+
+// RetryPolicyFunc adapts a function to a [RetryPolicy].
+type RetryPolicyFunc func(attempt RetryAttempt) (retry bool, delay time.Duration)
+
+func (f RetryPolicyFunc) Retry(attempt RetryAttempt) (bool, time.Duration) { return f(attempt) }
+
+// DefaultShouldRetry retries connection errors, and responses with the status
+// codes 408, 409, 429 and 5xx, unless the API says otherwise with the
+// x-should-retry header.
+func DefaultShouldRetry(attempt RetryAttempt) bool {
+	return requestconfig.DefaultShouldRetry(attempt)
+}
+
+// WithRetryPolicy returns a RequestOption that decides which attempts of a request
+// are retried, and how long to wait between them. The number of retries is still
+// capped by [WithMaxRetries].
+func WithRetryPolicy(policy RetryPolicy) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.RetryPolicy = policy
+		return nil
+	})
+}
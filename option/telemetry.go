@@ -0,0 +1,96 @@
+package option
+
+import (
+	"log/slog"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+)
+
+// Tracer starts the spans of requests, see [WithTracer]. It follows the tracer of
+// OpenTelemetry, which can be adapted by converting the [slog.Attr] attributes to
+// attribute.KeyValue.
+type Tracer = requestconfig.Tracer
+
+// Span is a span started by a [Tracer].
+type Span = requestconfig.Span
+
+// Meter records the metrics of requests, see [WithMeter].
+type Meter = requestconfig.Meter
+
+// The names of the metrics recorded by a [Meter], from the semantic conventions
+// of OpenTelemetry for generative AI.
+const (
+	MetricOperationDuration = requestconfig.MetricOperationDuration
+	MetricTokenUsage        = requestconfig.MetricTokenUsage
+	MetricTimeToFirstChunk  = requestconfig.MetricTimeToFirstChunk
+)
+
+// WithLogger returns a RequestOption that logs every request to logger: each
+// attempt at the debug level, retries at the warn level, and the outcome of the
+// request at the info or error level. The attributes are the ones of the spans of
+// [WithTracer].
+//
+// Unlike [WithDebugLog], headers and bodies are not logged unless enabled with
+// [WithTelemetryHeaders] and [WithTelemetryBodies], and credentials are redacted.
+func WithLogger(logger *slog.Logger) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Telemetry.Logger = logger
+		return nil
+	})
+}
+
+// WithTracer returns a RequestOption that starts a span for every request, and a
+// child span for each of its attempts. The context of the attempts carries their
+// span, so the spans of an instrumented HTTP client are nested under them.
+//
+// The spans follow the semantic conventions of OpenTelemetry for generative AI,
+// with attributes such as gen_ai.request.model, gen_ai.usage.input_tokens,
+// gen_ai.usage.output_tokens, gen_ai.response.finish_reasons, openai.request.id
+// and http.request.resend_count. The span of a streamed request ends once the
+// stream is read or closed, and records gen_ai.response.time_to_first_chunk.
+func WithTracer(tracer Tracer) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Telemetry.Tracer = tracer
+		return nil
+	})
+}
+
+// WithMeter returns a RequestOption that records the duration, token usage and
+// time to first chunk of chat, completion and embedding requests. See
+// [MetricOperationDuration], [MetricTokenUsage] and [MetricTimeToFirstChunk].
+func WithMeter(meter Meter) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Telemetry.Meter = meter
+		return nil
+	})
+}
+
+// WithTelemetryHeaders returns a RequestOption that records the given request and
+// response headers in logs and spans. The Authorization, Api-Key and cookie
+// headers are always redacted.
+func WithTelemetryHeaders(names ...string) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Telemetry.Headers = append(r.Telemetry.Headers, names...)
+		return nil
+	})
+}
+
+// WithTelemetryBodies returns a RequestOption that records up to limit bytes of
+// the JSON request and response bodies in logs and spans, with API keys redacted.
+// Streamed responses are not recorded.
+func WithTelemetryBodies(limit int) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Telemetry.BodyLimit = limit
+		return nil
+	})
+}
+
+// WithTelemetryRedactor returns a RequestOption that rewrites the headers and
+// bodies recorded in logs and spans. The key is the name of the attribute, such
+// as "http.request.header.x-custom" or "http.request.body".
+func WithTelemetryRedactor(redact func(key string, value string) string) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Telemetry.Redact = redact
+		return nil
+	})
+}
@@ -0,0 +1,4 @@
+// Package iterutil provides helpers for the iterators returned by the All
+// methods of the streams and auto pagers, which yield an item or the error
+// ending the iteration. It requires Go 1.23.
+package iterutil
@@ -0,0 +1,90 @@
+//go:build go1.23
+
+package iterutil
+
+import (
+	"context"
+	"iter"
+)
+
+// Collect returns the items of seq, up to limit items when limit is positive.
+// The iteration stops at the limit, so the pages beyond it are not fetched.
+// The items collected before an error are returned with it.
+func Collect[T any](seq iter.Seq2[T, error], limit int) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+		if len(items) == limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+// Filter returns an iterator over the items of seq for which keep returns true,
+// and its error.
+func Filter[T any](seq iter.Seq2[T, error], keep func(T) bool) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range seq {
+			if err == nil && !keep(item) {
+				continue
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns an iterator over the items of seq transformed by fn, and its error.
+func Map[T any, U any](seq iter.Seq2[T, error], fn func(T) U) iter.Seq2[U, error] {
+	return func(yield func(U, error) bool) {
+		for item, err := range seq {
+			if err != nil {
+				var zero U
+				yield(zero, err)
+				return
+			}
+			if !yield(fn(item), nil) {
+				return
+			}
+		}
+	}
+}
+
+// Item is an item sent by [Chan], or the error ending the iteration.
+type Item[T any] struct {
+	Value T
+	Err   error
+}
+
+// Chan iterates over seq in a goroutine and sends its items to the returned
+// channel, which is closed when the iteration ends, for use in a select
+// statement. Cancelling ctx stops the iteration, which closes a stream, and the
+// channel is closed without sending the error of ctx.
+//
+//	for item := range iterutil.Chan(ctx, stream.All()) {
+//		if item.Err != nil {
+//			...
+//		}
+//	}
+func Chan[T any](ctx context.Context, seq iter.Seq2[T, error]) <-chan Item[T] {
+	ch := make(chan Item[T])
+	go func() {
+		defer close(ch)
+		for value, err := range seq {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case ch <- Item[T]{Value: value, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,396 @@
+// Package jsonschema derives JSON schemas from Go types. The schemas follow the
+// subset of JSON schema accepted by Structured Outputs and strict function
+// calling:
+//
+//   - every field is listed in "required", regardless of omitempty or omitzero
+//   - every object sets "additionalProperties" to false
+//   - pointer fields are nullable instead of optional
+//
+// Field names are taken from `json` struct tags, using the same rules as
+// encoding/json. Descriptions and enums are read from the `jsonschema` and
+// `jsonschema_description` tags:
+//
+//	type Answer struct {
+//		Mood   string  `json:"mood" jsonschema:"enum=happy,enum=sad"`
+//		Reason *string `json:"reason" jsonschema_description:"Why the model feels this way"`
+//	}
+//
+// Types which need a schema that cannot be expressed with tags can implement
+// [Schemer].
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schemer is implemented by types that provide their own JSON schema.
+type Schemer interface {
+	JSONSchema() map[string]any
+}
+
+// Properties is the ordered "properties" keyword of an object schema. Models
+// generate structured output in the order of the schema, so the order of the
+// struct fields is preserved when marshaling.
+type Properties []Property
+
+// Property is a single named entry of [Properties].
+type Property struct {
+	Name   string
+	Schema map[string]any
+}
+
+func (p Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, prop := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(prop.Name)
+		if err != nil {
+			return nil, err
+		}
+		schema, err := json.Marshal(prop.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(schema)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// For returns the strict JSON schema of T, which must be a struct or a pointer
+// to a struct.
+func For[T any]() (map[string]any, error) {
+	return Reflect(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// Reflect returns the strict JSON schema of t, which must be a struct or a
+// pointer to a struct.
+func Reflect(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return nil, fmt.Errorf("jsonschema: the root of a schema must be a struct, got %s", t)
+	}
+
+	r := &reflector{
+		root:      t,
+		visiting:  map[reflect.Type]bool{},
+		recursive: map[reflect.Type]bool{},
+		defNames:  map[reflect.Type]string{},
+		defs:      map[string]any{},
+	}
+	schema, err := r.schema(t)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.defs) > 0 {
+		schema["$defs"] = r.defs
+	}
+	return schema, nil
+}
+
+// Name returns a name for the schema of t that satisfies the naming rules of
+// the API: at most 64 letters, digits, underscores and dashes.
+func Name(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// Drop the package paths from the type arguments of generic types.
+	var b strings.Builder
+	segment := 0
+	for _, r := range t.Name() {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		case r == '.' || r == '/':
+			s := b.String()[:segment]
+			b.Reset()
+			b.WriteString(s)
+		default:
+			b.WriteByte('_')
+			segment = b.Len()
+		}
+	}
+	name := strings.Trim(b.String(), "_")
+	if name == "" {
+		return "response"
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	schemerType     = reflect.TypeOf((*Schemer)(nil)).Elem()
+	jsonMarshalType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+type reflector struct {
+	root      reflect.Type
+	visiting  map[reflect.Type]bool
+	recursive map[reflect.Type]bool
+	defNames  map[reflect.Type]string
+	defs      map[string]any
+}
+
+func (r *reflector) schema(t reflect.Type) (map[string]any, error) {
+	if t.Implements(schemerType) && t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+		return maps.Clone(reflect.New(t).Elem().Interface().(Schemer).JSONSchema()), nil
+	}
+	if reflect.PointerTo(t).Implements(schemerType) {
+		return maps.Clone(reflect.New(t).Interface().(Schemer).JSONSchema()), nil
+	}
+
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case rawMessageType:
+		return nil, fmt.Errorf("jsonschema: %s has no schema, implement jsonschema.Schemer to provide one", t)
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema, err := r.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(schema), nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Slice, reflect.Array:
+		// encoding/json encodes byte slices as base64 strings
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && !t.Elem().Implements(jsonMarshalType) {
+			return map[string]any{"type": "string"}, nil
+		}
+		items, err := r.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Struct:
+		return r.object(t)
+	}
+	return nil, fmt.Errorf("jsonschema: unsupported type %s, strict schemas only support structs, slices, strings, numbers and booleans", t)
+}
+
+func (r *reflector) object(t reflect.Type) (map[string]any, error) {
+	if r.visiting[t] {
+		if t == r.root {
+			return map[string]any{"$ref": "#"}, nil
+		}
+		r.recursive[t] = true
+		return map[string]any{"$ref": "#/$defs/" + r.defName(t)}, nil
+	}
+	r.visiting[t] = true
+	defer delete(r.visiting, t)
+
+	props := Properties{}
+	if err := r.fields(t, &props, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	required := make([]string, len(props))
+	for i, prop := range props {
+		required[i] = prop.Name
+	}
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+
+	if r.recursive[t] && t != r.root {
+		name := r.defName(t)
+		r.defs[name] = schema
+		return map[string]any{"$ref": "#/$defs/" + name}, nil
+	}
+	return schema, nil
+}
+
+// fields adds the properties of the struct t, flattening embedded structs like
+// encoding/json does.
+func (r *reflector) fields(t reflect.Type, props *Properties, seen map[string]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" || field.Tag.Get("jsonschema") == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := field.Type
+		if field.Anonymous && name == "" {
+			embedded := ft
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := r.fields(embedded, props, seen); err != nil {
+					return err
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		isNullable := false
+		if ft.Kind() == reflect.Pointer && !ft.Implements(schemerType) {
+			ft = ft.Elem()
+			isNullable = true
+		}
+
+		var schema map[string]any
+		if hasOption(opts, "string") && isScalar(ft) {
+			schema = map[string]any{"type": "string"}
+		} else {
+			var err error
+			schema, err = r.schema(ft)
+			if err != nil {
+				return fmt.Errorf("%w (in field %s.%s)", err, t, field.Name)
+			}
+		}
+		if err := applyTags(schema, field, ft); err != nil {
+			return err
+		}
+		if isNullable {
+			schema = nullable(schema)
+		}
+		*props = append(*props, Property{Name: name, Schema: schema})
+	}
+	return nil
+}
+
+func (r *reflector) defName(t reflect.Type) string {
+	if name, ok := r.defNames[t]; ok {
+		return name
+	}
+	base := Name(t)
+	name := base
+	for i := 2; ; i++ {
+		taken := false
+		for _, other := range r.defNames {
+			if other == name {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			break
+		}
+		name = base + strconv.Itoa(i)
+	}
+	r.defNames[t] = name
+	return name
+}
+
+// applyTags sets the description and enum of a field schema from its struct
+// tags.
+func applyTags(schema map[string]any, field reflect.StructField, t reflect.Type) error {
+	var enum []any
+	for _, part := range strings.Split(field.Tag.Get("jsonschema"), ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "description":
+			schema["description"] = value
+		case "enum":
+			v, err := enumValue(value, t)
+			if err != nil {
+				return fmt.Errorf("jsonschema: invalid enum value %q for field %s: %w", value, field.Name, err)
+			}
+			enum = append(enum, v)
+		}
+	}
+	if desc, ok := field.Tag.Lookup("jsonschema_description"); ok {
+		schema["description"] = desc
+	}
+	if len(enum) > 0 {
+		schema["enum"] = enum
+	}
+	return nil
+}
+
+func enumValue(value string, t reflect.Type) (any, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseInt(value, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	}
+	return value, nil
+}
+
+// nullable allows null in addition to the values accepted by schema.
+func nullable(schema map[string]any) map[string]any {
+	if typ, ok := schema["type"].(string); ok {
+		if _, hasRef := schema["$ref"]; !hasRef {
+			schema["type"] = []any{typ, "null"}
+			if enum, ok := schema["enum"].([]any); ok {
+				schema["enum"] = append(enum, nil)
+			}
+			return schema
+		}
+	}
+	outer := map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+	if desc, ok := schema["description"]; ok {
+		delete(schema, "description")
+		outer["description"] = desc
+	}
+	return outer
+}
+
+func hasOption(opts string, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3/packages/jsonschema"
+)
+
+type Origin struct {
+	YearBuilt    int64  `json:"year_of_construction" jsonschema_description:"The year it was made"`
+	Organization string `json:"organization,omitempty"`
+}
+
+type Embedded struct {
+	Notes []string `json:"notes"`
+}
+
+type HistoricalComputer struct {
+	Name    string    `json:"full_name"`
+	Origin  Origin    `json:"origin"`
+	Legacy  string    `json:"legacy" jsonschema:"enum=positive,enum=neutral,enum=negative"`
+	Rating  *int      `json:"rating" jsonschema:"enum=1,enum=2,enum=3"`
+	Built   time.Time `json:"built"`
+	Ignored string    `json:"-"`
+	private string
+	Embedded
+}
+
+func TestForStruct(t *testing.T) {
+	schema, err := jsonschema.For[HistoricalComputer]()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	expected := `{"additionalProperties":false,"properties":{` +
+		`"full_name":{"type":"string"},` +
+		`"origin":{"additionalProperties":false,"properties":{"year_of_construction":{"description":"The year it was made","type":"integer"},"organization":{"type":"string"}},"required":["year_of_construction","organization"],"type":"object"},` +
+		`"legacy":{"enum":["positive","neutral","negative"],"type":"string"},` +
+		`"rating":{"enum":[1,2,3,null],"type":["integer","null"]},` +
+		`"built":{"format":"date-time","type":"string"},` +
+		`"notes":{"items":{"type":"string"},"type":"array"}` +
+		`},"required":["full_name","origin","legacy","rating","built","notes"],"type":"object"}`
+	if string(data) != expected {
+		t.Fatalf("unexpected schema\nexpected: %s\ngot:      %s", expected, data)
+	}
+}
+
+type Tree struct {
+	Value    string `json:"value"`
+	Children []Tree `json:"children"`
+}
+
+type Forest struct {
+	Trees []Node `json:"trees"`
+}
+
+type Node struct {
+	Next *Node `json:"next" jsonschema_description:"The next node"`
+}
+
+func TestForRecursive(t *testing.T) {
+	schema, err := jsonschema.For[Tree]()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	data, _ := json.Marshal(schema)
+	expected := `{"additionalProperties":false,"properties":{"value":{"type":"string"},"children":{"items":{"$ref":"#"},"type":"array"}},"required":["value","children"],"type":"object"}`
+	if string(data) != expected {
+		t.Fatalf("unexpected schema\nexpected: %s\ngot:      %s", expected, data)
+	}
+
+	schema, err = jsonschema.For[Forest]()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	data, _ = json.Marshal(schema)
+	expected = `{"$defs":{"Node":{"additionalProperties":false,"properties":{"next":{"anyOf":[{"$ref":"#/$defs/Node"},{"type":"null"}],"description":"The next node"}},"required":["next"],"type":"object"}},` +
+		`"additionalProperties":false,"properties":{"trees":{"items":{"$ref":"#/$defs/Node"},"type":"array"}},"required":["trees"],"type":"object"}`
+	if string(data) != expected {
+		t.Fatalf("unexpected schema\nexpected: %s\ngot:      %s", expected, data)
+	}
+}
+
+type Color string
+
+func (Color) JSONSchema() map[string]any {
+	return map[string]any{"type": "string", "pattern": "^#[0-9a-f]{6}$"}
+}
+
+func TestForSchemer(t *testing.T) {
+	schema, err := jsonschema.For[struct {
+		Color Color `json:"color"`
+	}]()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	data, _ := json.Marshal(schema)
+	expected := `{"additionalProperties":false,"properties":{"color":{"pattern":"^#[0-9a-f]{6}$","type":"string"}},"required":["color"],"type":"object"}`
+	if string(data) != expected {
+		t.Fatalf("unexpected schema\nexpected: %s\ngot:      %s", expected, data)
+	}
+}
+
+func TestForUnsupported(t *testing.T) {
+	if _, err := jsonschema.For[[]string](); err == nil {
+		t.Fatal("expected an error for a non-struct root")
+	}
+	if _, err := jsonschema.For[struct {
+		Extra map[string]string `json:"extra"`
+	}](); err == nil {
+		t.Fatal("expected an error for a map field")
+	}
+	if _, err := jsonschema.For[struct {
+		Value any `json:"value"`
+	}](); err == nil {
+		t.Fatal("expected an error for an interface field")
+	}
+}
+
+type generic[T any] struct{ Value T }
+
+func TestName(t *testing.T) {
+	cases := map[reflect.Type]string{
+		reflect.TypeOf(HistoricalComputer{}):  "HistoricalComputer",
+		reflect.TypeOf(&HistoricalComputer{}): "HistoricalComputer",
+		reflect.TypeOf(generic[Origin]{}):     "generic_Origin",
+		reflect.TypeOf(struct{ A string }{}):  "response",
+	}
+	for typ, expected := range cases {
+		if got := jsonschema.Name(typ); got != expected {
+			t.Errorf("expected name %q for %s, got %q", expected, typ, got)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+//go:build go1.23
+
+package pagination
+
+import "iter"
+
+// all returns an iterator over the items of an auto pager. An error ends the
+// iteration, yielded with the zero value of T. The pager is closed when the
+// iteration ends if close is not nil.
+func all[T any](next func() bool, current func() T, err func() error, close func() error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if close != nil {
+			defer close()
+		}
+		for next() {
+			if !yield(current(), nil) {
+				return
+			}
+		}
+		if err := err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// All returns an iterator over the items of every page, fetched as the
+// iteration reaches them, for use with a range loop.
+//
+//	for item, err := range pager.All() {
+//		if err != nil {
+//			...
+//		}
+//	}
+func (r *PageAutoPager[T]) All() iter.Seq2[T, error] {
+	return all(r.Next, r.Current, r.Err, nil)
+}
+
+// All returns an iterator over the items of every page, fetched as the
+// iteration reaches them, for use with a range loop. The pager is closed when
+// the iteration ends, which stops the prefetching of its pages.
+func (r *CursorPageAutoPager[T]) All() iter.Seq2[T, error] {
+	return all(r.Next, r.Current, r.Err, r.Close)
+}
+
+// All returns an iterator over the items of every page, fetched as the
+// iteration reaches them, for use with a range loop. The pager is closed when
+// the iteration ends, which stops the prefetching of its pages.
+func (r *ConversationCursorPageAutoPager[T]) All() iter.Seq2[T, error] {
+	return all(r.Next, r.Current, r.Err, r.Close)
+}
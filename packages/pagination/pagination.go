@@ -178,19 +178,22 @@ func (r *CursorPage[T]) SetPageConfig(cfg *requestconfig.RequestConfig, res *htt
 }
 
 type CursorPageAutoPager[T any] struct {
-	page *CursorPage[T]
-	cur  T
-	idx  int
-	run  int
-	err  error
+	page     *CursorPage[T]
+	cur      T
+	idx      int
+	run      int
+	err      error
+	prefetch *prefetcher[*CursorPage[T]]
 	paramObj
 }
 
 func NewCursorPageAutoPager[T any](page *CursorPage[T], err error) *CursorPageAutoPager[T] {
-	return &CursorPageAutoPager[T]{
+	pager := &CursorPageAutoPager[T]{
 		page: page,
 		err:  err,
 	}
+	pager.startPrefetch()
+	return pager
 }
 
 func (r *CursorPageAutoPager[T]) Next() bool {
@@ -199,7 +202,7 @@ func (r *CursorPageAutoPager[T]) Next() bool {
 	}
 	if r.idx >= len(r.page.Data) {
 		r.idx = 0
-		r.page, r.err = r.page.GetNextPage()
+		r.page, r.err = r.nextPage()
 		if r.err != nil || r.page == nil || len(r.page.Data) == 0 {
 			return false
 		}
@@ -284,19 +287,22 @@ func (r *ConversationCursorPage[T]) SetPageConfig(cfg *requestconfig.RequestConf
 }
 
 type ConversationCursorPageAutoPager[T any] struct {
-	page *ConversationCursorPage[T]
-	cur  T
-	idx  int
-	run  int
-	err  error
+	page     *ConversationCursorPage[T]
+	cur      T
+	idx      int
+	run      int
+	err      error
+	prefetch *prefetcher[*ConversationCursorPage[T]]
 	paramObj
 }
 
 func NewConversationCursorPageAutoPager[T any](page *ConversationCursorPage[T], err error) *ConversationCursorPageAutoPager[T] {
-	return &ConversationCursorPageAutoPager[T]{
+	pager := &ConversationCursorPageAutoPager[T]{
 		page: page,
 		err:  err,
 	}
+	pager.startPrefetch()
+	return pager
 }
 
 func (r *ConversationCursorPageAutoPager[T]) Next() bool {
@@ -305,7 +311,7 @@ func (r *ConversationCursorPageAutoPager[T]) Next() bool {
 	}
 	if r.idx >= len(r.page.Data) {
 		r.idx = 0
-		r.page, r.err = r.page.GetNextPage()
+		r.page, r.err = r.nextPage()
 		if r.err != nil || r.page == nil || len(r.page.Data) == 0 {
 			return false
 		}
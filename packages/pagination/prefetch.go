@@ -0,0 +1,200 @@
+package pagination
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+)
+
+// prefetcher fetches the next pages of an auto pager in the background, one
+// after the other, holding at most n pages ahead of the page being read. The
+// fetching goroutine ends once n pages are waiting, and is started again when a
+// page is read, so that a pager which is not read any more holds no goroutine.
+type prefetcher[P any] struct {
+	ctx      context.Context
+	fetchCtx context.Context
+	cancel   context.CancelFunc
+	fetch    func(context.Context, P) (P, error)
+	more     func(P) bool
+	pages    chan prefetched[P]
+
+	mu       sync.Mutex
+	last     P
+	fetching bool
+	ended    bool
+}
+
+type prefetched[P any] struct {
+	page P
+	err  error
+}
+
+// newPrefetcher fetches the pages following page with fetch, until it returns a
+// page for which more is false, or an error. The requests use a context derived
+// from ctx, which is cancelled by stop.
+func newPrefetcher[P any](ctx context.Context, n int, page P, fetch func(context.Context, P) (P, error), more func(P) bool) *prefetcher[P] {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	p := &prefetcher[P]{
+		ctx:      ctx,
+		fetchCtx: fetchCtx,
+		cancel:   cancel,
+		fetch:    fetch,
+		more:     more,
+		pages:    make(chan prefetched[P], n),
+		last:     page,
+	}
+	p.fill()
+	return p
+}
+
+// fill starts fetching pages in the background, unless they are already being
+// fetched or n pages are waiting.
+func (p *prefetcher[P]) fill() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fetching || p.ended || len(p.pages) == cap(p.pages) {
+		return
+	}
+	p.fetching = true
+	go p.run()
+}
+
+func (p *prefetcher[P]) run() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Only this goroutine sends pages, so there is room for the page fetched
+	// while the lock is released.
+	for len(p.pages) < cap(p.pages) {
+		if !p.more(p.last) {
+			p.end()
+			break
+		}
+		last := p.last
+		p.mu.Unlock()
+		page, err := p.fetch(p.fetchCtx, last)
+		p.mu.Lock()
+		p.last = page
+		p.pages <- prefetched[P]{page: page, err: err}
+		if err != nil {
+			p.end()
+			break
+		}
+	}
+	p.fetching = false
+}
+
+func (p *prefetcher[P]) end() {
+	p.ended = true
+	close(p.pages)
+}
+
+// next returns the next page, or the error of the context of the requests once
+// it is cancelled.
+func (p *prefetcher[P]) next() (P, error) {
+	fetched, ok := <-p.pages
+	p.fill()
+	if ok {
+		return fetched.page, fetched.err
+	}
+	var zero P
+	return zero, p.ctx.Err()
+}
+
+func (p *prefetcher[P]) stop() {
+	p.cancel()
+}
+
+// prefetchContext returns the context of the requests of the pages following a
+// page requested with cfg, or false when they are not prefetched.
+func prefetchContext(cfg *requestconfig.RequestConfig) (context.Context, bool) {
+	if cfg == nil || cfg.PagePrefetch <= 0 {
+		return nil, false
+	}
+	if cfg.Context == nil {
+		return context.Background(), true
+	}
+	return cfg.Context, true
+}
+
+// startPrefetch starts fetching the next pages in the background when the
+// pages are listed with [option.WithPagePrefetch].
+func (r *CursorPageAutoPager[T]) startPrefetch() {
+	if r.page == nil {
+		return
+	}
+	ctx, ok := prefetchContext(r.page.cfg)
+	if !ok {
+		return
+	}
+	next := func(ctx context.Context, page *CursorPage[T]) (*CursorPage[T], error) {
+		// The next pages are requested with the context of the prefetcher, so that
+		// stopping it cancels the request in flight.
+		bound, cfg := *page, *page.cfg
+		cfg.Context = ctx
+		bound.cfg = &cfg
+		return bound.GetNextPage()
+	}
+	r.prefetch = newPrefetcher(ctx, r.page.cfg.PagePrefetch, r.page, next, func(page *CursorPage[T]) bool {
+		return page != nil && len(page.Data) > 0
+	})
+}
+
+// nextPage returns the page following the current one, prefetched when the
+// pages are listed with [option.WithPagePrefetch].
+func (r *CursorPageAutoPager[T]) nextPage() (*CursorPage[T], error) {
+	if r.prefetch != nil {
+		return r.prefetch.next()
+	}
+	return r.page.GetNextPage()
+}
+
+// Close stops fetching the next pages in the background, when they are listed
+// with [option.WithPagePrefetch]. The pager has no items left once closed.
+func (r *CursorPageAutoPager[T]) Close() error {
+	if r.prefetch != nil {
+		r.prefetch.stop()
+	}
+	r.page = nil
+	return nil
+}
+
+// startPrefetch starts fetching the next pages in the background when the
+// pages are listed with [option.WithPagePrefetch].
+func (r *ConversationCursorPageAutoPager[T]) startPrefetch() {
+	if r.page == nil {
+		return
+	}
+	ctx, ok := prefetchContext(r.page.cfg)
+	if !ok {
+		return
+	}
+	next := func(ctx context.Context, page *ConversationCursorPage[T]) (*ConversationCursorPage[T], error) {
+		bound, cfg := *page, *page.cfg
+		cfg.Context = ctx
+		bound.cfg = &cfg
+		return bound.GetNextPage()
+	}
+	r.prefetch = newPrefetcher(ctx, r.page.cfg.PagePrefetch, r.page, next, func(page *ConversationCursorPage[T]) bool {
+		return page != nil && len(page.Data) > 0
+	})
+}
+
+// nextPage returns the page following the current one, prefetched when the
+// pages are listed with [option.WithPagePrefetch].
+func (r *ConversationCursorPageAutoPager[T]) nextPage() (*ConversationCursorPage[T], error) {
+	if r.prefetch != nil {
+		return r.prefetch.next()
+	}
+	return r.page.GetNextPage()
+}
+
+// Close stops fetching the next pages in the background, when they are listed
+// with [option.WithPagePrefetch]. The pager has no items left once closed.
+func (r *ConversationCursorPageAutoPager[T]) Close() error {
+	if r.prefetch != nil {
+		r.prefetch.stop()
+	}
+	r.page = nil
+	return nil
+}
@@ -0,0 +1,48 @@
+package pagination
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// idle waits until p is not fetching pages.
+func idle[P any](t *testing.T, p *prefetcher[P]) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		p.mu.Lock()
+		fetching := p.fetching
+		p.mu.Unlock()
+		if !fetching {
+			return
+		}
+	}
+	t.Fatal("expected the prefetcher to stop fetching")
+}
+
+func TestPrefetcherEndsWhenFull(t *testing.T) {
+	var fetched atomic.Int32
+	p := newPrefetcher(context.Background(), 2, 0, func(ctx context.Context, page int) (int, error) {
+		fetched.Add(1)
+		return page + 1, nil
+	}, func(page int) bool { return page < 5 })
+
+	// The goroutine ends once 2 pages are waiting, instead of blocking.
+	idle(t, p)
+	if fetched.Load() != 2 {
+		t.Fatalf("expected 2 pages to be fetched, got %d", fetched.Load())
+	}
+	for want := 1; want <= 5; want++ {
+		if page, err := p.next(); page != want || err != nil {
+			t.Fatalf("expected page %d, got %d and %v", want, page, err)
+		}
+	}
+	if _, err := p.next(); err != nil {
+		t.Fatalf("expected no page and no error after the last page, got %v", err)
+	}
+	idle(t, p)
+	if fetched.Load() != 5 {
+		t.Fatalf("expected every page to be fetched once, got %d", fetched.Load())
+	}
+}
@@ -0,0 +1,195 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// Limiter throttles requests so they stay under a number of requests and tokens
+// per minute. It is safe for concurrent use, and is meant to be shared by every
+// goroutine using the same API key, usually as a middleware of the client:
+//
+//	limiter := ratelimit.NewLimiter(500, 200_000)
+//	client := openai.NewClient(option.WithMiddleware(limiter.Middleware()))
+//
+// The buckets start full, refill continuously, and are lowered when the API
+// reports fewer remaining requests or tokens than the limiter expects.
+type Limiter struct {
+	// EstimateTokens returns the number of tokens a request is expected to use.
+	// The body is only read for JSON requests, and is nil for others, such as
+	// file uploads. Defaults to [EstimateTokens].
+	EstimateTokens func(req *http.Request, body []byte) int
+
+	mu       sync.Mutex
+	requests *bucket
+	tokens   *bucket
+	now      func() time.Time
+}
+
+// NewLimiter returns a Limiter allowing requestsPerMinute requests and
+// tokensPerMinute tokens every minute. A limit of 0 is not enforced.
+func NewLimiter(requestsPerMinute int, tokensPerMinute int) *Limiter {
+	l := &Limiter{now: time.Now}
+	if requestsPerMinute > 0 {
+		l.requests = newBucket(float64(requestsPerMinute), l.now())
+	}
+	if tokensPerMinute > 0 {
+		l.tokens = newBucket(float64(tokensPerMinute), l.now())
+	}
+	return l
+}
+
+// Wait blocks until a request using the given number of tokens can be sent, or
+// ctx is done.
+func (l *Limiter) Wait(ctx context.Context, tokens int) error {
+	for {
+		l.mu.Lock()
+		wait := l.reserve(float64(tokens))
+		l.mu.Unlock()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a request and the tokens from the buckets if both have enough
+// of them, or returns how long to wait until they do.
+func (l *Limiter) reserve(tokens float64) time.Duration {
+	now := l.now()
+	var wait time.Duration
+	if l.requests != nil {
+		wait = max(wait, l.requests.wait(1, now))
+	}
+	if l.tokens != nil {
+		// A request larger than the bucket would never fit, so it waits for a
+		// full bucket instead.
+		tokens = min(tokens, l.tokens.capacity)
+		wait = max(wait, l.tokens.wait(tokens, now))
+	}
+	if wait > 0 {
+		return wait
+	}
+	if l.requests != nil {
+		l.requests.level--
+	}
+	if l.tokens != nil {
+		l.tokens.level -= tokens
+	}
+	return 0
+}
+
+// Update lowers the buckets to the remaining requests and tokens reported by the
+// API, as they also count the requests of other clients using the same key.
+func (l *Limiter) Update(info Info) {
+	if !info.Valid() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if l.requests != nil && info.LimitRequests > 0 {
+		l.requests.lower(float64(info.RemainingRequests), now)
+	}
+	if l.tokens != nil && info.LimitTokens > 0 {
+		l.tokens.lower(float64(info.RemainingTokens), now)
+	}
+}
+
+// Middleware returns a middleware, to use with option.WithMiddleware, which waits
+// for the limiter before every attempt of a request and updates it from the
+// rate limit headers of the response.
+func (l *Limiter) Middleware() func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	return func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+		// Other bodies, such as multipart uploads, are not read again only to
+		// be estimated.
+		var body []byte
+		if req.GetBody != nil && isJSON(req.Header) {
+			if rc, err := req.GetBody(); err == nil {
+				body, _ = io.ReadAll(rc)
+				rc.Close()
+			}
+		}
+		estimate := l.EstimateTokens
+		if estimate == nil {
+			estimate = EstimateTokens
+		}
+		if err := l.Wait(req.Context(), estimate(req, body)); err != nil {
+			return nil, err
+		}
+
+		res, err := next(req)
+		if res != nil {
+			l.Update(Parse(res.Header))
+		}
+		return res, err
+	}
+}
+
+// EstimateTokens estimates the tokens counted against the rate limit for a JSON
+// request body: about one token per four bytes of the body, plus the maximum
+// number of output tokens requested. Other bodies are estimated at 0 tokens.
+func EstimateTokens(req *http.Request, body []byte) int {
+	if !isJSON(req.Header) || len(bytes.TrimSpace(body)) == 0 {
+		return 0
+	}
+	tokens := (len(body) + 3) / 4
+	for _, key := range []string{"max_completion_tokens", "max_output_tokens", "max_tokens"} {
+		if v := gjson.GetBytes(body, key); v.Exists() {
+			tokens += int(v.Int())
+			break
+		}
+	}
+	return tokens
+}
+
+func isJSON(header http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// bucket is a token bucket refilled continuously to its capacity every minute.
+type bucket struct {
+	capacity float64
+	level    float64
+	last     time.Time
+}
+
+func newBucket(capacity float64, now time.Time) *bucket {
+	return &bucket{capacity: capacity, level: capacity, last: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.level = min(b.capacity, b.level+b.capacity*elapsed.Minutes())
+		b.last = now
+	}
+}
+
+func (b *bucket) wait(n float64, now time.Time) time.Duration {
+	b.refill(now)
+	if b.level >= n {
+		return 0
+	}
+	return time.Duration((n - b.level) / b.capacity * float64(time.Minute))
+}
+
+func (b *bucket) lower(remaining float64, now time.Time) {
+	b.refill(now)
+	b.level = min(b.level, remaining)
+}
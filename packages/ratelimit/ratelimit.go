@@ -0,0 +1,77 @@
+// Package ratelimit reads the rate limits reported by the API, and throttles
+// requests on the client side so they stay under them.
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Info holds the rate limits reported by the x-ratelimit-* headers of a response.
+// Fields are zero when the header is missing, check presence with [Info.Valid].
+type Info struct {
+	// The maximum number of requests permitted before exhausting the rate limit.
+	LimitRequests int64
+	// The maximum number of tokens permitted before exhausting the rate limit.
+	LimitTokens int64
+	// The remaining number of requests permitted before exhausting the rate limit.
+	RemainingRequests int64
+	// The remaining number of tokens permitted before exhausting the rate limit.
+	RemainingTokens int64
+	// The time until the request rate limit resets to its initial state.
+	ResetRequests time.Duration
+	// The time until the token rate limit resets to its initial state.
+	ResetTokens time.Duration
+
+	valid bool
+}
+
+// Valid reports whether the response carried at least one rate limit header.
+func (i Info) Valid() bool { return i.valid }
+
+// Parse reads the rate limits from the headers of a response.
+func Parse(header http.Header) (info Info) {
+	info.LimitRequests = parseInt(header, "x-ratelimit-limit-requests", &info.valid)
+	info.LimitTokens = parseInt(header, "x-ratelimit-limit-tokens", &info.valid)
+	info.RemainingRequests = parseInt(header, "x-ratelimit-remaining-requests", &info.valid)
+	info.RemainingTokens = parseInt(header, "x-ratelimit-remaining-tokens", &info.valid)
+	info.ResetRequests = parseDuration(header, "x-ratelimit-reset-requests", &info.valid)
+	info.ResetTokens = parseDuration(header, "x-ratelimit-reset-tokens", &info.valid)
+	return info
+}
+
+// FromResponse reads the rate limits from the headers of res, which may be nil.
+func FromResponse(res *http.Response) Info {
+	if res == nil {
+		return Info{}
+	}
+	return Parse(res.Header)
+}
+
+func parseInt(header http.Header, key string, valid *bool) int64 {
+	n, err := strconv.ParseInt(header.Get(key), 10, 64)
+	if err != nil {
+		return 0
+	}
+	*valid = true
+	return n
+}
+
+// parseDuration reads durations such as "1s", "6m0s" or "20ms". A plain number is
+// read as seconds.
+func parseDuration(header http.Header, key string, valid *bool) time.Duration {
+	v := header.Get(key)
+	if v == "" {
+		return 0
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		*valid = true
+		return d
+	}
+	if s, err := strconv.ParseFloat(v, 64); err == nil {
+		*valid = true
+		return time.Duration(s * float64(time.Second))
+	}
+	return 0
+}
@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	header := http.Header{}
+	header.Set("x-ratelimit-limit-requests", "60")
+	header.Set("x-ratelimit-remaining-requests", "59")
+	header.Set("x-ratelimit-limit-tokens", "150000")
+	header.Set("x-ratelimit-remaining-tokens", "149984")
+	header.Set("x-ratelimit-reset-requests", "1s")
+	header.Set("x-ratelimit-reset-tokens", "6m0.5s")
+
+	info := Parse(header)
+	expected := Info{
+		LimitRequests:     60,
+		LimitTokens:       150000,
+		RemainingRequests: 59,
+		RemainingTokens:   149984,
+		ResetRequests:     time.Second,
+		ResetTokens:       6*time.Minute + 500*time.Millisecond,
+		valid:             true,
+	}
+	if info != expected {
+		t.Fatalf("expected %+v, got %+v", expected, info)
+	}
+	if Parse(http.Header{}).Valid() {
+		t.Fatalf("expected no rate limit without headers")
+	}
+}
+
+func TestLimiterReserve(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewLimiter(2, 1000)
+	l.now = func() time.Time { return now }
+	l.requests.last, l.tokens.last = now, now
+
+	if l.reserve(400) != 0 || l.reserve(400) != 0 {
+		t.Fatalf("expected the first two requests to be sent immediately")
+	}
+	// The request bucket is empty and refills one request every 30 seconds.
+	if wait := l.reserve(100); wait != 30*time.Second {
+		t.Fatalf("expected to wait 30s for a request, got %s", wait)
+	}
+	now = now.Add(30 * time.Second)
+	// The token bucket holds 200 + 500 tokens, 100 short of 800.
+	if wait := l.reserve(800); wait != 6*time.Second {
+		t.Fatalf("expected to wait 6s for the tokens, got %s", wait)
+	}
+	if l.reserve(700) != 0 {
+		t.Fatalf("expected the request to fit")
+	}
+
+	now = now.Add(time.Minute)
+	l.Update(Info{LimitRequests: 2, RemainingRequests: 0, LimitTokens: 1000, RemainingTokens: 1000, valid: true})
+	if wait := l.reserve(1); wait != 30*time.Second {
+		t.Fatalf("expected the reported remaining requests to lower the bucket, got %s", wait)
+	}
+}
+
+func TestLimiterWaitContext(t *testing.T) {
+	l := NewLimiter(1, 0)
+	if err := l.Wait(context.Background(), 0); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, 0); err != context.DeadlineExceeded {
+		t.Fatalf("expected the wait to stop with the context, got %v", err)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	body := []byte(`{"model":"gpt-4o","input":"hello","max_output_tokens":100}`)
+	req, _ := http.NewRequest(http.MethodPost, "https://api.openai.com/v1/responses", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	if tokens := EstimateTokens(req, body); tokens != (len(body)+3)/4+100 {
+		t.Fatalf("unexpected estimate %d", tokens)
+	}
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	if tokens := EstimateTokens(req, body); tokens != 0 {
+		t.Fatalf("expected multipart bodies not to be estimated, got %d", tokens)
+	}
+}
+
+func TestMiddlewareSkipsOtherBodies(t *testing.T) {
+	l := NewLimiter(0, 1000)
+	reads := 0
+	req, _ := http.NewRequest(http.MethodPost, "https://api.openai.com/v1/files", strings.NewReader("--x--"))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	req.GetBody = func() (io.ReadCloser, error) {
+		reads++
+		return io.NopCloser(strings.NewReader("--x--")), nil
+	}
+	_, err := l.Middleware()(req, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+	})
+	if err != nil || reads != 0 {
+		t.Fatalf("expected the multipart body not to be read, got %d reads and %v", reads, err)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build go1.23
+
+package ssestream
+
+import "iter"
+
+// All returns an iterator over the events of the stream, for use with a range
+// loop. An error ends the iteration, yielded with the zero value of T. The
+// stream is closed when the iteration ends, including when the loop breaks.
+//
+//	for event, err := range stream.All() {
+//		if err != nil {
+//			...
+//		}
+//	}
+func (s *Stream[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer s.Close()
+		for s.Next() {
+			if !yield(s.Current(), nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
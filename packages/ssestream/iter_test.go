@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package ssestream_test
+
+import (
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ssestream"
+)
+
+func TestStreamAll(t *testing.T) {
+	decoder := &mockDecoder{events: []ssestream.Event{
+		{Data: []byte(`{"id":"1","data":"test1"}`)},
+		{Data: []byte(`{"id":"2","data":"test2"}`)},
+		{Data: []byte(`{"error":{"message":"overloaded","code":"server_error"}}`)},
+	}}
+	stream := ssestream.NewStream[testStruct](decoder, nil)
+
+	var ids []string
+	var errs []error
+	for chunk, err := range stream.All() {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, chunk.ID)
+	}
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+		t.Errorf("Expected the chunks before the error, got %v", ids)
+	}
+	if len(errs) != 1 || errs[0] != stream.Err() {
+		t.Errorf("Expected the error of the stream to end the iteration, got %v", errs)
+	}
+
+	stream = ssestream.NewStream[testStruct](&mockDecoder{events: decoder.events}, nil)
+	for range stream.All() {
+		break
+	}
+	if chunk, ok := stream.Peek(); !ok || chunk.ID != "2" {
+		t.Errorf("Expected a break to stop reading the stream, got %v", chunk)
+	}
+}
@@ -5,12 +5,16 @@ package ssestream
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apierror"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/respmeta"
 	"github.com/tidwall/gjson"
 )
 
@@ -26,18 +30,71 @@ func NewDecoder(res *http.Response) Decoder {
 		return nil
 	}
 
+	ctx := context.Background()
+	if res.Request != nil {
+		ctx = res.Request.Context()
+	}
+	body := newContextBody(ctx, res.Body)
+
 	var decoder Decoder
 	contentType := res.Header.Get("content-type")
 	if t, ok := decoderTypes[contentType]; ok {
-		decoder = t(res.Body)
+		decoder = metaDecoder{Decoder: t(body), meta: respmeta.FromResponse(res), ctx: ctx}
 	} else {
-		scn := bufio.NewScanner(res.Body)
+		scn := bufio.NewScanner(body)
 		scn.Buffer(nil, bufio.MaxScanTokenSize<<9)
-		decoder = &eventStreamDecoder{rc: res.Body, scn: scn}
+		decoder = &eventStreamDecoder{rc: body, scn: scn, meta: respmeta.FromResponse(res), ctx: ctx}
 	}
 	return decoder
 }
 
+// contextBody is a response body which is closed when its context is done, so
+// that a read waiting for the server returns with the error of the context.
+type contextBody struct {
+	ctx  context.Context
+	rc   io.ReadCloser
+	stop func() bool
+}
+
+func newContextBody(ctx context.Context, rc io.ReadCloser) *contextBody {
+	return &contextBody{ctx: ctx, rc: rc, stop: context.AfterFunc(ctx, func() { rc.Close() })}
+}
+
+func (b *contextBody) Read(p []byte) (int, error) {
+	n, err := b.rc.Read(p)
+	if err != nil && err != io.EOF && b.ctx.Err() != nil && !errors.Is(err, b.ctx.Err()) {
+		err = b.ctx.Err()
+	}
+	return n, err
+}
+
+func (b *contextBody) Close() error {
+	b.stop()
+	return b.rc.Close()
+}
+
+// MetaDecoder is implemented by the decoders which know the response they
+// decode, see [Stream.Meta].
+type MetaDecoder interface {
+	Meta() respmeta.Meta
+}
+
+// ContextDecoder is implemented by the decoders which know the context of the
+// request of their response, see [Stream.Context].
+type ContextDecoder interface {
+	Context() context.Context
+}
+
+// metaDecoder adds the metadata of the response to a registered decoder.
+type metaDecoder struct {
+	Decoder
+	meta respmeta.Meta
+	ctx  context.Context
+}
+
+func (d metaDecoder) Meta() respmeta.Meta      { return d.meta }
+func (d metaDecoder) Context() context.Context { return d.ctx }
+
 var decoderTypes = map[string](func(io.ReadCloser) Decoder){}
 
 func RegisterDecoder(contentType string, decoder func(io.ReadCloser) Decoder) {
@@ -68,19 +125,31 @@ func isWhitespaceOrEmpty(data []byte) bool {
 // preserving the original event data for structured access.
 type StreamError struct {
 	Message string
-	Event   Event
+	// The code and type of the error, when the event has them, such as
+	// "server_error".
+	Code  string
+	Type  string
+	Event Event
 }
 
 func (e *StreamError) Error() string {
 	return e.Message
 }
 
+// Is reports whether the error matches a sentinel such as openai.ErrServerError,
+// from its code and type.
+func (e *StreamError) Is(target error) bool {
+	return apierror.Matches(target, 0, e.Code, e.Type)
+}
+
 // A base implementation of a Decoder for text/event-stream.
 type eventStreamDecoder struct {
-	evt Event
-	rc  io.ReadCloser
-	scn *bufio.Scanner
-	err error
+	evt  Event
+	rc   io.ReadCloser
+	scn  *bufio.Scanner
+	err  error
+	meta respmeta.Meta
+	ctx  context.Context
 }
 
 func (s *eventStreamDecoder) Next() bool {
@@ -148,6 +217,14 @@ func (s *eventStreamDecoder) Err() error {
 	return s.err
 }
 
+func (s *eventStreamDecoder) Meta() respmeta.Meta {
+	return s.meta
+}
+
+func (s *eventStreamDecoder) Context() context.Context {
+	return s.ctx
+}
+
 type Stream[T any] struct {
 	decoder Decoder
 	cur     T
@@ -183,6 +260,8 @@ func (s *Stream[T]) readNext() bool {
 		if ep.Exists() {
 			s.err = &StreamError{
 				Message: fmt.Sprintf("received error while streaming: %s", ep.String()),
+				Code:    ep.Get("code").String(),
+				Type:    ep.Get("type").String(),
 				Event:   s.decoder.Event(),
 			}
 			return false
@@ -227,6 +306,10 @@ func (s *Stream[T]) Next() bool {
 	if s.err != nil {
 		return false
 	}
+	if err := s.Context().Err(); err != nil {
+		s.err = err
+		return false
+	}
 
 	if s.bufIdx < len(s.buffer) {
 		s.cur = s.buffer[s.bufIdx]
@@ -249,6 +332,28 @@ func (s *Stream[T]) Err() error {
 	return s.err
 }
 
+// Meta returns the metadata of the response of the stream, such as its request
+// ID, or of the error response when the request failed.
+func (s *Stream[T]) Meta() respmeta.Meta {
+	if d, ok := s.decoder.(MetaDecoder); ok {
+		return d.Meta()
+	}
+	var apierr *apierror.Error
+	if errors.As(s.err, &apierr) {
+		return apierr.Meta
+	}
+	return respmeta.Meta{}
+}
+
+// Context returns the context of the request of the stream. The stream ends with
+// the error of the context once it is done, without waiting for the server.
+func (s *Stream[T]) Context() context.Context {
+	if d, ok := s.decoder.(ContextDecoder); ok {
+		return d.Context()
+	}
+	return context.Background()
+}
+
 func (s *Stream[T]) Close() error {
 	if s.decoder == nil {
 		// already closed
@@ -0,0 +1,98 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+// pagedFiles serves pages of two files, file-1 to file-<2*pages>.
+func pagedFiles(pages int, requested func(after string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		requested(after)
+		start := 1
+		if after != "" {
+			n, _ := strconv.Atoi(after[len("file-"):])
+			start = n + 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"object": "list", "data": [{"id": "file-%d", "object": "file"}, {"id": "file-%d", "object": "file"}], "has_more": %t}`,
+			start, start+1, start+1 < 2*pages)
+	}
+}
+
+func TestAutoPagerPrefetch(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(pagedFiles(5, func(after string) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, after)
+	}))
+	defer srv.Close()
+	requested := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requests)
+	}
+
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+	ctx := context.Background()
+
+	iter := client.Files.ListAutoPaging(ctx, openai.FileListParams{}, option.WithPagePrefetch(2))
+	// The first page and the 2 pages prefetched while none is read.
+	deadline := time.Now().Add(5 * time.Second)
+	for requested() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := requested(); got != 3 {
+		t.Fatalf("expected the first page and 2 prefetched pages, got %d requests", got)
+	}
+
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Current().ID)
+		if iter.Index() != len(ids) {
+			t.Errorf("expected the index %d, got %d", len(ids), iter.Index())
+		}
+	}
+	if err := iter.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 10 || ids[0] != "file-1" || ids[9] != "file-10" {
+		t.Errorf("expected the files of every page in order, got %v", ids)
+	}
+	if requested() != 5 {
+		t.Errorf("expected every page to be requested once, got %q", requests)
+	}
+
+	// Closing the pager stops the prefetching.
+	iter = client.Files.ListAutoPaging(ctx, openai.FileListParams{}, option.WithPagePrefetch(1))
+	if !iter.Next() {
+		t.Fatal(iter.Err())
+	}
+	iter.Close()
+	if iter.Next() || iter.Err() != nil {
+		t.Errorf("expected no item once the pager is closed, got %v", iter.Err())
+	}
+
+	// Cancelling the context stops the prefetching with its error.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	iter = client.Files.ListAutoPaging(cancelCtx, openai.FileListParams{}, option.WithPagePrefetch(1))
+	cancel()
+	for iter.Next() {
+	}
+	if iter.Err() == nil {
+		t.Error("expected the error of the cancelled context")
+	}
+}
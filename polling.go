@@ -2,128 +2,219 @@ package openai
 
 import (
 	"context"
-	"fmt"
-	"net/http"
-	"strconv"
-	"time"
 
+	"github.com/Nordlys-Labs/openai-go/v3/internal/polling"
 	"github.com/Nordlys-Labs/openai-go/v3/option"
 )
 
-func mkPollingOptions(pollIntervalMs int) []option.RequestOption {
-	options := []option.RequestOption{option.WithHeader("X-Stainless-Poll-Helper", "true")}
-	if pollIntervalMs > 0 {
-		options = append(options, option.WithHeader("X-Stainless-Poll-Interval", fmt.Sprintf("%d", pollIntervalMs)))
-	}
-	return options
-}
+// ErrPollTimeout is wrapped by the error returned from the polling helpers when the
+// resource is still pending after the wait set with [option.WithPollMaxWait].
+var ErrPollTimeout = polling.ErrTimeout
 
-func getPollInterval(raw *http.Response) (ms int) {
-	if ms, err := strconv.Atoi(raw.Header.Get("openai-poll-after-ms")); err == nil {
-		return ms
-	}
-	return 1000
-}
+// Containers have no polling helpers: they can be used as soon as they are
+// created, and their status only changes when they expire.
 
 // PollStatus waits until a VectorStoreFile is no longer in an incomplete state and returns it.
 // Pass 0 as pollIntervalMs to use the default polling interval of 1 second.
 func (r *VectorStoreFileService) PollStatus(ctx context.Context, vectorStoreID string, fileID string, pollIntervalMs int, opts ...option.RequestOption) (*VectorStoreFile, error) {
-	var raw *http.Response
-	opts = append(opts, mkPollingOptions(pollIntervalMs)...)
-	opts = append(opts, option.WithResponseInto(&raw))
-	for {
-		file, err := r.Get(ctx, vectorStoreID, fileID, opts...)
-		if err != nil {
-			return nil, fmt.Errorf("vector store file poll: received %w", err)
-		}
-
-		switch file.Status {
-		case VectorStoreFileStatusInProgress:
-			if pollIntervalMs <= 0 {
-				pollIntervalMs = getPollInterval(raw)
+	return polling.Poll(ctx, polling.Target[VectorStoreFile]{
+		Name: "vector store file",
+		Get: func(opts ...option.RequestOption) (*VectorStoreFile, error) {
+			return r.Get(ctx, vectorStoreID, fileID, opts...)
+		},
+		Status: func(file *VectorStoreFile) (string, bool, bool) {
+			switch file.Status {
+			case VectorStoreFileStatusInProgress:
+				return string(file.Status), true, true
+			case VectorStoreFileStatusCancelled,
+				VectorStoreFileStatusCompleted,
+				VectorStoreFileStatusFailed:
+				return string(file.Status), false, true
 			}
-			time.Sleep(time.Duration(pollIntervalMs) * time.Millisecond)
-		case VectorStoreFileStatusCancelled,
-			VectorStoreFileStatusCompleted,
-			VectorStoreFileStatusFailed:
-			return file, nil
-		default:
-			return nil, fmt.Errorf("invalid vector store file status during polling: received %s", file.Status)
-		}
-
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-
-		}
-	}
+			return string(file.Status), false, false
+		},
+	}, pollIntervalMs, r.Options, opts)
 }
 
 // PollStatus waits until a BetaVectorStoreFileBatch is no longer in an incomplete state and returns it.
 // Pass 0 as pollIntervalMs to use the default polling interval of 1 second.
 func (r *VectorStoreFileBatchService) PollStatus(ctx context.Context, vectorStoreID string, batchID string, pollIntervalMs int, opts ...option.RequestOption) (*VectorStoreFileBatch, error) {
-	var raw *http.Response
-	opts = append(opts, option.WithResponseInto(&raw))
-	opts = append(opts, mkPollingOptions(pollIntervalMs)...)
-	for {
-		batch, err := r.Get(ctx, vectorStoreID, batchID, opts...)
-		if err != nil {
-			return nil, fmt.Errorf("vector store file batch poll: received %w", err)
-		}
+	return polling.Poll(ctx, polling.Target[VectorStoreFileBatch]{
+		Name: "vector store file batch",
+		Get: func(opts ...option.RequestOption) (*VectorStoreFileBatch, error) {
+			return r.Get(ctx, vectorStoreID, batchID, opts...)
+		},
+		Status: func(batch *VectorStoreFileBatch) (string, bool, bool) {
+			switch batch.Status {
+			case VectorStoreFileBatchStatusInProgress:
+				return string(batch.Status), true, true
+			case VectorStoreFileBatchStatusCancelled,
+				VectorStoreFileBatchStatusCompleted,
+				VectorStoreFileBatchStatusFailed:
+				return string(batch.Status), false, true
+			}
+			return string(batch.Status), false, false
+		},
+	}, pollIntervalMs, r.Options, opts)
+}
 
-		switch batch.Status {
-		case VectorStoreFileBatchStatusInProgress:
-			if pollIntervalMs <= 0 {
-				pollIntervalMs = getPollInterval(raw)
+// PollStatus waits until a Video is no longer queued or in progress and returns it.
+// Pass 0 as pollIntervalMs to use the default polling interval of 1 second.
+func (r *VideoService) PollStatus(ctx context.Context, videoID string, pollIntervalMs int, opts ...option.RequestOption) (*Video, error) {
+	return polling.Poll(ctx, polling.Target[Video]{
+		Name: "video",
+		Get: func(opts ...option.RequestOption) (*Video, error) {
+			return r.Get(ctx, videoID, opts...)
+		},
+		Status: func(video *Video) (string, bool, bool) {
+			switch video.Status {
+			case VideoStatusQueued, VideoStatusInProgress:
+				return string(video.Status), true, true
+			case VideoStatusCompleted,
+				VideoStatusFailed:
+				return string(video.Status), false, true
 			}
-			time.Sleep(time.Duration(pollIntervalMs) * time.Millisecond)
-		case VectorStoreFileBatchStatusCancelled,
-			VectorStoreFileBatchStatusCompleted,
-			VectorStoreFileBatchStatusFailed:
-			return batch, nil
-		default:
-			return nil, fmt.Errorf("invalid vector store file batch status during polling: received %s", batch.Status)
-		}
+			return string(video.Status), false, false
+		},
+	}, pollIntervalMs, r.Options, opts)
+}
 
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
+// PollStatus waits until an EvalRun is no longer queued or in progress and returns it.
+// Pass 0 as pollIntervalMs to use the default polling interval of 1 second.
+func (r *EvalRunService) PollStatus(ctx context.Context, evalID string, runID string, pollIntervalMs int, opts ...option.RequestOption) (*EvalRun, error) {
+	return polling.Poll(ctx, polling.Target[EvalRun]{
+		Name: "eval run",
+		Get: func(opts ...option.RequestOption) (*EvalRun, error) {
+			return r.Get(ctx, evalID, runID, opts...)
+		},
+		Status: func(run *EvalRun) (string, bool, bool) {
+			switch run.Status {
+			case EvalRunStatusQueued, EvalRunStatusInProgress:
+				return string(run.Status), true, true
+			case EvalRunStatusCompleted,
+				EvalRunStatusCanceled,
+				EvalRunStatusFailed:
+				return string(run.Status), false, true
+			}
+			return string(run.Status), false, false
+		},
+	}, pollIntervalMs, r.Options, opts)
+}
+
+// Creates a batch and polls the API until it is no longer in an incomplete state.
+//
+// Polls the API and blocks until the task is complete.
+// Default polling interval is 1 second.
+func (r *BatchService) NewAndPoll(ctx context.Context, body BatchNewParams, pollIntervalMs int, opts ...option.RequestOption) (res *Batch, err error) {
+	batch, err := r.New(ctx, body, opts...)
+	if err != nil {
+		return nil, err
 	}
+	return r.PollStatus(ctx, batch.ID, pollIntervalMs, opts...)
 }
 
-// PollStatus waits until a VectorStoreFile is no longer in an incomplete state and returns it.
+// PollStatus waits until a Batch is no longer in an incomplete state and returns it.
 // Pass 0 as pollIntervalMs to use the default polling interval of 1 second.
-func (r *VideoService) PollStatus(ctx context.Context, videoID string, pollIntervalMs int, opts ...option.RequestOption) (*Video, error) {
-	var raw *http.Response
-	opts = append(opts, mkPollingOptions(pollIntervalMs)...)
-	opts = append(opts, option.WithResponseInto(&raw))
-	for {
-		video, err := r.Get(ctx, videoID, opts...)
-		if err != nil {
-			return nil, fmt.Errorf("error running video poll: received %w", err)
-		}
-
-		switch video.Status {
-		case VideoStatusQueued, VideoStatusInProgress:
-			if pollIntervalMs <= 0 {
-				pollIntervalMs = getPollInterval(raw)
+func (r *BatchService) PollStatus(ctx context.Context, batchID string, pollIntervalMs int, opts ...option.RequestOption) (*Batch, error) {
+	return polling.Poll(ctx, polling.Target[Batch]{
+		Name: "batch",
+		Get: func(opts ...option.RequestOption) (*Batch, error) {
+			return r.Get(ctx, batchID, opts...)
+		},
+		Status: func(batch *Batch) (string, bool, bool) {
+			switch batch.Status {
+			case BatchStatusValidating,
+				BatchStatusInProgress,
+				BatchStatusFinalizing,
+				BatchStatusCancelling:
+				return string(batch.Status), true, true
+			case BatchStatusCompleted,
+				BatchStatusFailed,
+				BatchStatusExpired,
+				BatchStatusCancelled:
+				return string(batch.Status), false, true
 			}
-			time.Sleep(time.Duration(pollIntervalMs) * time.Millisecond)
-		case VideoStatusCompleted,
-			VideoStatusFailed:
-			return video, nil
-		default:
-			return nil, fmt.Errorf("invalid video status during polling: received %s", video.Status)
-		}
+			return string(batch.Status), false, false
+		},
+	}, pollIntervalMs, r.Options, opts)
+}
+
+// Creates a fine-tuning job and polls the API until it has succeeded, failed or
+// been cancelled.
+//
+// Polls the API and blocks until the task is complete.
+// Default polling interval is 1 second.
+func (r *FineTuningJobService) NewAndPoll(ctx context.Context, body FineTuningJobNewParams, pollIntervalMs int, opts ...option.RequestOption) (res *FineTuningJob, err error) {
+	job, err := r.New(ctx, body, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return r.PollStatus(ctx, job.ID, pollIntervalMs, opts...)
+}
 
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
+// PollStatus waits until a FineTuningJob has succeeded, failed or been cancelled
+// and returns it. Pass 0 as pollIntervalMs to use the default polling interval of 1 second.
+func (r *FineTuningJobService) PollStatus(ctx context.Context, fineTuningJobID string, pollIntervalMs int, opts ...option.RequestOption) (*FineTuningJob, error) {
+	return polling.Poll(ctx, polling.Target[FineTuningJob]{
+		Name: "fine-tuning job",
+		Get: func(opts ...option.RequestOption) (*FineTuningJob, error) {
+			return r.Get(ctx, fineTuningJobID, opts...)
+		},
+		Status: func(job *FineTuningJob) (string, bool, bool) {
+			switch job.Status {
+			case FineTuningJobStatusValidatingFiles,
+				FineTuningJobStatusQueued,
+				FineTuningJobStatusRunning:
+				return string(job.Status), true, true
+			case FineTuningJobStatusSucceeded,
+				FineTuningJobStatusFailed,
+				FineTuningJobStatusCancelled:
+				return string(job.Status), false, true
+			}
+			return string(job.Status), false, false
+		},
+	}, pollIntervalMs, r.Options, opts)
+}
 
-		}
+// Create a run and poll the API until it is no longer queued, in progress or
+// cancelling.
+//
+// Polls the API and blocks until the task is complete.
+// Default polling interval is 1 second.
+//
+// Deprecated: The Assistants API is deprecated in favor of the Responses API
+func (r *BetaThreadRunService) NewAndPoll(ctx context.Context, threadID string, params BetaThreadRunNewParams, pollIntervalMs int, opts ...option.RequestOption) (res *Run, err error) {
+	run, err := r.New(ctx, threadID, params, opts...)
+	if err != nil {
+		return nil, err
 	}
+	return r.PollStatus(ctx, threadID, run.ID, pollIntervalMs, opts...)
+}
+
+// PollStatus waits until a Run is no longer queued, in progress or cancelling and
+// returns it. A run that requires action is returned so its tool outputs can be
+// submitted. Pass 0 as pollIntervalMs to use the default polling interval of 1 second.
+func (r *BetaThreadRunService) PollStatus(ctx context.Context, threadID string, runID string, pollIntervalMs int, opts ...option.RequestOption) (*Run, error) {
+	return polling.Poll(ctx, polling.Target[Run]{
+		Name: "run",
+		Get: func(opts ...option.RequestOption) (*Run, error) {
+			return r.Get(ctx, threadID, runID, opts...)
+		},
+		Status: func(run *Run) (string, bool, bool) {
+			switch run.Status {
+			case RunStatusQueued,
+				RunStatusInProgress,
+				RunStatusCancelling:
+				return string(run.Status), true, true
+			case RunStatusRequiresAction,
+				RunStatusCancelled,
+				RunStatusFailed,
+				RunStatusCompleted,
+				RunStatusIncomplete,
+				RunStatusExpired:
+				return string(run.Status), false, true
+			}
+			return string(run.Status), false, false
+		},
+	}, pollIntervalMs, r.Options, opts)
 }
@@ -0,0 +1,98 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+// fineTuningJobServer reports the job as running until the given number of polls.
+func fineTuningJobServer(polls int32, pollAfterMs string) (*httptest.Server, *atomic.Int32) {
+	count := &atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("openai-poll-after-ms", pollAfterMs)
+		status := "running"
+		if r.Method == http.MethodPost {
+			status = "validating_files"
+		} else if count.Add(1) >= polls {
+			status = "succeeded"
+		}
+		fmt.Fprintf(w, `{"id":"ftjob_1","object":"fine_tuning.job","status":%q}`, status)
+	}))
+	return srv, count
+}
+
+func TestPollStatusBackoff(t *testing.T) {
+	srv, count := fineTuningJobServer(4, "1")
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	var progress []option.PollProgress
+	job, err := client.FineTuning.Jobs.NewAndPoll(context.Background(), openai.FineTuningJobNewParams{
+		Model:        openai.FineTuningJobNewParamsModelGPT4oMini,
+		TrainingFile: "file-abc123",
+	}, 0,
+		option.WithPollInterval(2*time.Millisecond),
+		option.WithPollBackoff(2, 5*time.Millisecond),
+		option.WithPollProgress(func(p option.PollProgress) { progress = append(progress, p) }),
+	)
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if job.Status != openai.FineTuningJobStatusSucceeded || count.Load() != 4 {
+		t.Fatalf("expected the job to succeed after 4 polls, got %s after %d", job.Status, count.Load())
+	}
+
+	expected := []time.Duration{2 * time.Millisecond, 4 * time.Millisecond, 5 * time.Millisecond, 0}
+	if len(progress) != len(expected) {
+		t.Fatalf("expected %d progress updates, got %d", len(expected), len(progress))
+	}
+	for i, p := range progress {
+		if p.Attempt != i+1 || p.Next != expected[i] {
+			t.Fatalf("unexpected progress %d: %+v", i, p)
+		}
+	}
+	if progress[3].Status != "succeeded" {
+		t.Fatalf("expected the last progress update to be succeeded, got %s", progress[3].Status)
+	}
+}
+
+func TestPollStatusMaxWait(t *testing.T) {
+	srv, _ := fineTuningJobServer(1000, "1")
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	job, err := client.FineTuning.Jobs.PollStatus(context.Background(), "ftjob_1", 0, option.WithPollMaxWait(20*time.Millisecond))
+	if !errors.Is(err, openai.ErrPollTimeout) {
+		t.Fatalf("expected ErrPollTimeout, got %v", err)
+	}
+	if job == nil || job.Status != openai.FineTuningJobStatusRunning {
+		t.Fatalf("expected the last fetched job to be returned")
+	}
+}
+
+func TestPollStatusContextCancel(t *testing.T) {
+	srv, _ := fineTuningJobServer(1000, "60000")
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.FineTuning.Jobs.PollStatus(ctx, "ftjob_1", 0)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("expected the poll to stop sleeping when the context is done")
+	}
+}
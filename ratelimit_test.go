@@ -0,0 +1,68 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ratelimit"
+)
+
+func TestRateLimitInfo(t *testing.T) {
+	status := http.StatusOK
+	client := openai.NewClient(
+		option.WithAPIKey("My API Key"),
+		option.WithMaxRetries(0),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: status,
+						Header: http.Header{
+							"Content-Type":                   []string{"application/json"},
+							"X-Ratelimit-Limit-Requests":     []string{"60"},
+							"X-Ratelimit-Remaining-Requests": []string{"0"},
+						},
+						Body: io.NopCloser(strings.NewReader(`{"id":"gpt-4o","object":"model","error":{"code":"rate_limit_exceeded"}}`)),
+					}, nil
+				},
+			},
+		}),
+	)
+
+	var info openai.RateLimitInfo
+	if _, err := client.Models.Get(context.Background(), "gpt-4o", option.WithRateLimitInto(&info)); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if !info.Valid() || info.LimitRequests != 60 || info.RemainingRequests != 0 {
+		t.Fatalf("unexpected rate limits %+v", info)
+	}
+
+	status = http.StatusTooManyRequests
+	_, err := client.Models.Get(context.Background(), "gpt-4o")
+	var apierr *openai.Error
+	if !errors.As(err, &apierr) || apierr.RateLimitInfo().LimitRequests != 60 {
+		t.Fatalf("expected the error to carry the rate limits, got %v", err)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	attempts := 0
+	client := rateLimitedClient("rate_limit_exceeded", &attempts,
+		option.WithMaxRetries(0),
+		option.WithRateLimiter(ratelimit.NewLimiter(1, 0)),
+	)
+	client.Models.Get(context.Background(), "gpt-4o")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Models.Get(ctx, "gpt-4o"); !errors.Is(err, context.DeadlineExceeded) || attempts != 1 {
+		t.Fatalf("expected the second request to wait for the limiter, got %d attempts and %v", attempts, err)
+	}
+}
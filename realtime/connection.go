@@ -0,0 +1,138 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/websocket"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+// CloseError is returned by [RealtimeConnection.Recv] when the server closes the
+// connection with a status other than a normal closure.
+type CloseError = websocket.CloseError
+
+// RealtimeConnection is an open WebSocket connection to a Realtime session,
+// created with [RealtimeService.Connect].
+//
+// Send may be called concurrently from multiple goroutines, while Recv should
+// only be called from a single goroutine.
+type RealtimeConnection struct {
+	conn *websocket.Conn
+	stop func() bool
+	res  *http.Response
+}
+
+// Connect opens a WebSocket connection to a Realtime session for the given
+// model. The client's base URL, authentication headers and middleware (such as
+// the ones installed by the azure package) are applied to the opening
+// handshake.
+//
+// The connection stays open until [RealtimeConnection.Close] is called, the
+// server closes it, or ctx is done.
+//
+//	conn, err := client.Realtime.Connect(ctx, "gpt-realtime")
+//	if err != nil {
+//		...
+//	}
+//	defer conn.Close()
+//
+//	for {
+//		event, err := conn.Recv()
+//		if err != nil {
+//			...
+//		}
+//		switch event := event.AsAny().(type) {
+//		case realtime.ResponseTextDeltaEvent:
+//			fmt.Print(event.Delta)
+//		}
+//	}
+func (r *RealtimeService) Connect(ctx context.Context, model string, opts ...option.RequestOption) (conn *RealtimeConnection, err error) {
+	key, err := websocket.NewKey()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = slices.Concat(r.Options, opts)
+	if model != "" {
+		opts = append(opts, option.WithQuery("model", model))
+	}
+	opts = append(opts, requestconfig.RequestOptionFunc(func(cfg *requestconfig.RequestConfig) error {
+		websocket.SetHandshakeHeaders(cfg.Request.Header, key)
+		return nil
+	}))
+
+	var res *http.Response
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, "realtime", nil, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = websocket.CheckHandshake(res, key); err != nil {
+		res.Body.Close()
+		return nil, fmt.Errorf("realtime: %w", err)
+	}
+	rwc, ok := res.Body.(io.ReadWriteCloser)
+	if !ok {
+		res.Body.Close()
+		return nil, errors.New("realtime: the HTTP client does not support upgrading connections to WebSockets")
+	}
+
+	conn = &RealtimeConnection{conn: websocket.NewConn(rwc, nil, true), res: res}
+	conn.stop = context.AfterFunc(ctx, func() {
+		conn.conn.Close(websocket.CloseGoingAway, "")
+	})
+	return conn, nil
+}
+
+// Send writes a client event to the connection.
+func (c *RealtimeConnection) Send(event RealtimeClientEventUnionParam) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Recv blocks until the next server event arrives. Once the server closes the
+// connection normally, Recv returns [io.EOF]. Other closures are reported as a
+// [*CloseError].
+//
+// Events with the type "error" are returned as regular events, since most of
+// them do not end the session.
+func (c *RealtimeConnection) Recv() (event RealtimeServerEventUnion, err error) {
+	for {
+		typ, data, err := c.conn.ReadMessage()
+		if err != nil {
+			var cerr *websocket.CloseError
+			if errors.As(err, &cerr) && cerr.Code == websocket.CloseNormalClosure {
+				return event, io.EOF
+			}
+			return event, err
+		}
+		// The Realtime API only sends JSON text messages.
+		if typ != websocket.TextMessage {
+			continue
+		}
+		err = event.UnmarshalJSON(data)
+		return event, err
+	}
+}
+
+// Response returns the HTTP response of the opening handshake, which carries
+// headers such as x-request-id.
+func (c *RealtimeConnection) Response() *http.Response {
+	return c.res
+}
+
+// Close performs the closing handshake and releases the connection.
+func (c *RealtimeConnection) Close() error {
+	c.stop()
+	return c.conn.Close(websocket.CloseNormalClosure, "")
+}
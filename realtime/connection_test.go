@@ -0,0 +1,270 @@
+package realtime_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/websocket"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/realtime"
+)
+
+// newRealtimeServer starts a WebSocket server which sends session.created and
+// then answers every client event with the result of respond.
+func newRealtimeServer(t *testing.T, respond func(event map[string]any) []string) (*httptest.Server, chan *http.Request) {
+	t.Helper()
+	requests := make(chan *http.Request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		conn, err := websocket.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.CloseNormalClosure, "")
+
+		created := `{"type":"session.created","event_id":"event_1","session":{"type":"realtime","model":"gpt-realtime","instructions":"be brief"}}`
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(created)); err != nil {
+			return
+		}
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var event map[string]any
+			if err := json.Unmarshal(data, &event); err != nil {
+				return
+			}
+			replies := respond(event)
+			if replies == nil {
+				return
+			}
+			for _, reply := range replies {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(reply)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, requests
+}
+
+func TestRealtimeConnectSendRecv(t *testing.T) {
+	srv, requests := newRealtimeServer(t, func(event map[string]any) []string {
+		switch event["type"] {
+		case "conversation.item.create":
+			return []string{
+				`{"type":"conversation.item.added","event_id":"event_2","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_text","text":"hello"}]}}`,
+			}
+		case "response.create":
+			return []string{
+				`{"type":"response.output_text.delta","event_id":"event_3","response_id":"resp_1","item_id":"item_2","output_index":0,"content_index":0,"delta":"Hi"}`,
+				`{"type":"response.done","event_id":"event_4","response":{"id":"resp_1","status":"completed","usage":{"input_tokens":3,"output_tokens":1,"total_tokens":4}}}`,
+			}
+		}
+		return nil
+	})
+
+	client := openai.NewClient(
+		option.WithBaseURL(srv.URL),
+		option.WithAPIKey("My API Key"),
+	)
+	conn, err := client.Realtime.Connect(context.Background(), "gpt-realtime")
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	defer conn.Close()
+
+	req := <-requests
+	if req.URL.Path != "/realtime" {
+		t.Fatalf("expected path /realtime, got %s", req.URL.Path)
+	}
+	if got := req.URL.Query().Get("model"); got != "gpt-realtime" {
+		t.Fatalf("expected model query gpt-realtime, got %q", got)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer My API Key" {
+		t.Fatalf("expected authorization header, got %q", got)
+	}
+
+	event, err := conn.Recv()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	created, ok := event.AsAny().(realtime.SessionCreatedEvent)
+	if !ok {
+		t.Fatalf("expected SessionCreatedEvent, got %T", event.AsAny())
+	}
+	if created.Session.Instructions != "be brief" {
+		t.Fatalf("unexpected session instructions %q", created.Session.Instructions)
+	}
+
+	err = conn.Send(realtime.RealtimeClientEventUnionParam{
+		OfConversationItemCreate: &realtime.ConversationItemCreateEventParam{
+			Item: realtime.UserMessageItem("hello"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	event, err = conn.Recv()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	added := event.AsConversationItemAdded()
+	message, ok := added.Item.AsAny().(realtime.RealtimeConversationItemUserMessage)
+	if !ok {
+		t.Fatalf("expected a user message, got %T", added.Item.AsAny())
+	}
+	if len(message.Content) != 1 || message.Content[0].Text != "hello" {
+		t.Fatalf("unexpected message content %+v", message.Content)
+	}
+
+	err = conn.Send(realtime.RealtimeClientEventUnionParam{
+		OfResponseCreate: &realtime.ResponseCreateEventParam{},
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	event, err = conn.Recv()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if delta := event.AsResponseOutputTextDelta(); delta.Delta != "Hi" || delta.ResponseID != "resp_1" {
+		t.Fatalf("unexpected text delta %+v", delta)
+	}
+	event, err = conn.Recv()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	done := event.AsResponseDone()
+	if done.Response.Status != "completed" || done.Response.Usage.TotalTokens != 4 {
+		t.Fatalf("unexpected response %+v", done.Response)
+	}
+}
+
+func TestRealtimeConnectWithLogger(t *testing.T) {
+	srv, _ := newRealtimeServer(t, func(event map[string]any) []string {
+		return []string{`{"type":"input_audio_buffer.cleared","event_id":"event_2"}`}
+	})
+
+	var logs bytes.Buffer
+	client := openai.NewClient(
+		option.WithBaseURL(srv.URL),
+		option.WithAPIKey("My API Key"),
+		option.WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+	)
+	conn, err := client.Realtime.Connect(context.Background(), "gpt-realtime")
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	defer conn.Close()
+
+	// The call is logged at the handshake, with the connection still open.
+	if !strings.Contains(logs.String(), `"http.response.status_code":101`) {
+		t.Fatalf("expected the handshake to be logged, got %s", logs.String())
+	}
+	if _, err := conn.Recv(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	err = conn.Send(realtime.RealtimeClientEventUnionParam{
+		OfInputAudioBufferClear: &realtime.InputAudioBufferClearEventParam{},
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	event, err := conn.Recv()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if event.Type != "input_audio_buffer.cleared" {
+		t.Fatalf("unexpected event %s", event.Type)
+	}
+}
+
+func TestRealtimeConnectClosedByServer(t *testing.T) {
+	srv, _ := newRealtimeServer(t, func(event map[string]any) []string { return nil })
+
+	client := openai.NewClient(
+		option.WithBaseURL(srv.URL),
+		option.WithAPIKey("My API Key"),
+		option.WithRequestTimeout(50*time.Millisecond),
+	)
+	conn, err := client.Realtime.Connect(context.Background(), "gpt-realtime")
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	defer conn.Close()
+
+	// The request timeout only bounds the opening handshake.
+	time.Sleep(100 * time.Millisecond)
+	if _, err := conn.Recv(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+
+	err = conn.Send(realtime.RealtimeClientEventUnionParam{
+		OfInputAudioBufferClear: &realtime.InputAudioBufferClearEventParam{},
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if _, err := conn.Recv(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestRealtimeConnectAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"message":"Incorrect API key provided","type":"invalid_request_error","code":"invalid_api_key"}}`))
+	}))
+	defer srv.Close()
+
+	client := openai.NewClient(
+		option.WithBaseURL(srv.URL),
+		option.WithAPIKey("My API Key"),
+	)
+	_, err := client.Realtime.Connect(context.Background(), "gpt-realtime")
+	var apierr *openai.Error
+	if !errors.As(err, &apierr) {
+		t.Fatalf("expected *openai.Error, got %v", err)
+	}
+	if apierr.StatusCode != http.StatusUnauthorized || apierr.Code != "invalid_api_key" {
+		t.Fatalf("unexpected error %+v", apierr)
+	}
+}
+
+func TestRealtimeConnectContextCancel(t *testing.T) {
+	srv, _ := newRealtimeServer(t, func(event map[string]any) []string { return []string{} })
+
+	client := openai.NewClient(
+		option.WithBaseURL(srv.URL),
+		option.WithAPIKey("My API Key"),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	conn, err := client.Realtime.Connect(ctx, "gpt-realtime")
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	defer conn.Close()
+	if _, err := conn.Recv(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+
+	cancel()
+	if _, err := conn.Recv(); err == nil {
+		t.Fatal("expected an error after the context was canceled")
+	}
+}
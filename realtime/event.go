@@ -0,0 +1,3405 @@
+package realtime
+
+import (
+	"encoding/json"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apijson"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/respjson"
+	"github.com/Nordlys-Labs/openai-go/v3/shared"
+	"github.com/Nordlys-Labs/openai-go/v3/shared/constant"
+)
+
+// RealtimeServerEventUnion contains all possible properties and values from the
+// events that the server sends over a Realtime connection.
+//
+// Use the [RealtimeServerEventUnion.AsAny] method to switch on the variant.
+//
+// Use the methods beginning with 'As' to cast the union to one of its variants.
+type RealtimeServerEventUnion struct {
+	EventID string        `json:"event_id"`
+	Error   RealtimeError `json:"error"`
+	// Any of "error", "session.created", "session.updated",
+	// "conversation.item.created", "conversation.item.added",
+	// "conversation.item.done", "conversation.item.retrieved",
+	// "conversation.item.deleted", "conversation.item.truncated",
+	// "conversation.item.input_audio_transcription.completed",
+	// "conversation.item.input_audio_transcription.delta",
+	// "conversation.item.input_audio_transcription.segment",
+	// "conversation.item.input_audio_transcription.failed",
+	// "input_audio_buffer.cleared", "input_audio_buffer.committed",
+	// "input_audio_buffer.speech_started", "input_audio_buffer.speech_stopped",
+	// "input_audio_buffer.timeout_triggered",
+	// "input_audio_buffer.dtmf_event_received", "output_audio_buffer.started",
+	// "output_audio_buffer.stopped", "output_audio_buffer.cleared",
+	// "rate_limits.updated", "response.created", "response.done",
+	// "response.output_item.added", "response.output_item.done",
+	// "response.content_part.added", "response.content_part.done",
+	// "response.output_text.delta", "response.output_text.done",
+	// "response.output_audio_transcript.delta",
+	// "response.output_audio_transcript.done", "response.output_audio.delta",
+	// "response.output_audio.done", "response.function_call_arguments.delta",
+	// "response.function_call_arguments.done",
+	// "response.mcp_call_arguments.delta", "response.mcp_call_arguments.done",
+	// "response.mcp_call.in_progress", "response.mcp_call.completed",
+	// "response.mcp_call.failed", "mcp_list_tools.in_progress",
+	// "mcp_list_tools.completed", "mcp_list_tools.failed".
+	Type           string                              `json:"type"`
+	Session        ClientSecretNewResponseSessionUnion `json:"session"`
+	Item           ConversationItemUnion               `json:"item"`
+	PreviousItemID string                              `json:"previous_item_id"`
+	ItemID         string                              `json:"item_id"`
+	AudioEndMs     int64                               `json:"audio_end_ms"`
+	ContentIndex   int64                               `json:"content_index"`
+	Transcript     string                              `json:"transcript"`
+	// This field is from variant [ConversationItemInputAudioTranscriptionCompletedEvent].
+	Usage    ConversationItemInputAudioTranscriptionCompletedEventUsage `json:"usage"`
+	Logprobs []LogProbProperties                                        `json:"logprobs"`
+	Delta    string                                                     `json:"delta"`
+	// This field is from variant [ConversationItemInputAudioTranscriptionSegment].
+	ID string `json:"id"`
+	// This field is from variant [ConversationItemInputAudioTranscriptionSegment].
+	End float64 `json:"end"`
+	// This field is from variant [ConversationItemInputAudioTranscriptionSegment].
+	Speaker string `json:"speaker"`
+	// This field is from variant [ConversationItemInputAudioTranscriptionSegment].
+	Start        float64 `json:"start"`
+	Text         string  `json:"text"`
+	AudioStartMs int64   `json:"audio_start_ms"`
+	// This field is from variant [InputAudioBufferDtmfEventReceivedEvent].
+	Event string `json:"event"`
+	// This field is from variant [InputAudioBufferDtmfEventReceivedEvent].
+	ReceivedAt int64  `json:"received_at"`
+	ResponseID string `json:"response_id"`
+	// This field is from variant [RateLimitsUpdatedEvent].
+	RateLimits  []RateLimitsUpdatedEventRateLimit `json:"rate_limits"`
+	Response    RealtimeResponse                  `json:"response"`
+	OutputIndex int64                             `json:"output_index"`
+	Part        RealtimeContentPart               `json:"part"`
+	CallID      string                            `json:"call_id"`
+	Arguments   string                            `json:"arguments"`
+	// This field is from variant [ResponseFunctionCallArgumentsDoneEvent].
+	Name string `json:"name"`
+	JSON struct {
+		EventID        respjson.Field
+		Error          respjson.Field
+		Type           respjson.Field
+		Session        respjson.Field
+		Item           respjson.Field
+		PreviousItemID respjson.Field
+		ItemID         respjson.Field
+		AudioEndMs     respjson.Field
+		ContentIndex   respjson.Field
+		Transcript     respjson.Field
+		Usage          respjson.Field
+		Logprobs       respjson.Field
+		Delta          respjson.Field
+		ID             respjson.Field
+		End            respjson.Field
+		Speaker        respjson.Field
+		Start          respjson.Field
+		Text           respjson.Field
+		AudioStartMs   respjson.Field
+		Event          respjson.Field
+		ReceivedAt     respjson.Field
+		ResponseID     respjson.Field
+		RateLimits     respjson.Field
+		Response       respjson.Field
+		OutputIndex    respjson.Field
+		Part           respjson.Field
+		CallID         respjson.Field
+		Arguments      respjson.Field
+		Name           respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+// anyRealtimeServerEvent is implemented by each variant of
+// [RealtimeServerEventUnion] to add type safety for the return type of
+// [RealtimeServerEventUnion.AsAny]
+type anyRealtimeServerEvent interface {
+	implRealtimeServerEventUnion()
+}
+
+func (RealtimeErrorEvent) implRealtimeServerEventUnion()                                    {}
+func (SessionCreatedEvent) implRealtimeServerEventUnion()                                   {}
+func (SessionUpdatedEvent) implRealtimeServerEventUnion()                                   {}
+func (ConversationItemCreatedEvent) implRealtimeServerEventUnion()                          {}
+func (ConversationItemAdded) implRealtimeServerEventUnion()                                 {}
+func (ConversationItemDone) implRealtimeServerEventUnion()                                  {}
+func (ConversationItemRetrieved) implRealtimeServerEventUnion()                             {}
+func (ConversationItemDeletedEvent) implRealtimeServerEventUnion()                          {}
+func (ConversationItemTruncatedEvent) implRealtimeServerEventUnion()                        {}
+func (ConversationItemInputAudioTranscriptionCompletedEvent) implRealtimeServerEventUnion() {}
+func (ConversationItemInputAudioTranscriptionDeltaEvent) implRealtimeServerEventUnion()     {}
+func (ConversationItemInputAudioTranscriptionSegment) implRealtimeServerEventUnion()        {}
+func (ConversationItemInputAudioTranscriptionFailedEvent) implRealtimeServerEventUnion()    {}
+func (InputAudioBufferClearedEvent) implRealtimeServerEventUnion()                          {}
+func (InputAudioBufferCommittedEvent) implRealtimeServerEventUnion()                        {}
+func (InputAudioBufferSpeechStartedEvent) implRealtimeServerEventUnion()                    {}
+func (InputAudioBufferSpeechStoppedEvent) implRealtimeServerEventUnion()                    {}
+func (InputAudioBufferTimeoutTriggered) implRealtimeServerEventUnion()                      {}
+func (InputAudioBufferDtmfEventReceivedEvent) implRealtimeServerEventUnion()                {}
+func (OutputAudioBufferStartedEvent) implRealtimeServerEventUnion()                         {}
+func (OutputAudioBufferStoppedEvent) implRealtimeServerEventUnion()                         {}
+func (OutputAudioBufferClearedEvent) implRealtimeServerEventUnion()                         {}
+func (RateLimitsUpdatedEvent) implRealtimeServerEventUnion()                                {}
+func (ResponseCreatedEvent) implRealtimeServerEventUnion()                                  {}
+func (ResponseDoneEvent) implRealtimeServerEventUnion()                                     {}
+func (ResponseOutputItemAddedEvent) implRealtimeServerEventUnion()                          {}
+func (ResponseOutputItemDoneEvent) implRealtimeServerEventUnion()                           {}
+func (ResponseContentPartAddedEvent) implRealtimeServerEventUnion()                         {}
+func (ResponseContentPartDoneEvent) implRealtimeServerEventUnion()                          {}
+func (ResponseTextDeltaEvent) implRealtimeServerEventUnion()                                {}
+func (ResponseTextDoneEvent) implRealtimeServerEventUnion()                                 {}
+func (ResponseAudioTranscriptDeltaEvent) implRealtimeServerEventUnion()                     {}
+func (ResponseAudioTranscriptDoneEvent) implRealtimeServerEventUnion()                      {}
+func (ResponseAudioDeltaEvent) implRealtimeServerEventUnion()                               {}
+func (ResponseAudioDoneEvent) implRealtimeServerEventUnion()                                {}
+func (ResponseFunctionCallArgumentsDeltaEvent) implRealtimeServerEventUnion()               {}
+func (ResponseFunctionCallArgumentsDoneEvent) implRealtimeServerEventUnion()                {}
+func (ResponseMcpCallArgumentsDelta) implRealtimeServerEventUnion()                         {}
+func (ResponseMcpCallArgumentsDone) implRealtimeServerEventUnion()                          {}
+func (ResponseMcpCallInProgress) implRealtimeServerEventUnion()                             {}
+func (ResponseMcpCallCompleted) implRealtimeServerEventUnion()                              {}
+func (ResponseMcpCallFailed) implRealtimeServerEventUnion()                                 {}
+func (McpListToolsInProgress) implRealtimeServerEventUnion()                                {}
+func (McpListToolsCompleted) implRealtimeServerEventUnion()                                 {}
+func (McpListToolsFailed) implRealtimeServerEventUnion()                                    {}
+
+// Use the following switch statement to find the correct variant
+//
+//	switch variant := RealtimeServerEventUnion.AsAny().(type) {
+//	case realtime.RealtimeErrorEvent:
+//	case realtime.SessionCreatedEvent:
+//	case realtime.SessionUpdatedEvent:
+//	case realtime.ConversationItemCreatedEvent:
+//	case realtime.ConversationItemAdded:
+//	case realtime.ConversationItemDone:
+//	case realtime.ConversationItemRetrieved:
+//	case realtime.ConversationItemDeletedEvent:
+//	case realtime.ConversationItemTruncatedEvent:
+//	case realtime.ConversationItemInputAudioTranscriptionCompletedEvent:
+//	case realtime.ConversationItemInputAudioTranscriptionDeltaEvent:
+//	case realtime.ConversationItemInputAudioTranscriptionSegment:
+//	case realtime.ConversationItemInputAudioTranscriptionFailedEvent:
+//	case realtime.InputAudioBufferClearedEvent:
+//	case realtime.InputAudioBufferCommittedEvent:
+//	case realtime.InputAudioBufferSpeechStartedEvent:
+//	case realtime.InputAudioBufferSpeechStoppedEvent:
+//	case realtime.InputAudioBufferTimeoutTriggered:
+//	case realtime.InputAudioBufferDtmfEventReceivedEvent:
+//	case realtime.OutputAudioBufferStartedEvent:
+//	case realtime.OutputAudioBufferStoppedEvent:
+//	case realtime.OutputAudioBufferClearedEvent:
+//	case realtime.RateLimitsUpdatedEvent:
+//	case realtime.ResponseCreatedEvent:
+//	case realtime.ResponseDoneEvent:
+//	case realtime.ResponseOutputItemAddedEvent:
+//	case realtime.ResponseOutputItemDoneEvent:
+//	case realtime.ResponseContentPartAddedEvent:
+//	case realtime.ResponseContentPartDoneEvent:
+//	case realtime.ResponseTextDeltaEvent:
+//	case realtime.ResponseTextDoneEvent:
+//	case realtime.ResponseAudioTranscriptDeltaEvent:
+//	case realtime.ResponseAudioTranscriptDoneEvent:
+//	case realtime.ResponseAudioDeltaEvent:
+//	case realtime.ResponseAudioDoneEvent:
+//	case realtime.ResponseFunctionCallArgumentsDeltaEvent:
+//	case realtime.ResponseFunctionCallArgumentsDoneEvent:
+//	case realtime.ResponseMcpCallArgumentsDelta:
+//	case realtime.ResponseMcpCallArgumentsDone:
+//	case realtime.ResponseMcpCallInProgress:
+//	case realtime.ResponseMcpCallCompleted:
+//	case realtime.ResponseMcpCallFailed:
+//	case realtime.McpListToolsInProgress:
+//	case realtime.McpListToolsCompleted:
+//	case realtime.McpListToolsFailed:
+//	default:
+//	  fmt.Errorf("no variant present")
+//	}
+func (u RealtimeServerEventUnion) AsAny() anyRealtimeServerEvent {
+	switch u.Type {
+	case "error":
+		return u.AsError()
+	case "session.created":
+		return u.AsSessionCreated()
+	case "session.updated":
+		return u.AsSessionUpdated()
+	case "conversation.item.created":
+		return u.AsConversationItemCreated()
+	case "conversation.item.added":
+		return u.AsConversationItemAdded()
+	case "conversation.item.done":
+		return u.AsConversationItemDone()
+	case "conversation.item.retrieved":
+		return u.AsConversationItemRetrieved()
+	case "conversation.item.deleted":
+		return u.AsConversationItemDeleted()
+	case "conversation.item.truncated":
+		return u.AsConversationItemTruncated()
+	case "conversation.item.input_audio_transcription.completed":
+		return u.AsConversationItemInputAudioTranscriptionCompleted()
+	case "conversation.item.input_audio_transcription.delta":
+		return u.AsConversationItemInputAudioTranscriptionDelta()
+	case "conversation.item.input_audio_transcription.segment":
+		return u.AsConversationItemInputAudioTranscriptionSegment()
+	case "conversation.item.input_audio_transcription.failed":
+		return u.AsConversationItemInputAudioTranscriptionFailed()
+	case "input_audio_buffer.cleared":
+		return u.AsInputAudioBufferCleared()
+	case "input_audio_buffer.committed":
+		return u.AsInputAudioBufferCommitted()
+	case "input_audio_buffer.speech_started":
+		return u.AsInputAudioBufferSpeechStarted()
+	case "input_audio_buffer.speech_stopped":
+		return u.AsInputAudioBufferSpeechStopped()
+	case "input_audio_buffer.timeout_triggered":
+		return u.AsInputAudioBufferTimeoutTriggered()
+	case "input_audio_buffer.dtmf_event_received":
+		return u.AsInputAudioBufferDtmfEventReceived()
+	case "output_audio_buffer.started":
+		return u.AsOutputAudioBufferStarted()
+	case "output_audio_buffer.stopped":
+		return u.AsOutputAudioBufferStopped()
+	case "output_audio_buffer.cleared":
+		return u.AsOutputAudioBufferCleared()
+	case "rate_limits.updated":
+		return u.AsRateLimitsUpdated()
+	case "response.created":
+		return u.AsResponseCreated()
+	case "response.done":
+		return u.AsResponseDone()
+	case "response.output_item.added":
+		return u.AsResponseOutputItemAdded()
+	case "response.output_item.done":
+		return u.AsResponseOutputItemDone()
+	case "response.content_part.added":
+		return u.AsResponseContentPartAdded()
+	case "response.content_part.done":
+		return u.AsResponseContentPartDone()
+	case "response.output_text.delta":
+		return u.AsResponseOutputTextDelta()
+	case "response.output_text.done":
+		return u.AsResponseOutputTextDone()
+	case "response.output_audio_transcript.delta":
+		return u.AsResponseOutputAudioTranscriptDelta()
+	case "response.output_audio_transcript.done":
+		return u.AsResponseOutputAudioTranscriptDone()
+	case "response.output_audio.delta":
+		return u.AsResponseOutputAudioDelta()
+	case "response.output_audio.done":
+		return u.AsResponseOutputAudioDone()
+	case "response.function_call_arguments.delta":
+		return u.AsResponseFunctionCallArgumentsDelta()
+	case "response.function_call_arguments.done":
+		return u.AsResponseFunctionCallArgumentsDone()
+	case "response.mcp_call_arguments.delta":
+		return u.AsResponseMcpCallArgumentsDelta()
+	case "response.mcp_call_arguments.done":
+		return u.AsResponseMcpCallArgumentsDone()
+	case "response.mcp_call.in_progress":
+		return u.AsResponseMcpCallInProgress()
+	case "response.mcp_call.completed":
+		return u.AsResponseMcpCallCompleted()
+	case "response.mcp_call.failed":
+		return u.AsResponseMcpCallFailed()
+	case "mcp_list_tools.in_progress":
+		return u.AsMcpListToolsInProgress()
+	case "mcp_list_tools.completed":
+		return u.AsMcpListToolsCompleted()
+	case "mcp_list_tools.failed":
+		return u.AsMcpListToolsFailed()
+	}
+	return nil
+}
+
+func (u RealtimeServerEventUnion) AsError() (v RealtimeErrorEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsSessionCreated() (v SessionCreatedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsSessionUpdated() (v SessionUpdatedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemCreated() (v ConversationItemCreatedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemAdded() (v ConversationItemAdded) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemDone() (v ConversationItemDone) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemRetrieved() (v ConversationItemRetrieved) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemDeleted() (v ConversationItemDeletedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemTruncated() (v ConversationItemTruncatedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemInputAudioTranscriptionCompleted() (v ConversationItemInputAudioTranscriptionCompletedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemInputAudioTranscriptionDelta() (v ConversationItemInputAudioTranscriptionDeltaEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemInputAudioTranscriptionSegment() (v ConversationItemInputAudioTranscriptionSegment) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsConversationItemInputAudioTranscriptionFailed() (v ConversationItemInputAudioTranscriptionFailedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsInputAudioBufferCleared() (v InputAudioBufferClearedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsInputAudioBufferCommitted() (v InputAudioBufferCommittedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsInputAudioBufferSpeechStarted() (v InputAudioBufferSpeechStartedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsInputAudioBufferSpeechStopped() (v InputAudioBufferSpeechStoppedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsInputAudioBufferTimeoutTriggered() (v InputAudioBufferTimeoutTriggered) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsInputAudioBufferDtmfEventReceived() (v InputAudioBufferDtmfEventReceivedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsOutputAudioBufferStarted() (v OutputAudioBufferStartedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsOutputAudioBufferStopped() (v OutputAudioBufferStoppedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsOutputAudioBufferCleared() (v OutputAudioBufferClearedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsRateLimitsUpdated() (v RateLimitsUpdatedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseCreated() (v ResponseCreatedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseDone() (v ResponseDoneEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseOutputItemAdded() (v ResponseOutputItemAddedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseOutputItemDone() (v ResponseOutputItemDoneEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseContentPartAdded() (v ResponseContentPartAddedEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseContentPartDone() (v ResponseContentPartDoneEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseOutputTextDelta() (v ResponseTextDeltaEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseOutputTextDone() (v ResponseTextDoneEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseOutputAudioTranscriptDelta() (v ResponseAudioTranscriptDeltaEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseOutputAudioTranscriptDone() (v ResponseAudioTranscriptDoneEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseOutputAudioDelta() (v ResponseAudioDeltaEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseOutputAudioDone() (v ResponseAudioDoneEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseFunctionCallArgumentsDelta() (v ResponseFunctionCallArgumentsDeltaEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseFunctionCallArgumentsDone() (v ResponseFunctionCallArgumentsDoneEvent) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseMcpCallArgumentsDelta() (v ResponseMcpCallArgumentsDelta) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseMcpCallArgumentsDone() (v ResponseMcpCallArgumentsDone) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseMcpCallInProgress() (v ResponseMcpCallInProgress) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseMcpCallCompleted() (v ResponseMcpCallCompleted) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsResponseMcpCallFailed() (v ResponseMcpCallFailed) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsMcpListToolsInProgress() (v McpListToolsInProgress) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsMcpListToolsCompleted() (v McpListToolsCompleted) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u RealtimeServerEventUnion) AsMcpListToolsFailed() (v McpListToolsFailed) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+// Returns the unmodified JSON received from the API
+func (u RealtimeServerEventUnion) RawJSON() string { return u.JSON.raw }
+
+func (r *RealtimeServerEventUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// RealtimeClientEventUnionParam is an event that the client sends over a
+// Realtime connection.
+//
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type RealtimeClientEventUnionParam struct {
+	OfSessionUpdate            *SessionUpdateEventParam            `json:",omitzero,inline"`
+	OfInputAudioBufferAppend   *InputAudioBufferAppendEventParam   `json:",omitzero,inline"`
+	OfInputAudioBufferCommit   *InputAudioBufferCommitEventParam   `json:",omitzero,inline"`
+	OfInputAudioBufferClear    *InputAudioBufferClearEventParam    `json:",omitzero,inline"`
+	OfOutputAudioBufferClear   *OutputAudioBufferClearEventParam   `json:",omitzero,inline"`
+	OfConversationItemCreate   *ConversationItemCreateEventParam   `json:",omitzero,inline"`
+	OfConversationItemRetrieve *ConversationItemRetrieveEventParam `json:",omitzero,inline"`
+	OfConversationItemTruncate *ConversationItemTruncateEventParam `json:",omitzero,inline"`
+	OfConversationItemDelete   *ConversationItemDeleteEventParam   `json:",omitzero,inline"`
+	OfResponseCreate           *ResponseCreateEventParam           `json:",omitzero,inline"`
+	OfResponseCancel           *ResponseCancelEventParam           `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u RealtimeClientEventUnionParam) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfSessionUpdate, u.OfInputAudioBufferAppend, u.OfInputAudioBufferCommit, u.OfInputAudioBufferClear, u.OfOutputAudioBufferClear, u.OfConversationItemCreate, u.OfConversationItemRetrieve, u.OfConversationItemTruncate, u.OfConversationItemDelete, u.OfResponseCreate, u.OfResponseCancel)
+}
+func (u *RealtimeClientEventUnionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+func (u *RealtimeClientEventUnionParam) asAny() any {
+	if !param.IsOmitted(u.OfSessionUpdate) {
+		return u.OfSessionUpdate
+	} else if !param.IsOmitted(u.OfInputAudioBufferAppend) {
+		return u.OfInputAudioBufferAppend
+	} else if !param.IsOmitted(u.OfInputAudioBufferCommit) {
+		return u.OfInputAudioBufferCommit
+	} else if !param.IsOmitted(u.OfInputAudioBufferClear) {
+		return u.OfInputAudioBufferClear
+	} else if !param.IsOmitted(u.OfOutputAudioBufferClear) {
+		return u.OfOutputAudioBufferClear
+	} else if !param.IsOmitted(u.OfConversationItemCreate) {
+		return u.OfConversationItemCreate
+	} else if !param.IsOmitted(u.OfConversationItemRetrieve) {
+		return u.OfConversationItemRetrieve
+	} else if !param.IsOmitted(u.OfConversationItemTruncate) {
+		return u.OfConversationItemTruncate
+	} else if !param.IsOmitted(u.OfConversationItemDelete) {
+		return u.OfConversationItemDelete
+	} else if !param.IsOmitted(u.OfResponseCreate) {
+		return u.OfResponseCreate
+	} else if !param.IsOmitted(u.OfResponseCancel) {
+		return u.OfResponseCancel
+	}
+	return nil
+}
+
+func init() {
+	apijson.RegisterUnion[RealtimeClientEventUnionParam](
+		"type",
+		apijson.Discriminator[SessionUpdateEventParam]("session.update"),
+		apijson.Discriminator[InputAudioBufferAppendEventParam]("input_audio_buffer.append"),
+		apijson.Discriminator[InputAudioBufferCommitEventParam]("input_audio_buffer.commit"),
+		apijson.Discriminator[InputAudioBufferClearEventParam]("input_audio_buffer.clear"),
+		apijson.Discriminator[OutputAudioBufferClearEventParam]("output_audio_buffer.clear"),
+		apijson.Discriminator[ConversationItemCreateEventParam]("conversation.item.create"),
+		apijson.Discriminator[ConversationItemRetrieveEventParam]("conversation.item.retrieve"),
+		apijson.Discriminator[ConversationItemTruncateEventParam]("conversation.item.truncate"),
+		apijson.Discriminator[ConversationItemDeleteEventParam]("conversation.item.delete"),
+		apijson.Discriminator[ResponseCreateEventParam]("response.create"),
+		apijson.Discriminator[ResponseCancelEventParam]("response.cancel"),
+	)
+}
+
+// ConversationItemUnion contains all possible properties and values from
+// [RealtimeConversationItemSystemMessage], [RealtimeConversationItemUserMessage],
+// [RealtimeConversationItemAssistantMessage],
+// [RealtimeConversationItemFunctionCall],
+// [RealtimeConversationItemFunctionCallOutput], [RealtimeMcpApprovalResponse],
+// [RealtimeMcpListTools], [RealtimeMcpToolCall], [RealtimeMcpApprovalRequest].
+//
+// Use the [ConversationItemUnion.AsAny] method to switch on the variant.
+//
+// Use the methods beginning with 'As' to cast the union to one of its variants.
+type ConversationItemUnion struct {
+	ID string `json:"id"`
+	// Any of "message", "function_call", "function_call_output",
+	// "mcp_approval_response", "mcp_list_tools", "mcp_call", "mcp_approval_request".
+	Type    string                    `json:"type"`
+	Content []ConversationItemContent `json:"content"`
+	// Any of "system", "user", "assistant".
+	Role              string `json:"role"`
+	Object            string `json:"object"`
+	Status            string `json:"status"`
+	Arguments         string `json:"arguments"`
+	Name              string `json:"name"`
+	CallID            string `json:"call_id"`
+	Output            string `json:"output"`
+	ApprovalRequestID string `json:"approval_request_id"`
+	// This field is from variant [RealtimeMcpApprovalResponse].
+	Approve bool   `json:"approve"`
+	Reason  string `json:"reason"`
+	// This field is from variant [RealtimeMcpListTools].
+	Tools       []RealtimeMcpListToolsTool `json:"tools"`
+	ServerLabel string                     `json:"server_label"`
+	// This field is from variant [RealtimeMcpToolCall].
+	Error RealtimeError `json:"error"`
+	JSON  struct {
+		ID                respjson.Field
+		Type              respjson.Field
+		Content           respjson.Field
+		Role              respjson.Field
+		Object            respjson.Field
+		Status            respjson.Field
+		Arguments         respjson.Field
+		Name              respjson.Field
+		CallID            respjson.Field
+		Output            respjson.Field
+		ApprovalRequestID respjson.Field
+		Approve           respjson.Field
+		Reason            respjson.Field
+		Tools             respjson.Field
+		ServerLabel       respjson.Field
+		Error             respjson.Field
+		raw               string
+	} `json:"-"`
+}
+
+// anyConversationItem is implemented by each variant of [ConversationItemUnion]
+// to add type safety for the return type of [ConversationItemUnion.AsAny]
+type anyConversationItem interface {
+	implConversationItemUnion()
+}
+
+func (RealtimeConversationItemSystemMessage) implConversationItemUnion()      {}
+func (RealtimeConversationItemUserMessage) implConversationItemUnion()        {}
+func (RealtimeConversationItemAssistantMessage) implConversationItemUnion()   {}
+func (RealtimeConversationItemFunctionCall) implConversationItemUnion()       {}
+func (RealtimeConversationItemFunctionCallOutput) implConversationItemUnion() {}
+func (RealtimeMcpApprovalResponse) implConversationItemUnion()                {}
+func (RealtimeMcpListTools) implConversationItemUnion()                       {}
+func (RealtimeMcpToolCall) implConversationItemUnion()                        {}
+func (RealtimeMcpApprovalRequest) implConversationItemUnion()                 {}
+
+// Messages share the "message" type and are distinguished by their role.
+//
+// Use the following switch statement to find the correct variant
+//
+//	switch variant := ConversationItemUnion.AsAny().(type) {
+//	case realtime.RealtimeConversationItemSystemMessage:
+//	case realtime.RealtimeConversationItemUserMessage:
+//	case realtime.RealtimeConversationItemAssistantMessage:
+//	case realtime.RealtimeConversationItemFunctionCall:
+//	case realtime.RealtimeConversationItemFunctionCallOutput:
+//	case realtime.RealtimeMcpApprovalResponse:
+//	case realtime.RealtimeMcpListTools:
+//	case realtime.RealtimeMcpToolCall:
+//	case realtime.RealtimeMcpApprovalRequest:
+//	default:
+//	  fmt.Errorf("no variant present")
+//	}
+func (u ConversationItemUnion) AsAny() anyConversationItem {
+	switch u.Type {
+	case "message":
+		switch u.Role {
+		case "system":
+			return u.AsSystemMessage()
+		case "user":
+			return u.AsUserMessage()
+		case "assistant":
+			return u.AsAssistantMessage()
+		}
+	case "function_call":
+		return u.AsFunctionCall()
+	case "function_call_output":
+		return u.AsFunctionCallOutput()
+	case "mcp_approval_response":
+		return u.AsMcpApprovalResponse()
+	case "mcp_list_tools":
+		return u.AsMcpListTools()
+	case "mcp_call":
+		return u.AsMcpCall()
+	case "mcp_approval_request":
+		return u.AsMcpApprovalRequest()
+	}
+	return nil
+}
+
+func (u ConversationItemUnion) AsSystemMessage() (v RealtimeConversationItemSystemMessage) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ConversationItemUnion) AsUserMessage() (v RealtimeConversationItemUserMessage) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ConversationItemUnion) AsAssistantMessage() (v RealtimeConversationItemAssistantMessage) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ConversationItemUnion) AsFunctionCall() (v RealtimeConversationItemFunctionCall) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ConversationItemUnion) AsFunctionCallOutput() (v RealtimeConversationItemFunctionCallOutput) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ConversationItemUnion) AsMcpApprovalResponse() (v RealtimeMcpApprovalResponse) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ConversationItemUnion) AsMcpListTools() (v RealtimeMcpListTools) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ConversationItemUnion) AsMcpCall() (v RealtimeMcpToolCall) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ConversationItemUnion) AsMcpApprovalRequest() (v RealtimeMcpApprovalRequest) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+// Returns the unmodified JSON received from the API
+func (u ConversationItemUnion) RawJSON() string { return u.JSON.raw }
+
+func (r *ConversationItemUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// ConversationItemUnionParam is a single item within a Realtime conversation.
+//
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type ConversationItemUnionParam struct {
+	OfSystemMessage       *RealtimeConversationItemSystemMessageParam      `json:",omitzero,inline"`
+	OfUserMessage         *RealtimeConversationItemUserMessageParam        `json:",omitzero,inline"`
+	OfAssistantMessage    *RealtimeConversationItemAssistantMessageParam   `json:",omitzero,inline"`
+	OfFunctionCall        *RealtimeConversationItemFunctionCallParam       `json:",omitzero,inline"`
+	OfFunctionCallOutput  *RealtimeConversationItemFunctionCallOutputParam `json:",omitzero,inline"`
+	OfMcpApprovalResponse *RealtimeMcpApprovalResponseParam                `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u ConversationItemUnionParam) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfSystemMessage, u.OfUserMessage, u.OfAssistantMessage, u.OfFunctionCall, u.OfFunctionCallOutput, u.OfMcpApprovalResponse)
+}
+func (u *ConversationItemUnionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+func (u *ConversationItemUnionParam) asAny() any {
+	if !param.IsOmitted(u.OfSystemMessage) {
+		return u.OfSystemMessage
+	} else if !param.IsOmitted(u.OfUserMessage) {
+		return u.OfUserMessage
+	} else if !param.IsOmitted(u.OfAssistantMessage) {
+		return u.OfAssistantMessage
+	} else if !param.IsOmitted(u.OfFunctionCall) {
+		return u.OfFunctionCall
+	} else if !param.IsOmitted(u.OfFunctionCallOutput) {
+		return u.OfFunctionCallOutput
+	} else if !param.IsOmitted(u.OfMcpApprovalResponse) {
+		return u.OfMcpApprovalResponse
+	}
+	return nil
+}
+
+// UserMessageItem is a convenience constructor for a user message containing a
+// single `input_text` part.
+func UserMessageItem(text string) ConversationItemUnionParam {
+	return ConversationItemUnionParam{
+		OfUserMessage: &RealtimeConversationItemUserMessageParam{
+			Content: []ConversationItemContentParam{{Type: "input_text", Text: param.NewOpt(text)}},
+		},
+	}
+}
+
+// FunctionCallOutputItem is a convenience constructor for the output of a
+// function call.
+func FunctionCallOutputItem(callID string, output string) ConversationItemUnionParam {
+	return ConversationItemUnionParam{
+		OfFunctionCallOutput: &RealtimeConversationItemFunctionCallOutputParam{
+			CallID: callID,
+			Output: output,
+		},
+	}
+}
+
+// Details of an error reported by the Realtime API.
+type RealtimeError struct {
+	// A human-readable error message.
+	Message string `json:"message,required"`
+	// The type of error (e.g., "invalid_request_error", "server_error").
+	Type string `json:"type,required"`
+	// Error code, if any.
+	Code string `json:"code"`
+	// The event_id of the client event that caused the error, if applicable.
+	EventID string `json:"event_id"`
+	// Parameter related to the error, if any.
+	Param string `json:"param"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Message     respjson.Field
+		Type        respjson.Field
+		Code        respjson.Field
+		EventID     respjson.Field
+		Param       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeError) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeError) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A log probability object.
+type LogProbProperties struct {
+	// The token that was used to generate the log probability.
+	Token string `json:"token,required"`
+	// The bytes that were used to generate the log probability.
+	Bytes []int64 `json:"bytes,required"`
+	// The log probability of the token.
+	Logprob float64 `json:"logprob,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Token       respjson.Field
+		Bytes       respjson.Field
+		Logprob     respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r LogProbProperties) RawJSON() string { return r.JSON.raw }
+func (r *LogProbProperties) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A content part of a conversation item.
+//
+// The fields that are populated depend on the content Type.
+type ConversationItemContent struct {
+	// The content type.
+	//
+	// Any of "input_text", "input_audio", "input_image", "output_text",
+	// "output_audio".
+	Type string `json:"type,required"`
+	// The text content, for `input_text` and `output_text` parts.
+	Text string `json:"text"`
+	// Base64-encoded audio bytes, for `input_audio` and `output_audio` parts.
+	Audio string `json:"audio"`
+	// The transcript of the audio, for `input_audio` and `output_audio` parts.
+	Transcript string `json:"transcript"`
+	// Base64-encoded image bytes as a data URL, for `input_image` parts.
+	ImageURL string `json:"image_url"`
+	// The detail level of the image, for `input_image` parts.
+	//
+	// Any of "auto", "low", "high".
+	Detail string `json:"detail"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Type        respjson.Field
+		Text        respjson.Field
+		Audio       respjson.Field
+		Transcript  respjson.Field
+		ImageURL    respjson.Field
+		Detail      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemContent) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemContent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A system message item in a Realtime conversation.
+type RealtimeConversationItemSystemMessage struct {
+	// The content of the message.
+	Content []ConversationItemContent `json:"content,required"`
+	// The role of the message sender. Always `system`.
+	Role constant.System `json:"role,required"`
+	// The type of the item. Always `message`.
+	Type constant.Message `json:"type,required"`
+	// The unique ID of the item.
+	ID string `json:"id"`
+	// Identifier for the API object being returned - always `realtime.item`.
+	Object string `json:"object"`
+	// The status of the item.
+	//
+	// Any of "completed", "incomplete", "in_progress".
+	Status string `json:"status"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Content     respjson.Field
+		Role        respjson.Field
+		Type        respjson.Field
+		ID          respjson.Field
+		Object      respjson.Field
+		Status      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeConversationItemSystemMessage) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeConversationItemSystemMessage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A user message item in a Realtime conversation.
+type RealtimeConversationItemUserMessage struct {
+	// The content of the message.
+	Content []ConversationItemContent `json:"content,required"`
+	// The role of the message sender. Always `user`.
+	Role constant.User `json:"role,required"`
+	// The type of the item. Always `message`.
+	Type constant.Message `json:"type,required"`
+	// The unique ID of the item.
+	ID string `json:"id"`
+	// Identifier for the API object being returned - always `realtime.item`.
+	Object string `json:"object"`
+	// The status of the item.
+	//
+	// Any of "completed", "incomplete", "in_progress".
+	Status string `json:"status"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Content     respjson.Field
+		Role        respjson.Field
+		Type        respjson.Field
+		ID          respjson.Field
+		Object      respjson.Field
+		Status      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeConversationItemUserMessage) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeConversationItemUserMessage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A assistant message item in a Realtime conversation.
+type RealtimeConversationItemAssistantMessage struct {
+	// The content of the message.
+	Content []ConversationItemContent `json:"content,required"`
+	// The role of the message sender. Always `assistant`.
+	Role constant.Assistant `json:"role,required"`
+	// The type of the item. Always `message`.
+	Type constant.Message `json:"type,required"`
+	// The unique ID of the item.
+	ID string `json:"id"`
+	// Identifier for the API object being returned - always `realtime.item`.
+	Object string `json:"object"`
+	// The status of the item.
+	//
+	// Any of "completed", "incomplete", "in_progress".
+	Status string `json:"status"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Content     respjson.Field
+		Role        respjson.Field
+		Type        respjson.Field
+		ID          respjson.Field
+		Object      respjson.Field
+		Status      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeConversationItemAssistantMessage) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeConversationItemAssistantMessage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A function call item in a Realtime conversation.
+type RealtimeConversationItemFunctionCall struct {
+	// The arguments of the function call, as a JSON-encoded string.
+	Arguments string `json:"arguments,required"`
+	// The name of the function being called.
+	Name string `json:"name,required"`
+	// The type of the item. Always `function_call`.
+	Type constant.FunctionCall `json:"type,required"`
+	// The ID of the function call.
+	CallID string `json:"call_id"`
+	// The unique ID of the item.
+	ID string `json:"id"`
+	// Identifier for the API object being returned - always `realtime.item`.
+	Object string `json:"object"`
+	// The status of the item.
+	//
+	// Any of "completed", "incomplete", "in_progress".
+	Status string `json:"status"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Arguments   respjson.Field
+		Name        respjson.Field
+		Type        respjson.Field
+		CallID      respjson.Field
+		ID          respjson.Field
+		Object      respjson.Field
+		Status      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeConversationItemFunctionCall) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeConversationItemFunctionCall) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A function call output item in a Realtime conversation.
+type RealtimeConversationItemFunctionCallOutput struct {
+	// The ID of the function call this output is for.
+	CallID string `json:"call_id,required"`
+	// The output of the function call, this is free text and can contain any
+	// information or simply be empty.
+	Output string `json:"output,required"`
+	// The type of the item. Always `function_call_output`.
+	Type constant.FunctionCallOutput `json:"type,required"`
+	// The unique ID of the item.
+	ID string `json:"id"`
+	// Identifier for the API object being returned - always `realtime.item`.
+	Object string `json:"object"`
+	// The status of the item.
+	//
+	// Any of "completed", "incomplete", "in_progress".
+	Status string `json:"status"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		CallID      respjson.Field
+		Output      respjson.Field
+		Type        respjson.Field
+		ID          respjson.Field
+		Object      respjson.Field
+		Status      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeConversationItemFunctionCallOutput) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeConversationItemFunctionCallOutput) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A Realtime item responding to an MCP approval request.
+type RealtimeMcpApprovalResponse struct {
+	// The unique ID of the approval response.
+	ID string `json:"id,required"`
+	// The ID of the approval request being answered.
+	ApprovalRequestID string `json:"approval_request_id,required"`
+	// Whether the request was approved.
+	Approve bool `json:"approve,required"`
+	// The type of the item. Always `mcp_approval_response`.
+	Type constant.McpApprovalResponse `json:"type,required"`
+	// Optional reason for the decision.
+	Reason string `json:"reason"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID                respjson.Field
+		ApprovalRequestID respjson.Field
+		Approve           respjson.Field
+		Type              respjson.Field
+		Reason            respjson.Field
+		ExtraFields       map[string]respjson.Field
+		raw               string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeMcpApprovalResponse) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeMcpApprovalResponse) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A Realtime item requesting human approval of a tool invocation.
+type RealtimeMcpApprovalRequest struct {
+	// The unique ID of the approval request.
+	ID string `json:"id,required"`
+	// A JSON string of arguments for the tool.
+	Arguments string `json:"arguments,required"`
+	// The name of the tool to run.
+	Name string `json:"name,required"`
+	// The label of the MCP server making the request.
+	ServerLabel string `json:"server_label,required"`
+	// The type of the item. Always `mcp_approval_request`.
+	Type constant.McpApprovalRequest `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID          respjson.Field
+		Arguments   respjson.Field
+		Name        respjson.Field
+		ServerLabel respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeMcpApprovalRequest) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeMcpApprovalRequest) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A Realtime item listing tools available on an MCP server.
+type RealtimeMcpListTools struct {
+	// The label of the MCP server.
+	ServerLabel string `json:"server_label,required"`
+	// The tools available on the server.
+	Tools []RealtimeMcpListToolsTool `json:"tools,required"`
+	// The type of the item. Always `mcp_list_tools`.
+	Type constant.McpListTools `json:"type,required"`
+	// The unique ID of the list.
+	ID string `json:"id"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ServerLabel respjson.Field
+		Tools       respjson.Field
+		Type        respjson.Field
+		ID          respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeMcpListTools) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeMcpListTools) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A tool available on an MCP server.
+type RealtimeMcpListToolsTool struct {
+	// The JSON schema describing the tool's input.
+	InputSchema any `json:"input_schema,required"`
+	// The name of the tool.
+	Name string `json:"name,required"`
+	// Additional annotations about the tool.
+	Annotations any `json:"annotations"`
+	// The description of the tool.
+	Description string `json:"description"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		InputSchema respjson.Field
+		Name        respjson.Field
+		Annotations respjson.Field
+		Description respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeMcpListToolsTool) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeMcpListToolsTool) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A Realtime item representing an invocation of a tool on an MCP server.
+type RealtimeMcpToolCall struct {
+	// The unique ID of the tool call.
+	ID string `json:"id,required"`
+	// A JSON string of the arguments passed to the tool.
+	Arguments string `json:"arguments,required"`
+	// The name of the tool that was run.
+	Name string `json:"name,required"`
+	// The label of the MCP server running the tool.
+	ServerLabel string `json:"server_label,required"`
+	// The type of the item. Always `mcp_call`.
+	Type constant.McpCall `json:"type,required"`
+	// The ID of an associated approval request, if any.
+	ApprovalRequestID string `json:"approval_request_id"`
+	// The error from the tool call, if any.
+	Error RealtimeError `json:"error"`
+	// The output from the tool call.
+	Output string `json:"output"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID                respjson.Field
+		Arguments         respjson.Field
+		Name              respjson.Field
+		ServerLabel       respjson.Field
+		Type              respjson.Field
+		ApprovalRequestID respjson.Field
+		Error             respjson.Field
+		Output            respjson.Field
+		ExtraFields       map[string]respjson.Field
+		raw               string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeMcpToolCall) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeMcpToolCall) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The response resource.
+type RealtimeResponse struct {
+	// The unique ID of the response, will look like `resp_1234`.
+	ID string `json:"id"`
+	// Which conversation the response is added to, determined by the
+	// `conversation` field in the `response.create` event. If `auto`, the response
+	// will be added to the default conversation and the value of `conversation_id`
+	// will be an id like `conv_1234`. If `none`, the response will not be added to
+	// any conversation and the value of `conversation_id` will be `null`.
+	ConversationID string `json:"conversation_id"`
+	// Maximum number of output tokens for a single assistant response, inclusive
+	// of tool calls, that was used in this response.
+	MaxOutputTokens RealtimeSessionCreateResponseMaxOutputTokensUnion `json:"max_output_tokens"`
+	// Set of 16 key-value pairs that can be attached to an object.
+	Metadata shared.Metadata `json:"metadata"`
+	// The object type, must be `realtime.response`.
+	Object string `json:"object"`
+	// The list of output items generated by the response.
+	Output []ConversationItemUnion `json:"output"`
+	// The set of modalities the model used to respond.
+	//
+	// Any of "text", "audio".
+	OutputModalities []string `json:"output_modalities"`
+	// The final status of the response.
+	//
+	// Any of "completed", "cancelled", "failed", "incomplete", "in_progress".
+	Status string `json:"status"`
+	// Additional details about the status.
+	StatusDetails RealtimeResponseStatus `json:"status_details"`
+	// Usage statistics for the Response, this will correspond to billing. A
+	// Realtime API session will maintain a conversation context and append new
+	// Items to the Conversation, thus output from previous turns (text and audio
+	// tokens) will become the input for later turns.
+	Usage RealtimeResponseUsage `json:"usage"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID               respjson.Field
+		ConversationID   respjson.Field
+		MaxOutputTokens  respjson.Field
+		Metadata         respjson.Field
+		Object           respjson.Field
+		Output           respjson.Field
+		OutputModalities respjson.Field
+		Status           respjson.Field
+		StatusDetails    respjson.Field
+		Usage            respjson.Field
+		ExtraFields      map[string]respjson.Field
+		raw              string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeResponse) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeResponse) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Additional details about the status of a response.
+type RealtimeResponseStatus struct {
+	// A description of the error that caused the response to fail, populated when
+	// the `status` is `failed`.
+	Error RealtimeResponseStatusError `json:"error"`
+	// The reason the Response did not complete.
+	//
+	// Any of "turn_detected", "client_cancelled", "max_output_tokens",
+	// "content_filter".
+	Reason string `json:"reason"`
+	// The type of error that caused the response to fail, corresponding with the
+	// `status` field.
+	//
+	// Any of "completed", "cancelled", "incomplete", "failed".
+	Type string `json:"type"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Error       respjson.Field
+		Reason      respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeResponseStatus) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeResponseStatus) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A description of the error that caused the response to fail.
+type RealtimeResponseStatusError struct {
+	// Error code, if any.
+	Code string `json:"code"`
+	// The type of error.
+	Type string `json:"type"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Code        respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeResponseStatusError) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeResponseStatusError) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Usage statistics for a Realtime response.
+type RealtimeResponseUsage struct {
+	// Details about the input tokens used in the Response.
+	InputTokenDetails RealtimeResponseUsageInputTokenDetails `json:"input_token_details"`
+	// The number of input tokens used in the Response, including text and audio
+	// tokens.
+	InputTokens int64 `json:"input_tokens"`
+	// Details about the output tokens used in the Response.
+	OutputTokenDetails RealtimeResponseUsageOutputTokenDetails `json:"output_token_details"`
+	// The number of output tokens sent in the Response, including text and audio
+	// tokens.
+	OutputTokens int64 `json:"output_tokens"`
+	// The total number of tokens in the Response including input and output text
+	// and audio tokens.
+	TotalTokens int64 `json:"total_tokens"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		InputTokenDetails  respjson.Field
+		InputTokens        respjson.Field
+		OutputTokenDetails respjson.Field
+		OutputTokens       respjson.Field
+		TotalTokens        respjson.Field
+		ExtraFields        map[string]respjson.Field
+		raw                string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeResponseUsage) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeResponseUsage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Details about the input tokens used in the Response.
+type RealtimeResponseUsageInputTokenDetails struct {
+	// The number of audio tokens used as input for the Response.
+	AudioTokens int64 `json:"audio_tokens"`
+	// The number of cached tokens used as input for the Response.
+	CachedTokens int64 `json:"cached_tokens"`
+	// The number of image tokens used as input for the Response.
+	ImageTokens int64 `json:"image_tokens"`
+	// The number of text tokens used as input for the Response.
+	TextTokens int64 `json:"text_tokens"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		AudioTokens  respjson.Field
+		CachedTokens respjson.Field
+		ImageTokens  respjson.Field
+		TextTokens   respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeResponseUsageInputTokenDetails) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeResponseUsageInputTokenDetails) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Details about the output tokens used in the Response.
+type RealtimeResponseUsageOutputTokenDetails struct {
+	// The number of audio tokens used in the Response.
+	AudioTokens int64 `json:"audio_tokens"`
+	// The number of text tokens used in the Response.
+	TextTokens int64 `json:"text_tokens"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		AudioTokens respjson.Field
+		TextTokens  respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeResponseUsageOutputTokenDetails) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeResponseUsageOutputTokenDetails) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A content part of a Realtime response output item.
+type RealtimeContentPart struct {
+	// The content type.
+	//
+	// Any of "text", "audio".
+	Type string `json:"type,required"`
+	// Base64-encoded audio data, if the type is `audio`.
+	Audio string `json:"audio"`
+	// The text content, if the type is `text`.
+	Text string `json:"text"`
+	// The transcript of the audio, if the type is `audio`.
+	Transcript string `json:"transcript"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Type        respjson.Field
+		Audio       respjson.Field
+		Text        respjson.Field
+		Transcript  respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeContentPart) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeContentPart) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Usage statistics for the transcription, this is billed according to the ASR
+// model's pricing rather than the realtime model's pricing.
+type ConversationItemInputAudioTranscriptionCompletedEventUsage struct {
+	// The type of the usage object.
+	//
+	// Any of "tokens", "duration".
+	Type string `json:"type,required"`
+	// Number of input tokens billed for this request.
+	InputTokens int64 `json:"input_tokens"`
+	// Number of output tokens generated.
+	OutputTokens int64 `json:"output_tokens"`
+	// Total number of tokens used (input + output).
+	TotalTokens int64 `json:"total_tokens"`
+	// Duration of the input audio in seconds.
+	Seconds float64 `json:"seconds"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Type         respjson.Field
+		InputTokens  respjson.Field
+		OutputTokens respjson.Field
+		TotalTokens  respjson.Field
+		Seconds      respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemInputAudioTranscriptionCompletedEventUsage) RawJSON() string {
+	return r.JSON.raw
+}
+func (r *ConversationItemInputAudioTranscriptionCompletedEventUsage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A rate limit reported by the server.
+type RateLimitsUpdatedEventRateLimit struct {
+	// The maximum allowed value for the rate limit.
+	Limit int64 `json:"limit"`
+	// The name of the rate limit.
+	//
+	// Any of "requests", "tokens".
+	Name string `json:"name"`
+	// The remaining value before the limit is reached.
+	Remaining int64 `json:"remaining"`
+	// Seconds until the rate limit resets.
+	ResetSeconds float64 `json:"reset_seconds"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Limit        respjson.Field
+		Name         respjson.Field
+		Remaining    respjson.Field
+		ResetSeconds respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RateLimitsUpdatedEventRateLimit) RawJSON() string { return r.JSON.raw }
+func (r *RateLimitsUpdatedEventRateLimit) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when an error occurs, which could be a client problem or a server
+// problem. Most errors are recoverable and the session will stay open.
+type RealtimeErrorEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// Details of the error.
+	Error RealtimeError `json:"error,required"`
+	// The event type, must be `error`.
+	Type constant.Error `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		Error       respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RealtimeErrorEvent) RawJSON() string { return r.JSON.raw }
+func (r *RealtimeErrorEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a Session is created. Emitted automatically when a new
+// connection is established as the first server event.
+type SessionCreatedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The session configuration.
+	Session ClientSecretNewResponseSessionUnion `json:"session,required"`
+	// The event type, must be `session.created`.
+	Type constant.SessionCreated `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		Session     respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r SessionCreatedEvent) RawJSON() string { return r.JSON.raw }
+func (r *SessionCreatedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a session is updated with a `session.update` event, unless
+// there is an error.
+type SessionUpdatedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The session configuration.
+	Session ClientSecretNewResponseSessionUnion `json:"session,required"`
+	// The event type, must be `session.updated`.
+	Type constant.SessionUpdated `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		Session     respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r SessionUpdatedEvent) RawJSON() string { return r.JSON.raw }
+func (r *SessionUpdatedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a conversation item is created.
+type ConversationItemCreatedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// A single item within a Realtime conversation.
+	Item ConversationItemUnion `json:"item,required"`
+	// The ID of the item that precedes this one, if any. This is used to maintain
+	// ordering when items are inserted.
+	PreviousItemID string `json:"previous_item_id"`
+	// The event type, must be `conversation.item.created`.
+	Type constant.ConversationItemCreated `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID        respjson.Field
+		Item           respjson.Field
+		PreviousItemID respjson.Field
+		Type           respjson.Field
+		ExtraFields    map[string]respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemCreatedEvent) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemCreatedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Sent by the server when an Item is added to the default Conversation. This
+// can happen in several cases, such as when a client sends a
+// `conversation.item.create` event or when the model produces output.
+type ConversationItemAdded struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// A single item within a Realtime conversation.
+	Item ConversationItemUnion `json:"item,required"`
+	// The ID of the item that precedes this one, if any. This is used to maintain
+	// ordering when items are inserted.
+	PreviousItemID string `json:"previous_item_id"`
+	// The event type, must be `conversation.item.added`.
+	Type constant.ConversationItemAdded `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID        respjson.Field
+		Item           respjson.Field
+		PreviousItemID respjson.Field
+		Type           respjson.Field
+		ExtraFields    map[string]respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemAdded) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemAdded) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a conversation item is finalized.
+type ConversationItemDone struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// A single item within a Realtime conversation.
+	Item ConversationItemUnion `json:"item,required"`
+	// The ID of the item that precedes this one, if any. This is used to maintain
+	// ordering when items are inserted.
+	PreviousItemID string `json:"previous_item_id"`
+	// The event type, must be `conversation.item.done`.
+	Type constant.ConversationItemDone `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID        respjson.Field
+		Item           respjson.Field
+		PreviousItemID respjson.Field
+		Type           respjson.Field
+		ExtraFields    map[string]respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemDone) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemDone) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a conversation item is retrieved with
+// `conversation.item.retrieve`.
+type ConversationItemRetrieved struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// A single item within a Realtime conversation.
+	Item ConversationItemUnion `json:"item,required"`
+	// The event type, must be `conversation.item.retrieved`.
+	Type constant.ConversationItemRetrieved `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		Item        respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemRetrieved) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemRetrieved) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when an item in the conversation is deleted by the client with a
+// `conversation.item.delete` event.
+type ConversationItemDeletedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item that was deleted.
+	ItemID string `json:"item_id,required"`
+	// The event type, must be `conversation.item.deleted`.
+	Type constant.ConversationItemDeleted `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemDeletedEvent) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemDeletedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when an earlier assistant audio message item is truncated by the
+// client with a `conversation.item.truncate` event.
+type ConversationItemTruncatedEvent struct {
+	// The duration up to which the audio was truncated, in milliseconds.
+	AudioEndMs int64 `json:"audio_end_ms,required"`
+	// The index of the content part that was truncated.
+	ContentIndex int64 `json:"content_index,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the assistant message item that was truncated.
+	ItemID string `json:"item_id,required"`
+	// The event type, must be `conversation.item.truncated`.
+	Type constant.ConversationItemTruncated `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		AudioEndMs   respjson.Field
+		ContentIndex respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemTruncatedEvent) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemTruncatedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// This event is the output of audio transcription for user audio written to the
+// user audio buffer.
+type ConversationItemInputAudioTranscriptionCompletedEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item containing the audio that is being transcribed.
+	ItemID string `json:"item_id,required"`
+	// The transcribed text.
+	Transcript string `json:"transcript,required"`
+	// Usage statistics for the transcription.
+	Usage ConversationItemInputAudioTranscriptionCompletedEventUsage `json:"usage,required"`
+	// The log probabilities of the transcription.
+	Logprobs []LogProbProperties `json:"logprobs"`
+	// The event type, must be
+	// `conversation.item.input_audio_transcription.completed`.
+	Type constant.ConversationItemInputAudioTranscriptionCompleted `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		Transcript   respjson.Field
+		Usage        respjson.Field
+		Logprobs     respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemInputAudioTranscriptionCompletedEvent) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemInputAudioTranscriptionCompletedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the text value of an input audio transcription content part is
+// updated with incremental transcription results.
+type ConversationItemInputAudioTranscriptionDeltaEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item containing the audio that is being transcribed.
+	ItemID string `json:"item_id,required"`
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index"`
+	// The text delta.
+	Delta string `json:"delta"`
+	// The log probabilities of the transcription.
+	Logprobs []LogProbProperties `json:"logprobs"`
+	// The event type, must be `conversation.item.input_audio_transcription.delta`.
+	Type constant.ConversationItemInputAudioTranscriptionDelta `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		ContentIndex respjson.Field
+		Delta        respjson.Field
+		Logprobs     respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemInputAudioTranscriptionDeltaEvent) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemInputAudioTranscriptionDeltaEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when an input audio transcription segment is identified for an item.
+type ConversationItemInputAudioTranscriptionSegment struct {
+	// The segment identifier.
+	ID string `json:"id,required"`
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// End time of the segment in seconds.
+	End float64 `json:"end,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item containing the input audio content.
+	ItemID string `json:"item_id,required"`
+	// The detected speaker label for this segment.
+	Speaker string `json:"speaker,required"`
+	// Start time of the segment in seconds.
+	Start float64 `json:"start,required"`
+	// The text for this segment.
+	Text string `json:"text,required"`
+	// The event type, must be
+	// `conversation.item.input_audio_transcription.segment`.
+	Type constant.ConversationItemInputAudioTranscriptionSegment `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID           respjson.Field
+		ContentIndex respjson.Field
+		End          respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		Speaker      respjson.Field
+		Start        respjson.Field
+		Text         respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemInputAudioTranscriptionSegment) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemInputAudioTranscriptionSegment) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when input audio transcription is configured, and a transcription
+// request for a user message failed.
+type ConversationItemInputAudioTranscriptionFailedEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// Details of the transcription error.
+	Error RealtimeError `json:"error,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the user message item.
+	ItemID string `json:"item_id,required"`
+	// The event type, must be
+	// `conversation.item.input_audio_transcription.failed`.
+	Type constant.ConversationItemInputAudioTranscriptionFailed `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		Error        respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConversationItemInputAudioTranscriptionFailedEvent) RawJSON() string { return r.JSON.raw }
+func (r *ConversationItemInputAudioTranscriptionFailedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the input audio buffer is cleared by the client with a
+// `input_audio_buffer.clear` event.
+type InputAudioBufferClearedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The event type, must be `input_audio_buffer.cleared`.
+	Type constant.InputAudioBufferCleared `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r InputAudioBufferClearedEvent) RawJSON() string { return r.JSON.raw }
+func (r *InputAudioBufferClearedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when an input audio buffer is committed, either by the client or
+// automatically in server VAD mode.
+type InputAudioBufferCommittedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the user message item that will be created.
+	ItemID string `json:"item_id,required"`
+	// The ID of the item that precedes this one, if any. This is used to maintain
+	// ordering when items are inserted.
+	PreviousItemID string `json:"previous_item_id"`
+	// The event type, must be `input_audio_buffer.committed`.
+	Type constant.InputAudioBufferCommitted `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID        respjson.Field
+		ItemID         respjson.Field
+		PreviousItemID respjson.Field
+		Type           respjson.Field
+		ExtraFields    map[string]respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r InputAudioBufferCommittedEvent) RawJSON() string { return r.JSON.raw }
+func (r *InputAudioBufferCommittedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Sent by the server when in `server_vad` mode to indicate that speech has been
+// detected in the audio buffer.
+type InputAudioBufferSpeechStartedEvent struct {
+	// Milliseconds from the start of all audio written to the buffer during the
+	// session when speech was first detected.
+	AudioStartMs int64 `json:"audio_start_ms,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the user message item that will be created when speech stops.
+	ItemID string `json:"item_id,required"`
+	// The event type, must be `input_audio_buffer.speech_started`.
+	Type constant.InputAudioBufferSpeechStarted `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		AudioStartMs respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r InputAudioBufferSpeechStartedEvent) RawJSON() string { return r.JSON.raw }
+func (r *InputAudioBufferSpeechStartedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned in `server_vad` mode when the server detects the end of speech in
+// the audio buffer.
+type InputAudioBufferSpeechStoppedEvent struct {
+	// Milliseconds since the session started when speech stopped.
+	AudioEndMs int64 `json:"audio_end_ms,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the user message item that will be created.
+	ItemID string `json:"item_id,required"`
+	// The event type, must be `input_audio_buffer.speech_stopped`.
+	Type constant.InputAudioBufferSpeechStopped `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		AudioEndMs  respjson.Field
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r InputAudioBufferSpeechStoppedEvent) RawJSON() string { return r.JSON.raw }
+func (r *InputAudioBufferSpeechStoppedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the Server VAD timeout is triggered for the input audio buffer.
+type InputAudioBufferTimeoutTriggered struct {
+	// Millisecond offset of audio written to the input audio buffer at the time
+	// the timeout was triggered.
+	AudioEndMs int64 `json:"audio_end_ms,required"`
+	// Millisecond offset of audio written to the input audio buffer that was after
+	// the playback time of the last model response.
+	AudioStartMs int64 `json:"audio_start_ms,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item associated with this segment.
+	ItemID string `json:"item_id,required"`
+	// The event type, must be `input_audio_buffer.timeout_triggered`.
+	Type constant.InputAudioBufferTimeoutTriggered `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		AudioEndMs   respjson.Field
+		AudioStartMs respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r InputAudioBufferTimeoutTriggered) RawJSON() string { return r.JSON.raw }
+func (r *InputAudioBufferTimeoutTriggered) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// SIP Only: Returned when a DTMF event is received.
+type InputAudioBufferDtmfEventReceivedEvent struct {
+	// The telephone keypad that was pressed by the user.
+	Event string `json:"event,required"`
+	// UTC Unix Timestamp when DTMF Event was received by server.
+	ReceivedAt int64 `json:"received_at,required"`
+	// The event type, must be `input_audio_buffer.dtmf_event_received`.
+	Type constant.InputAudioBufferDtmfEventReceived `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Event       respjson.Field
+		ReceivedAt  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r InputAudioBufferDtmfEventReceivedEvent) RawJSON() string { return r.JSON.raw }
+func (r *InputAudioBufferDtmfEventReceivedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// WebRTC and SIP Only: Emitted when the server begins streaming audio to the
+// client.
+type OutputAudioBufferStartedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The unique ID of the response that produced the audio.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `output_audio_buffer.started`.
+	Type constant.OutputAudioBufferStarted `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ResponseID  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r OutputAudioBufferStartedEvent) RawJSON() string { return r.JSON.raw }
+func (r *OutputAudioBufferStartedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// WebRTC and SIP Only: Emitted when the output audio buffer has been completely
+// drained on the server.
+type OutputAudioBufferStoppedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The unique ID of the response that produced the audio.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `output_audio_buffer.stopped`.
+	Type constant.OutputAudioBufferStopped `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ResponseID  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r OutputAudioBufferStoppedEvent) RawJSON() string { return r.JSON.raw }
+func (r *OutputAudioBufferStoppedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// WebRTC and SIP Only: Emitted when the output audio buffer is cleared.
+type OutputAudioBufferClearedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The unique ID of the response that produced the audio.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `output_audio_buffer.cleared`.
+	Type constant.OutputAudioBufferCleared `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ResponseID  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r OutputAudioBufferClearedEvent) RawJSON() string { return r.JSON.raw }
+func (r *OutputAudioBufferClearedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Emitted at the beginning of a Response to indicate the updated rate limits.
+type RateLimitsUpdatedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// List of rate limit information.
+	RateLimits []RateLimitsUpdatedEventRateLimit `json:"rate_limits,required"`
+	// The event type, must be `rate_limits.updated`.
+	Type constant.RateLimitsUpdated `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		RateLimits  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RateLimitsUpdatedEvent) RawJSON() string { return r.JSON.raw }
+func (r *RateLimitsUpdatedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a new Response is created. The first event of response
+// creation, where the response is in an initial state of `in_progress`.
+type ResponseCreatedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The response resource.
+	Response RealtimeResponse `json:"response,required"`
+	// The event type, must be `response.created`.
+	Type constant.ResponseCreated `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		Response    respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseCreatedEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseCreatedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a Response is done streaming. Always emitted, no matter the
+// final state.
+type ResponseDoneEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The response resource.
+	Response RealtimeResponse `json:"response,required"`
+	// The event type, must be `response.done`.
+	Type constant.ResponseDone `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		Response    respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseDoneEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseDoneEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a new Item is created during Response generation.
+type ResponseOutputItemAddedEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// A single item within a Realtime conversation.
+	Item ConversationItemUnion `json:"item,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.output_item.added`.
+	Type constant.ResponseOutputItemAdded `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		Item        respjson.Field
+		OutputIndex respjson.Field
+		ResponseID  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseOutputItemAddedEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseOutputItemAddedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when an Item is done streaming.
+type ResponseOutputItemDoneEvent struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// A single item within a Realtime conversation.
+	Item ConversationItemUnion `json:"item,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.output_item.done`.
+	Type constant.ResponseOutputItemDone `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		Item        respjson.Field
+		OutputIndex respjson.Field
+		ResponseID  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseOutputItemDoneEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseOutputItemDoneEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a new content part is added to an assistant message item during
+// response generation.
+type ResponseContentPartAddedEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The content part.
+	Part RealtimeContentPart `json:"part,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.content_part.added`.
+	Type constant.ResponseContentPartAdded `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		OutputIndex  respjson.Field
+		Part         respjson.Field
+		ResponseID   respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseContentPartAddedEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseContentPartAddedEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when a content part is done streaming in an assistant message item.
+type ResponseContentPartDoneEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The content part.
+	Part RealtimeContentPart `json:"part,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.content_part.done`.
+	Type constant.ResponseContentPartDone `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		OutputIndex  respjson.Field
+		Part         respjson.Field
+		ResponseID   respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseContentPartDoneEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseContentPartDoneEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the text value of an "output_text" content part is updated.
+type ResponseTextDeltaEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// The text delta.
+	Delta string `json:"delta,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.output_text.delta`.
+	Type constant.ResponseOutputTextDelta `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		Delta        respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		OutputIndex  respjson.Field
+		ResponseID   respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseTextDeltaEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseTextDeltaEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the text value of an "output_text" content part is done
+// streaming.
+type ResponseTextDoneEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The final text content.
+	Text string `json:"text,required"`
+	// The event type, must be `response.output_text.done`.
+	Type constant.ResponseOutputTextDone `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		OutputIndex  respjson.Field
+		ResponseID   respjson.Field
+		Text         respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseTextDoneEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseTextDoneEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the model-generated transcription of audio output is updated.
+type ResponseAudioTranscriptDeltaEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// The transcript delta.
+	Delta string `json:"delta,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.output_audio_transcript.delta`.
+	Type constant.ResponseOutputAudioTranscriptDelta `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		Delta        respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		OutputIndex  respjson.Field
+		ResponseID   respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseAudioTranscriptDeltaEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseAudioTranscriptDeltaEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the model-generated transcription of audio output is done
+// streaming.
+type ResponseAudioTranscriptDoneEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The final transcript of the audio.
+	Transcript string `json:"transcript,required"`
+	// The event type, must be `response.output_audio_transcript.done`.
+	Type constant.ResponseOutputAudioTranscriptDone `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		OutputIndex  respjson.Field
+		ResponseID   respjson.Field
+		Transcript   respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseAudioTranscriptDoneEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseAudioTranscriptDoneEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the model-generated audio is updated.
+type ResponseAudioDeltaEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// Base64-encoded audio data delta.
+	Delta string `json:"delta,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.output_audio.delta`.
+	Type constant.ResponseOutputAudioDelta `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		Delta        respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		OutputIndex  respjson.Field
+		ResponseID   respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseAudioDeltaEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseAudioDeltaEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the model-generated audio is done.
+type ResponseAudioDoneEvent struct {
+	// The index of the content part in the item's content array.
+	ContentIndex int64 `json:"content_index,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.output_audio.done`.
+	Type constant.ResponseOutputAudioDone `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ContentIndex respjson.Field
+		EventID      respjson.Field
+		ItemID       respjson.Field
+		OutputIndex  respjson.Field
+		ResponseID   respjson.Field
+		Type         respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseAudioDoneEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseAudioDoneEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the model-generated function call arguments are updated.
+type ResponseFunctionCallArgumentsDeltaEvent struct {
+	// The ID of the function call.
+	CallID string `json:"call_id,required"`
+	// The arguments delta as a JSON string.
+	Delta string `json:"delta,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.function_call_arguments.delta`.
+	Type constant.ResponseFunctionCallArgumentsDelta `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		CallID      respjson.Field
+		Delta       respjson.Field
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		OutputIndex respjson.Field
+		ResponseID  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseFunctionCallArgumentsDeltaEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseFunctionCallArgumentsDeltaEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when the model-generated function call arguments are done streaming.
+type ResponseFunctionCallArgumentsDoneEvent struct {
+	// The final arguments as a JSON string.
+	Arguments string `json:"arguments,required"`
+	// The ID of the function call.
+	CallID string `json:"call_id,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The name of the function that was called.
+	Name string `json:"name,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.function_call_arguments.done`.
+	Type constant.ResponseFunctionCallArgumentsDone `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Arguments   respjson.Field
+		CallID      respjson.Field
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		Name        respjson.Field
+		OutputIndex respjson.Field
+		ResponseID  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseFunctionCallArgumentsDoneEvent) RawJSON() string { return r.JSON.raw }
+func (r *ResponseFunctionCallArgumentsDoneEvent) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when MCP tool call arguments are updated during response generation.
+type ResponseMcpCallArgumentsDelta struct {
+	// The JSON-encoded arguments delta.
+	Delta string `json:"delta,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.mcp_call_arguments.delta`.
+	Type constant.ResponseMcpCallArgumentsDelta `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Delta       respjson.Field
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		OutputIndex respjson.Field
+		ResponseID  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseMcpCallArgumentsDelta) RawJSON() string { return r.JSON.raw }
+func (r *ResponseMcpCallArgumentsDelta) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when MCP tool call arguments are finalized during response
+// generation.
+type ResponseMcpCallArgumentsDone struct {
+	// The final JSON-encoded arguments string.
+	Arguments string `json:"arguments,required"`
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The ID of the response.
+	ResponseID string `json:"response_id,required"`
+	// The event type, must be `response.mcp_call_arguments.done`.
+	Type constant.ResponseMcpCallArgumentsDone `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Arguments   respjson.Field
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		OutputIndex respjson.Field
+		ResponseID  respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseMcpCallArgumentsDone) RawJSON() string { return r.JSON.raw }
+func (r *ResponseMcpCallArgumentsDone) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when an MCP tool call has started and is in progress.
+type ResponseMcpCallInProgress struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The event type, must be `response.mcp_call.in_progress`.
+	Type constant.ResponseMcpCallInProgress `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		OutputIndex respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseMcpCallInProgress) RawJSON() string { return r.JSON.raw }
+func (r *ResponseMcpCallInProgress) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when an MCP tool call has completed successfully.
+type ResponseMcpCallCompleted struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The event type, must be `response.mcp_call.completed`.
+	Type constant.ResponseMcpCallCompleted `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		OutputIndex respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseMcpCallCompleted) RawJSON() string { return r.JSON.raw }
+func (r *ResponseMcpCallCompleted) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when an MCP tool call has failed.
+type ResponseMcpCallFailed struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The index of the output item in the response.
+	OutputIndex int64 `json:"output_index,required"`
+	// The event type, must be `response.mcp_call.failed`.
+	Type constant.ResponseMcpCallFailed `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		OutputIndex respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ResponseMcpCallFailed) RawJSON() string { return r.JSON.raw }
+func (r *ResponseMcpCallFailed) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when listing MCP tools is in progress for an item.
+type McpListToolsInProgress struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The event type, must be `mcp_list_tools.in_progress`.
+	Type constant.McpListToolsInProgress `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r McpListToolsInProgress) RawJSON() string { return r.JSON.raw }
+func (r *McpListToolsInProgress) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when listing MCP tools has completed for an item.
+type McpListToolsCompleted struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The event type, must be `mcp_list_tools.completed`.
+	Type constant.McpListToolsCompleted `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r McpListToolsCompleted) RawJSON() string { return r.JSON.raw }
+func (r *McpListToolsCompleted) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Returned when listing MCP tools has failed for an item.
+type McpListToolsFailed struct {
+	// The unique ID of the server event.
+	EventID string `json:"event_id,required"`
+	// The ID of the item.
+	ItemID string `json:"item_id,required"`
+	// The event type, must be `mcp_list_tools.failed`.
+	Type constant.McpListToolsFailed `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		EventID     respjson.Field
+		ItemID      respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r McpListToolsFailed) RawJSON() string { return r.JSON.raw }
+func (r *McpListToolsFailed) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A content part of a conversation item.
+//
+// The fields that should be set depend on the content Type.
+//
+// The property Type is required.
+type ConversationItemContentParam struct {
+	// The content type.
+	//
+	// Any of "input_text", "input_audio", "input_image", "output_text",
+	// "output_audio".
+	Type string `json:"type,required"`
+	// The text content, for `input_text` and `output_text` parts.
+	Text param.Opt[string] `json:"text,omitzero"`
+	// Base64-encoded audio bytes, for `input_audio` and `output_audio` parts.
+	Audio param.Opt[string] `json:"audio,omitzero"`
+	// The transcript of the audio, for `input_audio` and `output_audio` parts.
+	Transcript param.Opt[string] `json:"transcript,omitzero"`
+	// Base64-encoded image bytes as a data URL, for `input_image` parts.
+	ImageURL param.Opt[string] `json:"image_url,omitzero"`
+	// The detail level of the image, for `input_image` parts.
+	//
+	// Any of "auto", "low", "high".
+	Detail string `json:"detail,omitzero"`
+	paramObj
+}
+
+func (r ConversationItemContentParam) MarshalJSON() (data []byte, err error) {
+	type shadow ConversationItemContentParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *ConversationItemContentParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A system message item in a Realtime conversation.
+//
+// The property Content is required.
+type RealtimeConversationItemSystemMessageParam struct {
+	// The content of the message.
+	Content []ConversationItemContentParam `json:"content,required"`
+	// The unique ID of the item. This may be provided by the client or generated
+	// by the server.
+	ID param.Opt[string] `json:"id,omitzero"`
+	// The role of the message sender. Always `system`.
+	//
+	// This field can be elided, and will marshal its zero value as "system".
+	Role constant.System `json:"role,required"`
+	// The type of the item. Always `message`.
+	//
+	// This field can be elided, and will marshal its zero value as "message".
+	Type constant.Message `json:"type,required"`
+	paramObj
+}
+
+func (r RealtimeConversationItemSystemMessageParam) MarshalJSON() (data []byte, err error) {
+	type shadow RealtimeConversationItemSystemMessageParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *RealtimeConversationItemSystemMessageParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A user message item in a Realtime conversation.
+//
+// The property Content is required.
+type RealtimeConversationItemUserMessageParam struct {
+	// The content of the message.
+	Content []ConversationItemContentParam `json:"content,required"`
+	// The unique ID of the item. This may be provided by the client or generated
+	// by the server.
+	ID param.Opt[string] `json:"id,omitzero"`
+	// The role of the message sender. Always `user`.
+	//
+	// This field can be elided, and will marshal its zero value as "user".
+	Role constant.User `json:"role,required"`
+	// The type of the item. Always `message`.
+	//
+	// This field can be elided, and will marshal its zero value as "message".
+	Type constant.Message `json:"type,required"`
+	paramObj
+}
+
+func (r RealtimeConversationItemUserMessageParam) MarshalJSON() (data []byte, err error) {
+	type shadow RealtimeConversationItemUserMessageParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *RealtimeConversationItemUserMessageParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A assistant message item in a Realtime conversation.
+//
+// The property Content is required.
+type RealtimeConversationItemAssistantMessageParam struct {
+	// The content of the message.
+	Content []ConversationItemContentParam `json:"content,required"`
+	// The unique ID of the item. This may be provided by the client or generated
+	// by the server.
+	ID param.Opt[string] `json:"id,omitzero"`
+	// The role of the message sender. Always `assistant`.
+	//
+	// This field can be elided, and will marshal its zero value as "assistant".
+	Role constant.Assistant `json:"role,required"`
+	// The type of the item. Always `message`.
+	//
+	// This field can be elided, and will marshal its zero value as "message".
+	Type constant.Message `json:"type,required"`
+	paramObj
+}
+
+func (r RealtimeConversationItemAssistantMessageParam) MarshalJSON() (data []byte, err error) {
+	type shadow RealtimeConversationItemAssistantMessageParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *RealtimeConversationItemAssistantMessageParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A function call item in a Realtime conversation.
+//
+// The properties Arguments and Name are required.
+type RealtimeConversationItemFunctionCallParam struct {
+	// The arguments of the function call, as a JSON-encoded string.
+	Arguments string `json:"arguments,required"`
+	// The name of the function being called.
+	Name string `json:"name,required"`
+	// The ID of the function call.
+	CallID param.Opt[string] `json:"call_id,omitzero"`
+	// The unique ID of the item. This may be provided by the client or generated
+	// by the server.
+	ID param.Opt[string] `json:"id,omitzero"`
+	// The type of the item. Always `function_call`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "function_call".
+	Type constant.FunctionCall `json:"type,required"`
+	paramObj
+}
+
+func (r RealtimeConversationItemFunctionCallParam) MarshalJSON() (data []byte, err error) {
+	type shadow RealtimeConversationItemFunctionCallParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *RealtimeConversationItemFunctionCallParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A function call output item in a Realtime conversation.
+//
+// The properties CallID and Output are required.
+type RealtimeConversationItemFunctionCallOutputParam struct {
+	// The ID of the function call this output is for.
+	CallID string `json:"call_id,required"`
+	// The output of the function call, this is free text and can contain any
+	// information or simply be empty.
+	Output string `json:"output,required"`
+	// The unique ID of the item. This may be provided by the client or generated
+	// by the server.
+	ID param.Opt[string] `json:"id,omitzero"`
+	// The type of the item. Always `function_call_output`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "function_call_output".
+	Type constant.FunctionCallOutput `json:"type,required"`
+	paramObj
+}
+
+func (r RealtimeConversationItemFunctionCallOutputParam) MarshalJSON() (data []byte, err error) {
+	type shadow RealtimeConversationItemFunctionCallOutputParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *RealtimeConversationItemFunctionCallOutputParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A Realtime item responding to an MCP approval request.
+//
+// The properties ID, ApprovalRequestID and Approve are required.
+type RealtimeMcpApprovalResponseParam struct {
+	// The unique ID of the approval response.
+	ID string `json:"id,required"`
+	// The ID of the approval request being answered.
+	ApprovalRequestID string `json:"approval_request_id,required"`
+	// Whether the request was approved.
+	Approve bool `json:"approve,required"`
+	// Optional reason for the decision.
+	Reason param.Opt[string] `json:"reason,omitzero"`
+	// The type of the item. Always `mcp_approval_response`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "mcp_approval_response".
+	Type constant.McpApprovalResponse `json:"type,required"`
+	paramObj
+}
+
+func (r RealtimeMcpApprovalResponseParam) MarshalJSON() (data []byte, err error) {
+	type shadow RealtimeMcpApprovalResponseParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *RealtimeMcpApprovalResponseParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Create a new Realtime response with these parameters
+type RealtimeResponseCreateParamsParam struct {
+	// Controls which conversation the response is added to. Currently supports
+	// `auto` and `none`, with `auto` as the default value. The `auto` value means
+	// that the contents of the response will be added to the default conversation.
+	// Set this to `none` to create an out-of-band response which will not add
+	// items to default conversation.
+	Conversation param.Opt[string] `json:"conversation,omitzero"`
+	// The default system instructions (i.e. system message) prepended to model
+	// calls.
+	Instructions param.Opt[string] `json:"instructions,omitzero"`
+	// Input items to include in the prompt for the model. Using this field creates
+	// a new context for this Response instead of using the default conversation.
+	Input []ConversationItemUnionParam `json:"input,omitzero"`
+	// Maximum number of output tokens for a single assistant response, inclusive
+	// of tool calls.
+	MaxOutputTokens RealtimeSessionCreateRequestMaxOutputTokensUnionParam `json:"max_output_tokens,omitzero"`
+	// Set of 16 key-value pairs that can be attached to an object.
+	Metadata shared.Metadata `json:"metadata,omitzero"`
+	// The set of modalities the model used to respond.
+	//
+	// Any of "text", "audio".
+	OutputModalities []string `json:"output_modalities,omitzero"`
+	// How the model chooses tools.
+	ToolChoice RealtimeToolChoiceConfigUnionParam `json:"tool_choice,omitzero"`
+	// Tools available to the model.
+	Tools RealtimeToolsConfigParam `json:"tools,omitzero"`
+	paramObj
+}
+
+func (r RealtimeResponseCreateParamsParam) MarshalJSON() (data []byte, err error) {
+	type shadow RealtimeResponseCreateParamsParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *RealtimeResponseCreateParamsParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Send this event to update the session's configuration. The client may send
+// this event at any time to update any field except for `voice` and `model`.
+//
+// The property Session is required.
+type SessionUpdateEventParam struct {
+	// Update the Realtime session. Choose either a realtime session or a
+	// transcription session.
+	Session ClientSecretNewParamsSessionUnion `json:"session,required"`
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// The event type, must be `session.update`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "session.update".
+	Type constant.SessionUpdate `json:"type,required"`
+	paramObj
+}
+
+func (r SessionUpdateEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow SessionUpdateEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *SessionUpdateEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Send this event to append audio bytes to the input audio buffer.
+//
+// The property Audio is required.
+type InputAudioBufferAppendEventParam struct {
+	// Base64-encoded audio bytes. This must be in the format specified by the
+	// `format` field in the session configuration.
+	Audio string `json:"audio,required"`
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// The event type, must be `input_audio_buffer.append`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "input_audio_buffer.append".
+	Type constant.InputAudioBufferAppend `json:"type,required"`
+	paramObj
+}
+
+func (r InputAudioBufferAppendEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow InputAudioBufferAppendEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *InputAudioBufferAppendEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Send this event to commit the user input audio buffer, which will create a
+// new user message item in the conversation.
+type InputAudioBufferCommitEventParam struct {
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// The event type, must be `input_audio_buffer.commit`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "input_audio_buffer.commit".
+	Type constant.InputAudioBufferCommit `json:"type,required"`
+	paramObj
+}
+
+func (r InputAudioBufferCommitEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow InputAudioBufferCommitEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *InputAudioBufferCommitEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Send this event to clear the audio bytes in the buffer.
+type InputAudioBufferClearEventParam struct {
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// The event type, must be `input_audio_buffer.clear`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "input_audio_buffer.clear".
+	Type constant.InputAudioBufferClear `json:"type,required"`
+	paramObj
+}
+
+func (r InputAudioBufferClearEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow InputAudioBufferClearEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *InputAudioBufferClearEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// WebRTC and SIP Only: Emit to cut off the current audio response.
+type OutputAudioBufferClearEventParam struct {
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// The event type, must be `output_audio_buffer.clear`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "output_audio_buffer.clear".
+	Type constant.OutputAudioBufferClear `json:"type,required"`
+	paramObj
+}
+
+func (r OutputAudioBufferClearEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow OutputAudioBufferClearEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *OutputAudioBufferClearEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Add a new Item to the Conversation's context, including messages, function
+// calls, and function call responses.
+//
+// The property Item is required.
+type ConversationItemCreateEventParam struct {
+	// A single item within a Realtime conversation.
+	Item ConversationItemUnionParam `json:"item,required"`
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// The ID of the preceding item after which the new item will be inserted. If
+	// not set, the new item will be appended to the end of the conversation. If
+	// set to `root`, the new item will be added to the beginning of the
+	// conversation.
+	PreviousItemID param.Opt[string] `json:"previous_item_id,omitzero"`
+	// The event type, must be `conversation.item.create`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "conversation.item.create".
+	Type constant.ConversationItemCreate `json:"type,required"`
+	paramObj
+}
+
+func (r ConversationItemCreateEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow ConversationItemCreateEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *ConversationItemCreateEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Send this event when you want to retrieve the server's representation of a
+// specific item in the conversation history.
+//
+// The property ItemID is required.
+type ConversationItemRetrieveEventParam struct {
+	// The ID of the item to retrieve.
+	ItemID string `json:"item_id,required"`
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// The event type, must be `conversation.item.retrieve`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "conversation.item.retrieve".
+	Type constant.ConversationItemRetrieve `json:"type,required"`
+	paramObj
+}
+
+func (r ConversationItemRetrieveEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow ConversationItemRetrieveEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *ConversationItemRetrieveEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Send this event to truncate a previous assistant message's audio.
+//
+// The properties AudioEndMs, ContentIndex and ItemID are required.
+type ConversationItemTruncateEventParam struct {
+	// Inclusive duration up to which audio is truncated, in milliseconds.
+	AudioEndMs int64 `json:"audio_end_ms,required"`
+	// The index of the content part to truncate. Set this to `0`.
+	ContentIndex int64 `json:"content_index,required"`
+	// The ID of the assistant message item to truncate.
+	ItemID string `json:"item_id,required"`
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// The event type, must be `conversation.item.truncate`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "conversation.item.truncate".
+	Type constant.ConversationItemTruncate `json:"type,required"`
+	paramObj
+}
+
+func (r ConversationItemTruncateEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow ConversationItemTruncateEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *ConversationItemTruncateEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Send this event when you want to remove any item from the conversation
+// history.
+//
+// The property ItemID is required.
+type ConversationItemDeleteEventParam struct {
+	// The ID of the item to delete.
+	ItemID string `json:"item_id,required"`
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// The event type, must be `conversation.item.delete`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "conversation.item.delete".
+	Type constant.ConversationItemDelete `json:"type,required"`
+	paramObj
+}
+
+func (r ConversationItemDeleteEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow ConversationItemDeleteEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *ConversationItemDeleteEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// This event instructs the server to create a Response, which means triggering
+// model inference.
+type ResponseCreateEventParam struct {
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// Create a new Realtime response with these parameters
+	Response RealtimeResponseCreateParamsParam `json:"response,omitzero"`
+	// The event type, must be `response.create`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "response.create".
+	Type constant.ResponseCreate `json:"type,required"`
+	paramObj
+}
+
+func (r ResponseCreateEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow ResponseCreateEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *ResponseCreateEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Send this event to cancel an in-progress response.
+type ResponseCancelEventParam struct {
+	// Optional client-generated ID used to identify this event.
+	EventID param.Opt[string] `json:"event_id,omitzero"`
+	// A specific response ID to cancel - if not provided, will cancel an in-
+	// progress response in the default conversation.
+	ResponseID param.Opt[string] `json:"response_id,omitzero"`
+	// The event type, must be `response.cancel`.
+	//
+	// This field can be elided, and will marshal its zero value as
+	// "response.cancel".
+	Type constant.ResponseCancel `json:"type,required"`
+	paramObj
+}
+
+func (r ResponseCancelEventParam) MarshalJSON() (data []byte, err error) {
+	type shadow ResponseCancelEventParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+func (r *ResponseCancelEventParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
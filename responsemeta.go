@@ -0,0 +1,39 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/respmeta"
+)
+
+// ResponseMetaOf returns the metadata of the response behind v, such as its
+// request ID, processing time and rate limits. v can be a stream, an error
+// wrapping an [*Error], or a *http.Response:
+//
+//	completion, err := client.Chat.Completions.New(ctx, params)
+//	if err != nil {
+//		meta, _ := openai.ResponseMetaOf(err)
+//		log.Printf("request %s failed: %v", meta.RequestID, err)
+//	}
+//
+// It reports false for other values. The metadata of a successful request is
+// copied by [option.WithResponseMetaInto].
+func ResponseMetaOf(v any) (ResponseMeta, bool) {
+	switch v := v.(type) {
+	case nil:
+		return ResponseMeta{}, false
+	case *http.Response:
+		return respmeta.FromResponse(v), v != nil
+	case interface{ Meta() ResponseMeta }:
+		meta := v.Meta()
+		return meta, meta.StatusCode != 0
+	case error:
+		var apierr *Error
+		if errors.As(v, &apierr) {
+			return apierr.Meta, true
+		}
+		return ResponseMeta{}, false
+	}
+	return ResponseMeta{}, false
+}
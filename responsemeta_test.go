@@ -0,0 +1,80 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/openaitest"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+func TestResponseMeta(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+	params := openai.ChatCompletionNewParams{
+		Model:    openai.ChatModelGPT4o,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hello")},
+	}
+
+	srv.Enqueue(openaitest.RouteChatCompletionNew, openaitest.RateLimited(time.Millisecond))
+	var meta openai.ResponseMeta
+	if _, err := client.Chat.Completions.New(ctx, params, option.WithResponseMetaInto(&meta)); err != nil {
+		t.Fatal(err)
+	}
+	if meta.RequestID != "req_2" || meta.Retries != 1 || meta.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected metadata: %+v", meta)
+	}
+
+	var pageMeta openai.ResponseMeta
+	if _, err := client.Files.List(ctx, openai.FileListParams{}, option.WithResponseMetaInto(&pageMeta)); err != nil {
+		t.Fatal(err)
+	}
+	if pageMeta.RequestID != "req_3" {
+		t.Fatalf("unexpected page metadata: %+v", pageMeta)
+	}
+
+	stream := client.Chat.Completions.NewStreaming(ctx, params)
+	defer stream.Close()
+	if meta := stream.Meta(); meta.RequestID != "req_4" {
+		t.Fatalf("unexpected stream metadata: %+v", meta)
+	}
+
+	srv.Enqueue(openaitest.RouteChatCompletionNew, openaitest.Reply{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"X-Request-Id":                   []string{"req_quota"},
+			"Openai-Processing-Ms":           []string{"12.5"},
+			"X-Ratelimit-Remaining-Requests": []string{"0"},
+		},
+		Body: openaitest.InsufficientQuota().Body,
+	})
+	var errMeta openai.ResponseMeta
+	_, err := client.Chat.Completions.New(ctx, params, option.WithMaxRetries(0), option.WithResponseMetaInto(&errMeta))
+	var apierr *openai.Error
+	if !errors.As(err, &apierr) {
+		t.Fatalf("expected an API error, got %v", err)
+	}
+	if apierr.RequestID != "req_quota" || apierr.Meta.ProcessingTime != 12500*time.Microsecond || !apierr.Meta.RateLimit.Valid() {
+		t.Fatalf("unexpected error metadata: %+v", apierr.Meta)
+	}
+	if !strings.Contains(err.Error(), "req_quota") {
+		t.Errorf("expected the request ID in the error message: %v", err)
+	}
+	if meta, ok := openai.ResponseMetaOf(err); !ok || meta.RequestID != "req_quota" {
+		t.Errorf("expected the metadata of the error, got %+v", meta)
+	}
+	if errMeta.RequestID != "req_quota" || errMeta.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected the metadata of the failed response, got %+v", errMeta)
+	}
+
+	if _, ok := openai.ResponseMetaOf(&openai.ChatCompletion{}); ok {
+		t.Errorf("expected no metadata for a result")
+	}
+}
@@ -0,0 +1,50 @@
+package responses
+
+import (
+	"context"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/polling"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+)
+
+// ErrPollTimeout is wrapped by the error returned from the polling helpers when the
+// response is still pending after the wait set with [option.WithPollMaxWait].
+var ErrPollTimeout = polling.ErrTimeout
+
+// PollStatus waits until a background Response is no longer queued or in progress
+// and returns it. Pass 0 as pollIntervalMs to use the default polling interval of 1 second.
+func (r *ResponseService) PollStatus(ctx context.Context, responseID string, pollIntervalMs int, opts ...option.RequestOption) (*Response, error) {
+	return polling.Poll(ctx, polling.Target[Response]{
+		Name: "response",
+		Get: func(opts ...option.RequestOption) (*Response, error) {
+			return r.Get(ctx, responseID, ResponseGetParams{}, opts...)
+		},
+		Status: func(res *Response) (string, bool, bool) {
+			switch res.Status {
+			case ResponseStatusQueued, ResponseStatusInProgress:
+				return string(res.Status), true, true
+			case ResponseStatusCompleted,
+				ResponseStatusFailed,
+				ResponseStatusCancelled,
+				ResponseStatusIncomplete:
+				return string(res.Status), false, true
+			}
+			return string(res.Status), false, false
+		},
+	}, pollIntervalMs, r.Options, opts)
+}
+
+// NewAndPoll creates a Response in the background and polls the API until it is
+// no longer queued or in progress.
+//
+// Polls the API and blocks until the task is complete.
+// Default polling interval is 1 second.
+func (r *ResponseService) NewAndPoll(ctx context.Context, body ResponseNewParams, pollIntervalMs int, opts ...option.RequestOption) (*Response, error) {
+	body.Background = param.NewOpt(true)
+	res, err := r.New(ctx, body, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return r.PollStatus(ctx, res.ID, pollIntervalMs, opts...)
+}
@@ -0,0 +1,201 @@
+package responses
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/apierror"
+	"github.com/Nordlys-Labs/openai-go/v3/internal/partialjson"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/jsonschema"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ssestream"
+)
+
+// RefusalError is returned when the model refuses to produce structured output.
+type RefusalError = apierror.RefusalError
+
+// IncompleteOutputError is returned when structured output cannot be parsed
+// because generation stopped early.
+type IncompleteOutputError = apierror.IncompleteOutputError
+
+// ParsedResponse is a [Response] whose output text was decoded into a value of
+// type T.
+type ParsedResponse[T any] struct {
+	Response
+	// The output text, decoded into T. It is the zero value when the model only
+	// called tools.
+	Parsed T
+}
+
+// ParseResponse creates a model response with a JSON schema text format derived
+// from T using [jsonschema.For], and decodes the output text into T.
+//
+// If the params already set a text format, it is used as is.
+//
+// When the model refuses, a [*RefusalError] is returned, and when the response
+// is incomplete because of the token limit or the content filter, an
+// [*IncompleteOutputError] is returned. In both cases the response is returned
+// alongside the error.
+//
+//	type Answer struct {
+//		City    string `json:"city"`
+//		Country string `json:"country"`
+//	}
+//
+//	response, err := responses.ParseResponse[Answer](ctx, &client.Responses, params)
+//	if err != nil {
+//		...
+//	}
+//	fmt.Println(response.Parsed.City)
+func ParseResponse[T any](ctx context.Context, r *ResponseService, body ResponseNewParams, opts ...option.RequestOption) (*ParsedResponse[T], error) {
+	body, err := withResponseTextFormat[T](body)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.New(ctx, body, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return parseResponse[T](*response)
+}
+
+// ParseResponseStreaming is the streaming variant of [ParseResponse]. The
+// returned stream exposes the partially decoded output text as deltas arrive.
+func ParseResponseStreaming[T any](ctx context.Context, r *ResponseService, body ResponseNewParams, opts ...option.RequestOption) *ResponseParseStream[T] {
+	body, err := withResponseTextFormat[T](body)
+	if err != nil {
+		return &ResponseParseStream[T]{Stream: ssestream.NewStream[ResponseStreamEventUnion](nil, err), acc: NewResponseAccumulator()}
+	}
+	return &ResponseParseStream[T]{Stream: r.NewStreaming(ctx, body, opts...), acc: NewResponseAccumulator()}
+}
+
+// ResponseParseStream is a stream of [ResponseStreamEventUnion] which
+// accumulates the events and decodes the output text as it grows.
+//
+//	stream := responses.ParseResponseStreaming[Answer](ctx, &client.Responses, params)
+//	for stream.Next() {
+//		fmt.Printf("%+v\n", stream.Partial())
+//	}
+//	response, err := stream.Result()
+type ResponseParseStream[T any] struct {
+	*ssestream.Stream[ResponseStreamEventUnion]
+	acc     *ResponseAccumulator
+	partial T
+	err     error
+}
+
+// Next advances the stream and updates the accumulated response. It returns
+// false once the stream has ended or an error occurred.
+func (s *ResponseParseStream[T]) Next() bool {
+	if s.err != nil || !s.Stream.Next() {
+		return false
+	}
+	event := s.Stream.Current()
+	if !s.acc.AddEvent(event) {
+		s.err = fmt.Errorf("responses: could not accumulate %s event", event.Type)
+		return false
+	}
+	if event.Type == "response.output_text.delta" {
+		var partial T
+		// Partial output is decoded on a best-effort basis. Errors are reported
+		// by Result once the output is complete.
+		if partialjson.Unmarshal(accumulatedText(s.acc.Output), &partial) == nil {
+			s.partial = partial
+		}
+	}
+	return true
+}
+
+// Partial returns the output text decoded from the events received so far.
+// Fields which have not been generated yet hold their zero value, and the last
+// string field may be cut short.
+func (s *ResponseParseStream[T]) Partial() T {
+	return s.partial
+}
+
+// Accumulated returns the response accumulated from the events received so
+// far.
+func (s *ResponseParseStream[T]) Accumulated() Response {
+	return s.acc.Response
+}
+
+func (s *ResponseParseStream[T]) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.Stream.Err()
+}
+
+// Result consumes the rest of the stream and decodes the final response,
+// reporting errors like [ParseResponse].
+func (s *ResponseParseStream[T]) Result() (*ParsedResponse[T], error) {
+	for s.Next() {
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return parseResponse[T](s.acc.Response)
+}
+
+func withResponseTextFormat[T any](body ResponseNewParams) (ResponseNewParams, error) {
+	if !param.IsOmitted(body.Text.Format) {
+		return body, nil
+	}
+	schema, err := jsonschema.For[T]()
+	if err != nil {
+		return body, err
+	}
+	format := ResponseFormatTextJSONSchemaConfigParam{
+		Name:   jsonschema.Name(reflect.TypeOf((*T)(nil)).Elem()),
+		Schema: schema,
+		Strict: param.NewOpt(true),
+	}
+	body.Text.Format = ResponseFormatTextConfigUnionParam{OfJSONSchema: &format}
+	return body, nil
+}
+
+func parseResponse[T any](response Response) (*ParsedResponse[T], error) {
+	parsed := &ParsedResponse[T]{Response: response}
+
+	calledTools := false
+	for _, item := range response.Output {
+		if item.Type == "function_call" || item.Type == "custom_tool_call" {
+			calledTools = true
+		}
+		for _, content := range item.Content {
+			if content.Type == "refusal" {
+				return parsed, &RefusalError{Refusal: content.Refusal}
+			}
+		}
+	}
+	if response.Status == ResponseStatusIncomplete {
+		return parsed, &IncompleteOutputError{Reason: response.IncompleteDetails.Reason}
+	}
+
+	text := response.OutputText()
+	if text == "" && calledTools {
+		return parsed, nil
+	}
+	if err := json.Unmarshal([]byte(text), &parsed.Parsed); err != nil {
+		return parsed, fmt.Errorf("responses: failed to decode structured output: %w", err)
+	}
+	return parsed, nil
+}
+
+// accumulatedText joins the output text of a response which is still being
+// streamed, whose content parts may not have a type yet.
+func accumulatedText(output []ResponseOutputItemUnion) string {
+	var text strings.Builder
+	for _, item := range output {
+		for _, content := range item.Content {
+			if content.Type == "output_text" || content.Type == "" {
+				text.WriteString(content.Text)
+			}
+		}
+	}
+	return text.String()
+}
@@ -0,0 +1,149 @@
+package responses_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/responses"
+	"github.com/tidwall/gjson"
+)
+
+type parsedCity struct {
+	City    string `json:"city"`
+	Country string `json:"country" jsonschema:"enum=France,enum=Italy"`
+}
+
+func responseBody(status string, incomplete string, content string) string {
+	return fmt.Sprintf(`{"id":"resp_1","object":"response","created_at":1,"model":"gpt-4o","status":%q,"incomplete_details":%s,"output":[{"id":"msg_1","type":"message","role":"assistant","status":"completed","content":[%s]}]}`, status, incomplete, content)
+}
+
+func newResponseServer(t *testing.T, body string, requests chan<- []byte) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if requests != nil {
+			requests <- data
+		}
+		if strings.HasPrefix(body, "event:") {
+			w.Header().Set("Content-Type", "text/event-stream")
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestParseResponse(t *testing.T) {
+	requests := make(chan []byte, 1)
+	srv := newResponseServer(t, responseBody("completed", "null", `{"type":"output_text","text":"{\"city\":\"Paris\",\"country\":\"France\"}","annotations":[]}`), requests)
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	response, err := responses.ParseResponse[parsedCity](context.Background(), &client.Responses, responses.ResponseNewParams{
+		Input: responses.ResponseNewParamsInputUnion{OfString: openai.String("Where is the Louvre?")},
+		Model: openai.ChatModelGPT4o,
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if response.Parsed.City != "Paris" || response.Parsed.Country != "France" || response.ID != "resp_1" {
+		t.Fatalf("unexpected parsed response %+v", response.Parsed)
+	}
+
+	format := gjson.GetBytes(<-requests, "text.format")
+	if format.Get("type").String() != "json_schema" || format.Get("name").String() != "parsedCity" || !format.Get("strict").Bool() {
+		t.Fatalf("unexpected text format %s", format.Raw)
+	}
+	if enum := format.Get("schema.properties.country.enum").Raw; enum != `["France","Italy"]` {
+		t.Fatalf("unexpected enum %s", enum)
+	}
+}
+
+func TestParseResponseErrors(t *testing.T) {
+	cases := map[string]struct {
+		body  string
+		check func(error) bool
+	}{
+		"refusal": {
+			body: responseBody("completed", "null", `{"type":"refusal","refusal":"I can't help with that."}`),
+			check: func(err error) bool {
+				var refusal *responses.RefusalError
+				return errors.As(err, &refusal) && refusal.Refusal == "I can't help with that."
+			},
+		},
+		"incomplete": {
+			body: responseBody("incomplete", `{"reason":"max_output_tokens"}`, `{"type":"output_text","text":"{\"city\":\"Pa","annotations":[]}`),
+			check: func(err error) bool {
+				var incomplete *responses.IncompleteOutputError
+				return errors.As(err, &incomplete) && incomplete.Reason == "max_output_tokens"
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := newResponseServer(t, tc.body, nil)
+			client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+			response, err := responses.ParseResponse[parsedCity](context.Background(), &client.Responses, responses.ResponseNewParams{
+				Input: responses.ResponseNewParamsInputUnion{OfString: openai.String("Where is the Louvre?")},
+				Model: openai.ChatModelGPT4o,
+			})
+			if !tc.check(err) {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if response == nil || response.ID != "resp_1" {
+				t.Fatalf("expected the response to be returned with the error")
+			}
+		})
+	}
+}
+
+func TestParseResponseStreaming(t *testing.T) {
+	var body strings.Builder
+	writeEvent := func(typ string, data string) {
+		fmt.Fprintf(&body, "event: %s\ndata: %s\n\n", typ, data)
+	}
+	writeEvent("response.created", `{"type":"response.created","sequence_number":0,"response":{"id":"resp_1","object":"response","status":"in_progress","output":[]}}`)
+	writeEvent("response.output_item.added", `{"type":"response.output_item.added","sequence_number":1,"output_index":0,"item":{"id":"msg_1","type":"message","role":"assistant","status":"in_progress","content":[]}}`)
+	for i, delta := range []string{`{\"city\":\"Pa`, `ris\",\"country\":\"Fr`, `ance\"}`} {
+		writeEvent("response.output_text.delta", fmt.Sprintf(`{"type":"response.output_text.delta","sequence_number":%d,"item_id":"msg_1","output_index":0,"content_index":0,"delta":"%s"}`, i+2, delta))
+	}
+	writeEvent("response.completed", `{"type":"response.completed","sequence_number":5,"response":`+responseBody("completed", "null", `{"type":"output_text","text":"{\"city\":\"Paris\",\"country\":\"France\"}","annotations":[]}`)+`}`)
+
+	srv := newResponseServer(t, body.String(), nil)
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+	stream := responses.ParseResponseStreaming[parsedCity](context.Background(), &client.Responses, responses.ResponseNewParams{
+		Input: responses.ResponseNewParamsInputUnion{OfString: openai.String("Where is the Louvre?")},
+		Model: openai.ChatModelGPT4o,
+	})
+	defer stream.Close()
+
+	var partials []parsedCity
+	for stream.Next() {
+		if stream.Current().Type == "response.output_text.delta" {
+			partials = append(partials, stream.Partial())
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if len(partials) != 3 || partials[0].City != "Pa" || partials[1].Country != "Fr" || partials[2].Country != "France" {
+		t.Fatalf("unexpected partial values %+v", partials)
+	}
+
+	response, err := stream.Result()
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if response.Parsed.City != "Paris" || response.Status != responses.ResponseStatusCompleted {
+		t.Fatalf("unexpected parsed response %+v", response.Parsed)
+	}
+}
@@ -0,0 +1,257 @@
+package responses
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+)
+
+// DefaultCompactThreshold is the fraction of the context window of a [Session]
+// above which its context is compacted.
+const DefaultCompactThreshold = 0.8
+
+// Session keeps the state of a multi-turn conversation with the Responses API. Add
+// the turns of the user and the outputs of the tools, then send them:
+//
+//	session := client.Responses.NewSession(responses.ResponseNewParams{Model: openai.ChatModelGPT5})
+//	session.AddUserMessage("Hello!")
+//	res, err := session.Send(ctx)
+//
+// The responses are chained with previous_response_id, or kept in a conversation
+// of the Conversations API when Params.Conversation is set. When Params.Store is
+// false, the whole transcript is sent with every response.
+//
+// The session keeps a transcript of the input and output items, persisted by
+// marshaling the session to JSON and restored with [ResponseService.RestoreSession],
+// or by unmarshaling it into a session returned by [ResponseService.NewSession].
+// A Session is not safe for concurrent use.
+type Session struct {
+	// Params are the params of every response of the session, such as the model,
+	// the instructions and the tools. Their input and previous response ID are set
+	// by the session.
+	Params ResponseNewParams
+	// ContextWindow is the maximum number of input tokens of the model. When set,
+	// the input tokens are counted before every response, and the context is
+	// compacted with [ResponseService.Compact] once it exceeds CompactThreshold of
+	// the window. The context of a conversation is truncated by the API instead,
+	// since its items are kept by the Conversations API.
+	ContextWindow int64
+	// CompactThreshold is the fraction of the context window above which the
+	// context is compacted. Defaults to [DefaultCompactThreshold].
+	CompactThreshold float64
+
+	service *ResponseService
+	state   sessionState
+}
+
+type sessionState struct {
+	PreviousResponseID string                        `json:"previous_response_id,omitempty"`
+	Items              []ResponseInputItemUnionParam `json:"items"`
+	// The number of items already known by the API.
+	Sent int `json:"sent"`
+}
+
+// NewSession returns a [Session] creating responses with params.
+func (r *ResponseService) NewSession(params ResponseNewParams) *Session {
+	return &Session{Params: params, service: r}
+}
+
+// RestoreSession returns a [Session] creating responses with params, with the
+// transcript of a session encoded with [Session.MarshalJSON].
+func (r *ResponseService) RestoreSession(params ResponseNewParams, data []byte) (*Session, error) {
+	s := r.NewSession(params)
+	if err := s.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// errNoService is returned by the sessions which were not created by a
+// [ResponseService].
+var errNoService = errors.New("responses: the session must be created with ResponseService.NewSession or ResponseService.RestoreSession")
+
+// AddUserMessage adds a message of the user to the next response.
+func (s *Session) AddUserMessage(text string) {
+	s.AddItems(ResponseInputItemParamOfMessage(text, EasyInputMessageRoleUser))
+}
+
+// AddFunctionCallOutput adds the output of a function call to the next response.
+func (s *Session) AddFunctionCallOutput(callID string, output string) {
+	s.AddItems(ResponseInputItemParamOfFunctionCallOutput(callID, output))
+}
+
+// AddItems adds input items to the next response.
+func (s *Session) AddItems(items ...ResponseInputItemUnionParam) {
+	s.state.Items = append(s.state.Items, items...)
+}
+
+// Items returns the transcript of the session: the input items added so far, and
+// the output items of its responses. After a compaction, it starts with the
+// compacted items.
+func (s *Session) Items() []ResponseInputItemUnionParam {
+	return s.state.Items
+}
+
+// PreviousResponseID returns the ID of the last response of the session, when the
+// responses are chained.
+func (s *Session) PreviousResponseID() string {
+	return s.state.PreviousResponseID
+}
+
+// Send creates a response with the items added since the last response, and adds
+// its output to the transcript.
+func (s *Session) Send(ctx context.Context, opts ...option.RequestOption) (*Response, error) {
+	if s.service == nil {
+		return nil, errNoService
+	}
+	params, err := s.Prepare(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.service.New(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.Record(res)
+	return res, nil
+}
+
+// Prepare returns the params of the next response, compacting the context first
+// when needed. Use it with [Session.Record] to create the response differently,
+// for instance with [ResponseService.NewStreaming].
+func (s *Session) Prepare(ctx context.Context, opts ...option.RequestOption) (ResponseNewParams, error) {
+	params := s.params()
+	if s.service == nil {
+		return params, errNoService
+	}
+	if s.ContextWindow <= 0 || params.Truncation == ResponseNewParamsTruncationAuto {
+		return params, nil
+	}
+
+	counted := InputTokenCountParams{
+		Instructions:       params.Instructions,
+		PreviousResponseID: params.PreviousResponseID,
+		Conversation: InputTokenCountParamsConversationUnion{
+			OfString:             params.Conversation.OfString,
+			OfConversationObject: params.Conversation.OfConversationObject,
+		},
+		Input:     InputTokenCountParamsInputUnion{OfResponseInputItemArray: params.Input.OfInputItemList},
+		Tools:     params.Tools,
+		Reasoning: params.Reasoning,
+	}
+	if params.Model != "" {
+		counted.Model = param.NewOpt(params.Model)
+	}
+	count, err := s.service.InputTokens.Count(ctx, counted, opts...)
+	if err != nil {
+		return params, err
+	}
+	threshold := s.CompactThreshold
+	if threshold <= 0 {
+		threshold = DefaultCompactThreshold
+	}
+	if float64(count.InputTokens) < threshold*float64(s.ContextWindow) {
+		return params, nil
+	}
+
+	if !param.IsOmitted(params.Conversation) {
+		params.Truncation = ResponseNewParamsTruncationAuto
+		return params, nil
+	}
+	if err := s.Compact(ctx, opts...); err != nil {
+		return params, err
+	}
+	return s.params(), nil
+}
+
+// Compact replaces the context of the session with a compacted one, with
+// [ResponseService.Compact]. The next response starts a new chain of responses
+// from the compacted items.
+func (s *Session) Compact(ctx context.Context, opts ...option.RequestOption) error {
+	if s.service == nil {
+		return errNoService
+	}
+	params := s.params()
+	compacted, err := s.service.Compact(ctx, ResponseCompactParams{
+		Model:              ResponseCompactParamsModel(params.Model),
+		Instructions:       params.Instructions,
+		PreviousResponseID: params.PreviousResponseID,
+		Input:              ResponseCompactParamsInputUnion{OfResponseInputItemArray: params.Input.OfInputItemList},
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	s.state = sessionState{Items: inputItems(compacted.Output)}
+	return nil
+}
+
+// Record adds the output of a response created with the params returned by
+// [Session.Prepare] to the transcript.
+func (s *Session) Record(res *Response) {
+	s.state.Items = append(s.state.Items, inputItems(res.Output)...)
+	s.state.Sent = len(s.state.Items)
+	if param.IsOmitted(s.Params.Conversation) && !s.stateless() {
+		s.state.PreviousResponseID = res.ID
+	}
+}
+
+// params returns the params of the next response, sending the whole transcript
+// when the API does not keep the previous items.
+func (s *Session) params() ResponseNewParams {
+	params := s.Params
+	items := s.state.Items
+	if !s.stateless() {
+		items = items[s.state.Sent:]
+	}
+	params.Input = ResponseNewParamsInputUnion{OfInputItemList: items}
+	params.PreviousResponseID = param.Opt[string]{}
+	if s.state.PreviousResponseID != "" {
+		params.PreviousResponseID = param.NewOpt(s.state.PreviousResponseID)
+	}
+	return params
+}
+
+func (s *Session) stateless() bool {
+	return s.Params.Store.Valid() && !s.Params.Store.Value
+}
+
+func inputItems(output []ResponseOutputItemUnion) []ResponseInputItemUnionParam {
+	items := make([]ResponseInputItemUnionParam, 0, len(output))
+	for _, item := range output {
+		items = append(items, param.Override[ResponseInputItemUnionParam](json.RawMessage(item.RawJSON())))
+	}
+	return items
+}
+
+// MarshalJSON encodes the transcript of the session, and the ID of its last
+// response. The params are not included.
+func (s *Session) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.state)
+}
+
+// UnmarshalJSON restores the transcript of a session encoded with
+// [Session.MarshalJSON]. The session must come from [ResponseService.NewSession],
+// or be restored with [ResponseService.RestoreSession] instead.
+func (s *Session) UnmarshalJSON(data []byte) error {
+	var state struct {
+		PreviousResponseID string            `json:"previous_response_id"`
+		Items              []json.RawMessage `json:"items"`
+		Sent               int               `json:"sent"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	items := make([]ResponseInputItemUnionParam, 0, len(state.Items))
+	for _, item := range state.Items {
+		items = append(items, param.Override[ResponseInputItemUnionParam](item))
+	}
+	s.state = sessionState{
+		PreviousResponseID: state.PreviousResponseID,
+		Items:              items,
+		Sent:               min(state.Sent, len(items)),
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package responses_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/openaitest"
+	"github.com/Nordlys-Labs/openai-go/v3/responses"
+)
+
+func TestSession(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	session := client.Responses.NewSession(responses.ResponseNewParams{Model: openai.ChatModelGPT4o})
+	session.AddUserMessage("What is the weather in Paris?")
+	first, err := session.Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session.AddFunctionCallOutput("call_123", `{"temperature": 21}`)
+	if _, err := session.Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := srv.ResponseRequests()
+	if len(sent) != 2 || sent[1].PreviousResponseID.Value != first.ID || len(sent[1].Input.OfInputItemList) != 1 {
+		t.Fatalf("expected the second response to follow the first with the new item only")
+	}
+	if len(session.Items()) != 4 {
+		t.Errorf("expected 2 inputs and 2 outputs in the transcript, got %d", len(session.Items()))
+	}
+
+	// The transcript survives a round trip through JSON.
+	data, err := json.Marshal(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := client.Responses.RestoreSession(responses.ResponseNewParams{Model: openai.ChatModelGPT4o}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A session unmarshaled into a zero value cannot create responses.
+	var unbound responses.Session
+	if err := json.Unmarshal(data, &unbound); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := unbound.Send(ctx); err == nil {
+		t.Error("expected an error from a session without a service")
+	}
+	if restored.PreviousResponseID() != session.PreviousResponseID() || len(restored.Items()) != 4 {
+		t.Fatalf("unexpected restored session %s", data)
+	}
+	if item := string(mustMarshal(t, restored.Items()[0])); !strings.Contains(item, `"content":"What is the weather in Paris?"`) {
+		t.Errorf("unexpected first item %s", item)
+	}
+
+	// Near the context window, the session is compacted and a new chain starts.
+	restored.ContextWindow = 100
+	restored.AddUserMessage("And in Rome?")
+	if _, err := restored.Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Requests(openaitest.RouteResponseInputTokens)) != 1 || len(srv.Requests(openaitest.RouteResponseCompact)) != 1 {
+		t.Fatalf("expected the tokens to be counted and the session compacted")
+	}
+	sent = srv.ResponseRequests()
+	last := sent[len(sent)-1]
+	if last.PreviousResponseID.Valid() || !strings.Contains(string(mustMarshal(t, last.Input)), `"type":"compaction"`) {
+		t.Errorf("expected the compacted items to start a new chain")
+	}
+}
+
+func TestSessionConversation(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	session := client.Responses.NewSession(responses.ResponseNewParams{
+		Model:        openai.ChatModelGPT4o,
+		Conversation: responses.ResponseNewParamsConversationUnion{OfString: openai.String("conv_123")},
+	})
+	session.ContextWindow = 10
+	session.AddUserMessage("Hello!")
+	if _, err := session.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	session.AddUserMessage("Hello again!")
+	if _, err := session.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, params := range srv.ResponseRequests() {
+		if params.PreviousResponseID.Valid() || params.Truncation != responses.ResponseNewParamsTruncationAuto || len(params.Input.OfInputItemList) != 1 {
+			t.Errorf("expected the conversation to be truncated, without chaining the responses")
+		}
+	}
+	if len(srv.Requests(openaitest.RouteResponseCompact)) != 0 {
+		t.Errorf("expected a conversation not to be compacted")
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -8,6 +8,7 @@ type ResponseAccumulator struct {
 	toolCallIndices  map[string]int64
 	nextToolIndex    int64
 	functionCallMeta map[string]FunctionCallMeta
+	lastSequence     int64
 
 	// transient state set when the last AddEvent produced a "just finished" item
 	justFinishedType            string
@@ -96,6 +97,14 @@ func (acc *ResponseAccumulator) AddEvent(event ResponseStreamEventUnion) bool {
 	acc.justAddedFunctionCall = AddedFunctionCall{}
 	acc.justDeltaFunctionCall = FunctionCallArgumentsDelta{}
 
+	// skip events repeated when a stream is resumed after a sequence number
+	if event.SequenceNumber > 0 {
+		if event.SequenceNumber <= acc.lastSequence {
+			return true
+		}
+		acc.lastSequence = event.SequenceNumber
+	}
+
 	switch event.Type {
 	case "response.created":
 		acc.Response = event.Response
@@ -0,0 +1,146 @@
+package responses_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/responses"
+)
+
+func sseEvent(w http.ResponseWriter, data string) {
+	fmt.Fprintf(w, "data: %s\n\n", data)
+	w.(http.Flusher).Flush()
+}
+
+// newDroppingStreamServer serves a response stream whose connection drops after
+// the third event, or stalls when stall is set, and whose resumed stream repeats
+// the last event it got.
+func newDroppingStreamServer(t *testing.T, background bool, stall bool, reconnects *atomic.Int32) *httptest.Server {
+	t.Helper()
+	created := fmt.Sprintf(`{"type":"response.created","sequence_number":0,"response":{"id":"resp_1","status":"in_progress","background":%t}}`, background)
+	delta := func(seq int, text string) string {
+		return fmt.Sprintf(`{"type":"response.output_text.delta","sequence_number":%d,"output_index":0,"content_index":0,"item_id":"msg_1","delta":%q}`, seq, text)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/responses":
+			sseEvent(w, created)
+			sseEvent(w, delta(1, "Hello"))
+			sseEvent(w, delta(2, ", "))
+			if stall {
+				<-r.Context().Done()
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/responses/resp_1":
+			reconnects.Add(1)
+			if r.URL.Query().Get("starting_after") != "2" || r.URL.Query().Get("stream") != "true" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			sseEvent(w, delta(2, ", "))
+			sseEvent(w, delta(3, "world"))
+			sseEvent(w, `{"type":"response.completed","sequence_number":4,"response":{"id":"resp_1","status":"completed","background":true,"output":[{"id":"msg_1","type":"message","role":"assistant","content":[{"type":"output_text","text":"Hello, world"}]}]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestResponseStreamResumes(t *testing.T) {
+	var reconnects atomic.Int32
+	srv := newDroppingStreamServer(t, true, false, &reconnects)
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	stream := client.Responses.NewStreaming(context.Background(), responses.ResponseNewParams{
+		Model:      openai.ChatModelGPT4o,
+		Background: openai.Bool(true),
+	}, option.WithStreamReconnects(2))
+	defer stream.Close()
+
+	acc := responses.NewResponseAccumulator()
+	var sequence []int64
+	text := ""
+	for stream.Next() {
+		event := stream.Current()
+		sequence = append(sequence, event.SequenceNumber)
+		acc.AddEvent(event)
+		text += event.Delta
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if reconnects.Load() != 1 {
+		t.Fatalf("expected 1 reconnection, got %d", reconnects.Load())
+	}
+	if fmt.Sprint(sequence) != "[0 1 2 3 4]" || text != "Hello, world" {
+		t.Fatalf("unexpected events %v with text %q", sequence, text)
+	}
+	if acc.Status != responses.ResponseStatusCompleted || acc.OutputText() != "Hello, world" {
+		t.Fatalf("unexpected accumulated response %s", acc.RawJSON())
+	}
+}
+
+func TestResponseStreamResumesIdleConnection(t *testing.T) {
+	var reconnects atomic.Int32
+	srv := newDroppingStreamServer(t, true, true, &reconnects)
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	stream := client.Responses.NewStreaming(context.Background(), responses.ResponseNewParams{
+		Model:      openai.ChatModelGPT4o,
+		Background: openai.Bool(true),
+	}, option.WithStreamReconnects(2), option.WithStreamIdleTimeout(50*time.Millisecond))
+	defer stream.Close()
+
+	text := ""
+	for stream.Next() {
+		text += stream.Current().Delta
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if reconnects.Load() != 1 || text != "Hello, world" {
+		t.Fatalf("expected the idle connection to be resumed once, got %d reconnections with text %q", reconnects.Load(), text)
+	}
+}
+
+func TestResponseStreamDoesNotResumeForeground(t *testing.T) {
+	var reconnects atomic.Int32
+	srv := newDroppingStreamServer(t, false, false, &reconnects)
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	stream := client.Responses.NewStreaming(context.Background(), responses.ResponseNewParams{
+		Model: openai.ChatModelGPT4o,
+	}, option.WithStreamReconnects(2))
+	defer stream.Close()
+
+	events := 0
+	for stream.Next() {
+		events++
+	}
+	if stream.Err() != nil || events != 3 || reconnects.Load() != 0 {
+		t.Fatalf("expected the stream to end after 3 events without reconnecting, got %d events, %d reconnections and %v", events, reconnects.Load(), stream.Err())
+	}
+}
+
+func TestResponseAccumulatorSkipsRepeatedEvents(t *testing.T) {
+	acc := responses.NewResponseAccumulator()
+	for _, event := range []responses.ResponseStreamEventUnion{
+		{Type: "response.output_text.delta", SequenceNumber: 1, Delta: "Hello"},
+		{Type: "response.output_text.delta", SequenceNumber: 2, Delta: " world"},
+		{Type: "response.output_text.delta", SequenceNumber: 2, Delta: " world"},
+	} {
+		acc.AddEvent(event)
+	}
+	if text := acc.Output[0].Content[0].Text; text != "Hello world" {
+		t.Fatalf("expected the repeated event to be skipped, got %q", text)
+	}
+}
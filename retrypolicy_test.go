@@ -0,0 +1,125 @@
+package openai_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+func rateLimitedClient(code string, attempts *int, opts ...option.RequestOption) openai.Client {
+	return openai.NewClient(append([]option.RequestOption{
+		option.WithAPIKey("My API Key"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					*attempts++
+					return &http.Response{
+						StatusCode: http.StatusTooManyRequests,
+						Header: http.Header{
+							"Content-Type":   []string{"application/json"},
+							"Retry-After-Ms": []string{"1"},
+						},
+						Body: io.NopCloser(strings.NewReader(`{"error":{"code":"` + code + `","message":"slow down"}}`)),
+					}, nil
+				},
+			},
+		}),
+	}, opts...)...)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	var observed []option.RetryAttempt
+	policy := option.BackoffRetryPolicy{
+		ShouldRetry: func(attempt option.RetryAttempt) bool {
+			if attempt.ErrorCode() == "insufficient_quota" {
+				return false
+			}
+			return option.DefaultShouldRetry(attempt)
+		},
+		OnAttempt: func(attempt option.RetryAttempt, retry bool, delay time.Duration) {
+			observed = append(observed, attempt)
+		},
+	}
+
+	attempts := 0
+	client := rateLimitedClient("rate_limit_exceeded", &attempts, option.WithRetryPolicy(policy))
+	_, err := client.Models.Get(context.Background(), "gpt-4o")
+	if err == nil || attempts != 3 || len(observed) != 3 {
+		t.Fatalf("expected 3 attempts for a rate limit, got %d attempts and %d observed", attempts, len(observed))
+	}
+	if observed[2].Attempt != 2 || observed[2].Remaining != 0 || observed[2].StatusCode() != http.StatusTooManyRequests {
+		t.Fatalf("unexpected last attempt %+v", observed[2])
+	}
+
+	attempts = 0
+	client = rateLimitedClient("insufficient_quota", &attempts, option.WithRetryPolicy(policy))
+	_, err = client.Models.Get(context.Background(), "gpt-4o")
+	apierr, ok := err.(*openai.Error)
+	if !ok || attempts != 1 || apierr.Code != "insufficient_quota" {
+		t.Fatalf("expected 1 attempt with an insufficient_quota error, got %d attempts and %v", attempts, err)
+	}
+}
+
+func TestRetryPolicyMaxElapsed(t *testing.T) {
+	attempts := 0
+	client := rateLimitedClient("rate_limit_exceeded", &attempts,
+		option.WithMaxRetries(10),
+		option.WithRetryPolicy(option.BackoffRetryPolicy{
+			BaseDelay:        20 * time.Millisecond,
+			IgnoreRetryAfter: true,
+			Jitter:           -1,
+			MaxElapsed:       50 * time.Millisecond,
+		}),
+	)
+	_, err := client.Models.Get(context.Background(), "gpt-4o")
+	// Retries start after 20ms and 60ms, only the first fits in 50ms.
+	if err == nil || attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryPolicyFunc(t *testing.T) {
+	attempts := 0
+	client := rateLimitedClient("rate_limit_exceeded", &attempts,
+		option.WithRetryPolicy(option.RetryPolicyFunc(func(attempt option.RetryAttempt) (bool, time.Duration) {
+			return attempt.Request.Method == http.MethodGet, 0
+		})),
+	)
+	client.Models.Get(context.Background(), "gpt-4o")
+	if attempts != 3 {
+		t.Fatalf("expected GET requests to be retried, got %d attempts", attempts)
+	}
+
+	attempts = 0
+	client.Models.Delete(context.Background(), "ft:gpt-4o:org:custom:abc")
+	if attempts != 1 {
+		t.Fatalf("expected DELETE requests not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestRetryPolicyBodyNotRewindable(t *testing.T) {
+	var decisions []bool
+	attempts := 0
+	client := rateLimitedClient("rate_limit_exceeded", &attempts,
+		option.WithRetryPolicy(option.BackoffRetryPolicy{
+			OnAttempt: func(attempt option.RetryAttempt, retry bool, delay time.Duration) {
+				if attempt.Remaining != 0 {
+					t.Errorf("expected no retries left, got %d", attempt.Remaining)
+				}
+				decisions = append(decisions, retry)
+			},
+		}),
+	)
+	// A reader of unknown type cannot be sent again.
+	body := io.MultiReader(strings.NewReader(`{"model":"gpt-4o"}`))
+	client.Post(context.Background(), "chat/completions", nil, nil, option.WithRequestBody("application/json", body))
+	if attempts != 1 || len(decisions) != 1 || decisions[0] {
+		t.Fatalf("expected 1 attempt reported without a retry, got %d attempts and %v", attempts, decisions)
+	}
+}
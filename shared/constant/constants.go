@@ -54,6 +54,7 @@ type Closed string                                           // Always "closed"
 type CodeInterpreter string                                  // Always "code_interpreter"
 type CodeInterpreterCall string                              // Always "code_interpreter_call"
 type Compaction string                                       // Always "compaction"
+type Completions string                                      // Always "completions"
 type ComputerCallOutput string                               // Always "computer_call_output"
 type ComputerScreenshot string                               // Always "computer_screenshot"
 type ComputerUsePreview string                               // Always "computer_use_preview"
@@ -92,13 +93,18 @@ type Drag string                                             // Always "drag"
 type Duration string                                         // Always "duration"
 type Embedding string                                        // Always "embedding"
 type Error string                                            // Always "error"
+type Eval string                                             // Always "eval"
+type EvalRun string                                          // Always "eval.run"
 type EvalRunCanceled string                                  // Always "eval.run.canceled"
 type EvalRunFailed string                                    // Always "eval.run.failed"
+type EvalRunOutputItem string                                // Always "eval.run.output_item"
 type EvalRunSucceeded string                                 // Always "eval.run.succeeded"
 type Exec string                                             // Always "exec"
 type Exit string                                             // Always "exit"
 type File string                                             // Always "file"
 type FileCitation string                                     // Always "file_citation"
+type FileContent string                                      // Always "file_content"
+type FileID string                                           // Always "file_id"
 type FilePath string                                         // Always "file_path"
 type FileSearch string                                       // Always "file_search"
 type FileSearchCall string                                   // Always "file_search_call"
@@ -138,6 +144,8 @@ type InputAudioBufferTimeoutTriggered string                 // Always "input_au
 type InputFile string                                        // Always "input_file"
 type InputImage string                                       // Always "input_image"
 type InputText string                                        // Always "input_text"
+type ItemReference string                                    // Always "item_reference"
+type Jsonl string                                            // Always "jsonl"
 type JSONObject string                                       // Always "json_object"
 type JSONSchema string                                       // Always "json_schema"
 type Keypress string                                         // Always "keypress"
@@ -240,6 +248,7 @@ type ResponseReasoningTextDelta string                       // Always "response
 type ResponseReasoningTextDone string                        // Always "response.reasoning_text.done"
 type ResponseRefusalDelta string                             // Always "response.refusal.delta"
 type ResponseRefusalDone string                              // Always "response.refusal.done"
+type Responses string                                        // Always "responses"
 type ResponseWebSearchCallCompleted string                   // Always "response.web_search_call.completed"
 type ResponseWebSearchCallInProgress string                  // Always "response.web_search_call.in_progress"
 type ResponseWebSearchCallSearching string                   // Always "response.web_search_call.searching"
@@ -262,10 +271,12 @@ type SkillDeleted string                                     // Always "skill.de
 type SkillVersion string                                     // Always "skill.version"
 type SkillVersionDeleted string                              // Always "skill.version.deleted"
 type Static string                                           // Always "static"
+type StoredCompletions string                                // Always "stored_completions"
 type StringCheck string                                      // Always "string_check"
 type SubmitToolOutputs string                                // Always "submit_tool_outputs"
 type SummaryText string                                      // Always "summary_text"
 type System string                                           // Always "system"
+type Template string                                         // Always "template"
 type Text string                                             // Always "text"
 type TextCompletion string                                   // Always "text_completion"
 type TextSimilarity string                                   // Always "text_similarity"
@@ -368,6 +379,7 @@ func (c Closed) Default() Closed                                 { return "close
 func (c CodeInterpreter) Default() CodeInterpreter               { return "code_interpreter" }
 func (c CodeInterpreterCall) Default() CodeInterpreterCall       { return "code_interpreter_call" }
 func (c Compaction) Default() Compaction                         { return "compaction" }
+func (c Completions) Default() Completions                       { return "completions" }
 func (c ComputerCallOutput) Default() ComputerCallOutput         { return "computer_call_output" }
 func (c ComputerScreenshot) Default() ComputerScreenshot         { return "computer_screenshot" }
 func (c ComputerUsePreview) Default() ComputerUsePreview         { return "computer_use_preview" }
@@ -426,13 +438,18 @@ func (c Drag) Default() Drag                                     { return "drag"
 func (c Duration) Default() Duration                             { return "duration" }
 func (c Embedding) Default() Embedding                           { return "embedding" }
 func (c Error) Default() Error                                   { return "error" }
+func (c Eval) Default() Eval                                     { return "eval" }
+func (c EvalRun) Default() EvalRun                               { return "eval.run" }
 func (c EvalRunCanceled) Default() EvalRunCanceled               { return "eval.run.canceled" }
 func (c EvalRunFailed) Default() EvalRunFailed                   { return "eval.run.failed" }
+func (c EvalRunOutputItem) Default() EvalRunOutputItem           { return "eval.run.output_item" }
 func (c EvalRunSucceeded) Default() EvalRunSucceeded             { return "eval.run.succeeded" }
 func (c Exec) Default() Exec                                     { return "exec" }
 func (c Exit) Default() Exit                                     { return "exit" }
 func (c File) Default() File                                     { return "file" }
 func (c FileCitation) Default() FileCitation                     { return "file_citation" }
+func (c FileContent) Default() FileContent                       { return "file_content" }
+func (c FileID) Default() FileID                                 { return "file_id" }
 func (c FilePath) Default() FilePath                             { return "file_path" }
 func (c FileSearch) Default() FileSearch                         { return "file_search" }
 func (c FileSearchCall) Default() FileSearchCall                 { return "file_search_call" }
@@ -490,6 +507,8 @@ func (c InputAudioBufferTimeoutTriggered) Default() InputAudioBufferTimeoutTrigg
 func (c InputFile) Default() InputFile                           { return "input_file" }
 func (c InputImage) Default() InputImage                         { return "input_image" }
 func (c InputText) Default() InputText                           { return "input_text" }
+func (c ItemReference) Default() ItemReference                   { return "item_reference" }
+func (c Jsonl) Default() Jsonl                                   { return "jsonl" }
 func (c JSONObject) Default() JSONObject                         { return "json_object" }
 func (c JSONSchema) Default() JSONSchema                         { return "json_schema" }
 func (c Keypress) Default() Keypress                             { return "keypress" }
@@ -678,6 +697,7 @@ func (c ResponseReasoningTextDone) Default() ResponseReasoningTextDone {
 }
 func (c ResponseRefusalDelta) Default() ResponseRefusalDelta { return "response.refusal.delta" }
 func (c ResponseRefusalDone) Default() ResponseRefusalDone   { return "response.refusal.done" }
+func (c Responses) Default() Responses                       { return "responses" }
 func (c ResponseWebSearchCallCompleted) Default() ResponseWebSearchCallCompleted {
 	return "response.web_search_call.completed"
 }
@@ -706,10 +726,12 @@ func (c SkillDeleted) Default() SkillDeleted                     { return "skill
 func (c SkillVersion) Default() SkillVersion                     { return "skill.version" }
 func (c SkillVersionDeleted) Default() SkillVersionDeleted       { return "skill.version.deleted" }
 func (c Static) Default() Static                                 { return "static" }
+func (c StoredCompletions) Default() StoredCompletions           { return "stored_completions" }
 func (c StringCheck) Default() StringCheck                       { return "string_check" }
 func (c SubmitToolOutputs) Default() SubmitToolOutputs           { return "submit_tool_outputs" }
 func (c SummaryText) Default() SummaryText                       { return "summary_text" }
 func (c System) Default() System                                 { return "system" }
+func (c Template) Default() Template                             { return "template" }
 func (c Text) Default() Text                                     { return "text" }
 func (c TextCompletion) Default() TextCompletion                 { return "text_completion" }
 func (c TextSimilarity) Default() TextSimilarity                 { return "text_similarity" }
@@ -826,6 +848,7 @@ func (c Closed) MarshalJSON() ([]byte, error)                  { return marshalS
 func (c CodeInterpreter) MarshalJSON() ([]byte, error)         { return marshalString(c) }
 func (c CodeInterpreterCall) MarshalJSON() ([]byte, error)     { return marshalString(c) }
 func (c Compaction) MarshalJSON() ([]byte, error)              { return marshalString(c) }
+func (c Completions) MarshalJSON() ([]byte, error)             { return marshalString(c) }
 func (c ComputerCallOutput) MarshalJSON() ([]byte, error)      { return marshalString(c) }
 func (c ComputerScreenshot) MarshalJSON() ([]byte, error)      { return marshalString(c) }
 func (c ComputerUsePreview) MarshalJSON() ([]byte, error)      { return marshalString(c) }
@@ -872,13 +895,18 @@ func (c Drag) MarshalJSON() ([]byte, error)                                  { r
 func (c Duration) MarshalJSON() ([]byte, error)                              { return marshalString(c) }
 func (c Embedding) MarshalJSON() ([]byte, error)                             { return marshalString(c) }
 func (c Error) MarshalJSON() ([]byte, error)                                 { return marshalString(c) }
+func (c Eval) MarshalJSON() ([]byte, error)                                  { return marshalString(c) }
+func (c EvalRun) MarshalJSON() ([]byte, error)                               { return marshalString(c) }
 func (c EvalRunCanceled) MarshalJSON() ([]byte, error)                       { return marshalString(c) }
 func (c EvalRunFailed) MarshalJSON() ([]byte, error)                         { return marshalString(c) }
+func (c EvalRunOutputItem) MarshalJSON() ([]byte, error)                     { return marshalString(c) }
 func (c EvalRunSucceeded) MarshalJSON() ([]byte, error)                      { return marshalString(c) }
 func (c Exec) MarshalJSON() ([]byte, error)                                  { return marshalString(c) }
 func (c Exit) MarshalJSON() ([]byte, error)                                  { return marshalString(c) }
 func (c File) MarshalJSON() ([]byte, error)                                  { return marshalString(c) }
 func (c FileCitation) MarshalJSON() ([]byte, error)                          { return marshalString(c) }
+func (c FileContent) MarshalJSON() ([]byte, error)                           { return marshalString(c) }
+func (c FileID) MarshalJSON() ([]byte, error)                                { return marshalString(c) }
 func (c FilePath) MarshalJSON() ([]byte, error)                              { return marshalString(c) }
 func (c FileSearch) MarshalJSON() ([]byte, error)                            { return marshalString(c) }
 func (c FileSearchCall) MarshalJSON() ([]byte, error)                        { return marshalString(c) }
@@ -918,6 +946,8 @@ func (c InputAudioBufferTimeoutTriggered) MarshalJSON() ([]byte, error)      { r
 func (c InputFile) MarshalJSON() ([]byte, error)                             { return marshalString(c) }
 func (c InputImage) MarshalJSON() ([]byte, error)                            { return marshalString(c) }
 func (c InputText) MarshalJSON() ([]byte, error)                             { return marshalString(c) }
+func (c ItemReference) MarshalJSON() ([]byte, error)                         { return marshalString(c) }
+func (c Jsonl) MarshalJSON() ([]byte, error)                                 { return marshalString(c) }
 func (c JSONObject) MarshalJSON() ([]byte, error)                            { return marshalString(c) }
 func (c JSONSchema) MarshalJSON() ([]byte, error)                            { return marshalString(c) }
 func (c Keypress) MarshalJSON() ([]byte, error)                              { return marshalString(c) }
@@ -1024,6 +1054,7 @@ func (c ResponseReasoningTextDelta) MarshalJSON() ([]byte, error)         { retu
 func (c ResponseReasoningTextDone) MarshalJSON() ([]byte, error)          { return marshalString(c) }
 func (c ResponseRefusalDelta) MarshalJSON() ([]byte, error)               { return marshalString(c) }
 func (c ResponseRefusalDone) MarshalJSON() ([]byte, error)                { return marshalString(c) }
+func (c Responses) MarshalJSON() ([]byte, error)                          { return marshalString(c) }
 func (c ResponseWebSearchCallCompleted) MarshalJSON() ([]byte, error)     { return marshalString(c) }
 func (c ResponseWebSearchCallInProgress) MarshalJSON() ([]byte, error)    { return marshalString(c) }
 func (c ResponseWebSearchCallSearching) MarshalJSON() ([]byte, error)     { return marshalString(c) }
@@ -1046,10 +1077,12 @@ func (c SkillDeleted) MarshalJSON() ([]byte, error)                       { retu
 func (c SkillVersion) MarshalJSON() ([]byte, error)                       { return marshalString(c) }
 func (c SkillVersionDeleted) MarshalJSON() ([]byte, error)                { return marshalString(c) }
 func (c Static) MarshalJSON() ([]byte, error)                             { return marshalString(c) }
+func (c StoredCompletions) MarshalJSON() ([]byte, error)                  { return marshalString(c) }
 func (c StringCheck) MarshalJSON() ([]byte, error)                        { return marshalString(c) }
 func (c SubmitToolOutputs) MarshalJSON() ([]byte, error)                  { return marshalString(c) }
 func (c SummaryText) MarshalJSON() ([]byte, error)                        { return marshalString(c) }
 func (c System) MarshalJSON() ([]byte, error)                             { return marshalString(c) }
+func (c Template) MarshalJSON() ([]byte, error)                           { return marshalString(c) }
 func (c Text) MarshalJSON() ([]byte, error)                               { return marshalString(c) }
 func (c TextCompletion) MarshalJSON() ([]byte, error)                     { return marshalString(c) }
 func (c TextSimilarity) MarshalJSON() ([]byte, error)                     { return marshalString(c) }
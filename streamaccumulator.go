@@ -1,42 +1,61 @@
 package openai
 
-import "github.com/Nordlys-Labs/openai-go/v3/shared/constant"
+import (
+	"encoding/json"
+
+	"github.com/Nordlys-Labs/openai-go/v3/shared/constant"
+)
 
 // Helper to accumulate chunks from a stream
 type ChatCompletionAccumulator struct {
 	// The up-to-date accumulation of model's responses
 	ChatCompletion
 	choiceChatCompletionStates []chatCompletionResponseState
-	justFinished               chatCompletionResponseState
+	justFinished               []chatCompletionResponseState
 
 	// transient state for "just added" tool call and delta events
-	justAddedToolCall   AddedChatCompletionToolCall
-	justAddedToolCallOk bool
-	justDeltaToolCall   ChatCompletionToolCallArgumentsDelta
-	justDeltaToolCallOk bool
+	justAddedToolCalls []AddedChatCompletionToolCall
+	justDeltaToolCalls []ChatCompletionToolCallArgumentsDelta
+
+	// the accumulation of the JSON of the chunks
+	raw map[string]any
+}
+
+type FinishedChatCompletionContent struct {
+	ChoiceIndex int
+	Content     string
+}
+
+type FinishedChatCompletionRefusal struct {
+	ChoiceIndex int
+	Refusal     string
 }
 
 type FinishedChatCompletionToolCall struct {
 	ChatCompletionMessageFunctionToolCallFunction
-	Index int
-	ID    string
+	ChoiceIndex int
+	Index       int
+	ID          string
 }
 
 type AddedChatCompletionToolCall struct {
-	Index int
-	ID    string
-	Name  string
-	Type  string
+	ChoiceIndex int
+	Index       int
+	ID          string
+	Name        string
+	Type        string
 }
 
 type ChatCompletionToolCallArgumentsDelta struct {
-	Index int
-	Delta string
+	ChoiceIndex int
+	Index       int
+	Delta       string
 }
 
 type chatCompletionResponseState struct {
-	state chatCompletionResponseStateEnum
-	index int
+	state  chatCompletionResponseStateEnum
+	index  int
+	choice int
 }
 
 type chatCompletionResponseStateEnum int
@@ -52,108 +71,176 @@ const (
 // AddChunk incorporates a chunk into the accumulation. Chunks must be added in order.
 // Returns false if the chunk could not be successfully accumulated.
 //
-// The ChatCompletion field JSON does not get accumulated.
+// The state of each choice is tracked separately, so the events of a stream with
+// more than one choice carry the index of their choice. The JSON of the chunks is
+// accumulated too, and the RawJSON of the ChatCompletion is updated whenever a
+// choice finishes and with the chunks without choices, such as the usage.
 func (acc *ChatCompletionAccumulator) AddChunk(chunk ChatCompletionChunk) bool {
-	acc.justFinished = chatCompletionResponseState{}
-	acc.justAddedToolCallOk = false
-	acc.justDeltaToolCallOk = false
+	acc.justFinished = acc.justFinished[:0]
+	acc.justAddedToolCalls = acc.justAddedToolCalls[:0]
+	acc.justDeltaToolCalls = acc.justDeltaToolCalls[:0]
 
 	if !acc.accumulateDeltaWithToolCallEvents(chunk) {
 		return false
 	}
+	acc.accumulateRawJSON(chunk)
 
 	// only chunks with choices can cause finished events
-	if len(chunk.Choices) == 0 {
-		return true
+	for _, choice := range chunk.Choices {
+		choiceIndex := int(choice.Index)
+		acc.choiceChatCompletionStates = expandToFit(acc.choiceChatCompletionStates, choiceIndex)
+		state := &acc.choiceChatCompletionStates[choiceIndex]
+		state.choice = choiceIndex
+		if finished, ok := state.update(choice.Delta); ok {
+			acc.justFinished = append(acc.justFinished, finished)
+		}
 	}
-
-	chunkIndex := int(chunk.Choices[0].Index)
-	acc.choiceChatCompletionStates = expandToFit(acc.choiceChatCompletionStates, chunkIndex)
-	acc.justFinished = acc.choiceChatCompletionStates[chunkIndex].update(chunk)
 	return true
 }
 
+// JustFinishedContents retrieves the content of every choice which has just been completed
+// by the last added chunk, in the order of the choices of the chunk.
+func (acc *ChatCompletionAccumulator) JustFinishedContents() []FinishedChatCompletionContent {
+	var contents []FinishedChatCompletionContent
+	for _, finished := range acc.justFinished {
+		if finished.state == contentResponseState {
+			contents = append(contents, FinishedChatCompletionContent{
+				ChoiceIndex: finished.choice,
+				Content:     acc.Choices[finished.choice].Message.Content,
+			})
+		}
+	}
+	return contents
+}
+
 // JustFinishedContent retrieves the chat completion content when it is known to have just been completed.
 // The content is "just completed" when the last added chunk no longer contains a content
 // delta. If the content is just completed, the content is returned and the boolean is true. Otherwise,
 // an empty string is returned and the boolean will be false.
+//
+// With more than one choice, the content of the first choice just completed is returned, see
+// [ChatCompletionAccumulator.JustFinishedContents].
 func (acc *ChatCompletionAccumulator) JustFinishedContent() (content string, ok bool) {
-	if acc.justFinished.state == contentResponseState {
-		return acc.Choices[0].Message.Content, true
+	if contents := acc.JustFinishedContents(); len(contents) > 0 {
+		return contents[0].Content, true
 	}
 	return "", false
 }
 
+// JustFinishedRefusals retrieves the refusal of every choice which has just been completed
+// by the last added chunk, in the order of the choices of the chunk.
+func (acc *ChatCompletionAccumulator) JustFinishedRefusals() []FinishedChatCompletionRefusal {
+	var refusals []FinishedChatCompletionRefusal
+	for _, finished := range acc.justFinished {
+		if finished.state == refusalResponseState {
+			refusals = append(refusals, FinishedChatCompletionRefusal{
+				ChoiceIndex: finished.choice,
+				Refusal:     acc.Choices[finished.choice].Message.Refusal,
+			})
+		}
+	}
+	return refusals
+}
+
 // JustFinishedRefusal retrieves the chat completion refusal when it is known to have just been completed.
 // The refusal is "just completed" when the last added chunk no longer contains a refusal
 // delta. If the refusal is just completed, the refusal is returned and the boolean is true. Otherwise,
 // an empty string is returned and the boolean will be false.
+//
+// With more than one choice, the refusal of the first choice just completed is returned, see
+// [ChatCompletionAccumulator.JustFinishedRefusals].
 func (acc *ChatCompletionAccumulator) JustFinishedRefusal() (refusal string, ok bool) {
-	if acc.justFinished.state == refusalResponseState {
-		return acc.Choices[0].Message.Refusal, true
+	if refusals := acc.JustFinishedRefusals(); len(refusals) > 0 {
+		return refusals[0].Refusal, true
 	}
 	return "", false
 }
 
+// JustFinishedToolCalls retrieves the tool call of every choice which has just been completed
+// by the last added chunk, in the order of the choices of the chunk.
+func (acc *ChatCompletionAccumulator) JustFinishedToolCalls() []FinishedChatCompletionToolCall {
+	var toolcalls []FinishedChatCompletionToolCall
+	for _, finished := range acc.justFinished {
+		if finished.state == toolResponseState {
+			tool := acc.Choices[finished.choice].Message.ToolCalls[finished.index]
+			toolcalls = append(toolcalls, FinishedChatCompletionToolCall{
+				ChoiceIndex: finished.choice,
+				ID:          tool.ID,
+				Index:       finished.index,
+				ChatCompletionMessageFunctionToolCallFunction: ChatCompletionMessageFunctionToolCallFunction{
+					Name:      tool.Function.Name,
+					Arguments: tool.Function.Arguments,
+				},
+			})
+		}
+	}
+	return toolcalls
+}
+
 // JustFinishedToolCall retrieves a tool call when it is known to have just been completed.
 // A tool call is "just completed" when the last added chunk no longer contains a tool call
 // delta or contains a delta for a different tool call. If the tool call is just completed,
 // a FinishedChatCompletionToolCall is returned and the boolean is true. Otherwise, an empty
 // tool call is returned and the boolean will be false.
 //
+// With more than one choice, the tool call of the first choice just completed is returned, see
+// [ChatCompletionAccumulator.JustFinishedToolCalls].
+//
 // You cannot rely on this with a stream that has ParallelToolCalls enabled.
 func (acc *ChatCompletionAccumulator) JustFinishedToolCall() (toolcall FinishedChatCompletionToolCall, ok bool) {
-	if acc.justFinished.state == toolResponseState {
-		f := acc.Choices[0].Message.ToolCalls[acc.justFinished.index].Function
-		id := acc.Choices[0].Message.ToolCalls[acc.justFinished.index].ID
-		return FinishedChatCompletionToolCall{
-			ID:    id,
-			Index: acc.justFinished.index,
-			ChatCompletionMessageFunctionToolCallFunction: ChatCompletionMessageFunctionToolCallFunction{
-				Name:      f.Name,
-				Arguments: f.Arguments,
-			},
-		}, true
+	if toolcalls := acc.JustFinishedToolCalls(); len(toolcalls) > 0 {
+		return toolcalls[0], true
 	}
 	return FinishedChatCompletionToolCall{}, false
 }
 
+// JustAddedToolCalls retrieves the tool calls started by the last added chunk, of every choice.
+func (acc *ChatCompletionAccumulator) JustAddedToolCalls() []AddedChatCompletionToolCall {
+	return acc.justAddedToolCalls
+}
+
+// JustAddedToolCall retrieves the first tool call started by the last added chunk.
 func (acc *ChatCompletionAccumulator) JustAddedToolCall() (AddedChatCompletionToolCall, bool) {
-	if acc.justAddedToolCallOk {
-		return acc.justAddedToolCall, true
+	if len(acc.justAddedToolCalls) > 0 {
+		return acc.justAddedToolCalls[0], true
 	}
 	return AddedChatCompletionToolCall{}, false
 }
 
+// JustDeltaToolCalls retrieves the tool call arguments added by the last added chunk, of every choice.
+func (acc *ChatCompletionAccumulator) JustDeltaToolCalls() []ChatCompletionToolCallArgumentsDelta {
+	return acc.justDeltaToolCalls
+}
+
+// JustDeltaToolCall retrieves the first tool call arguments added by the last added chunk.
 func (acc *ChatCompletionAccumulator) JustDeltaToolCall() (ChatCompletionToolCallArgumentsDelta, bool) {
-	if acc.justDeltaToolCallOk {
-		return acc.justDeltaToolCall, true
+	if len(acc.justDeltaToolCalls) > 0 {
+		return acc.justDeltaToolCalls[0], true
 	}
 	return ChatCompletionToolCallArgumentsDelta{}, false
 }
 
 func (acc *ChatCompletionAccumulator) accumulateDeltaWithToolCallEvents(chunk ChatCompletionChunk) bool {
-	if len(chunk.Choices) > 0 {
-		delta := chunk.Choices[0].Delta
-		for j := range delta.ToolCalls {
-			deltaTool := &delta.ToolCalls[j]
+	for _, choice := range chunk.Choices {
+		for j := range choice.Delta.ToolCalls {
+			deltaTool := &choice.Delta.ToolCalls[j]
 
 			if deltaTool.ID != "" {
-				acc.justAddedToolCall = AddedChatCompletionToolCall{
-					Index: int(deltaTool.Index),
-					ID:    deltaTool.ID,
-					Name:  deltaTool.Function.Name,
-					Type:  deltaTool.Type,
-				}
-				acc.justAddedToolCallOk = true
+				acc.justAddedToolCalls = append(acc.justAddedToolCalls, AddedChatCompletionToolCall{
+					ChoiceIndex: int(choice.Index),
+					Index:       int(deltaTool.Index),
+					ID:          deltaTool.ID,
+					Name:        deltaTool.Function.Name,
+					Type:        deltaTool.Type,
+				})
 			}
 
 			if deltaTool.Function.Arguments != "" {
-				acc.justDeltaToolCall = ChatCompletionToolCallArgumentsDelta{
-					Index: int(deltaTool.Index),
-					Delta: deltaTool.Function.Arguments,
-				}
-				acc.justDeltaToolCallOk = true
+				acc.justDeltaToolCalls = append(acc.justDeltaToolCalls, ChatCompletionToolCallArgumentsDelta{
+					ChoiceIndex: int(choice.Index),
+					Index:       int(deltaTool.Index),
+					Delta:       deltaTool.Function.Arguments,
+				})
 			}
 		}
 	}
@@ -161,6 +248,82 @@ func (acc *ChatCompletionAccumulator) accumulateDeltaWithToolCallEvents(chunk Ch
 	return acc.ChatCompletion.accumulateDelta(chunk)
 }
 
+// accumulateRawJSON merges the JSON of a chunk into the JSON of the ChatCompletion,
+// with the deltas of the choices merged into their message. Chunks without JSON,
+// such as the chunks built by hand, are skipped.
+func (acc *ChatCompletionAccumulator) accumulateRawJSON(chunk ChatCompletionChunk) {
+	var fields map[string]any
+	if json.Unmarshal([]byte(chunk.RawJSON()), &fields) != nil {
+		return
+	}
+	if acc.raw == nil {
+		acc.raw = map[string]any{"choices": []any{}}
+	}
+	for _, key := range []string{"id", "created", "model", "service_tier", "system_fingerprint", "usage"} {
+		if value, ok := fields[key]; ok && value != nil {
+			acc.raw[key] = value
+		}
+	}
+	acc.raw["object"] = "chat.completion"
+
+	finished := len(chunk.Choices) == 0
+	choices, _ := acc.raw["choices"].([]any)
+	deltas, _ := fields["choices"].([]any)
+	for _, delta := range deltas {
+		delta, ok := delta.(map[string]any)
+		if !ok {
+			continue
+		}
+		index, _ := delta["index"].(float64)
+		for len(choices) <= int(index) {
+			choices = append(choices, map[string]any{
+				"index":         float64(len(choices)),
+				"message":       map[string]any{},
+				"finish_reason": nil,
+				"logprobs":      nil,
+			})
+		}
+		choice := choices[int(index)].(map[string]any)
+
+		if message, ok := delta["delta"].(map[string]any); ok {
+			toolcalls, _ := message["tool_calls"].([]any)
+			for _, tool := range toolcalls {
+				if tool, ok := tool.(map[string]any); ok {
+					index, _ := tool["index"].(float64)
+					tool["index"] = max(index, 0)
+				}
+			}
+			if accumulateDelta(choice["message"].(map[string]any), message) != nil {
+				return
+			}
+		}
+		if reason := delta["finish_reason"]; reason != nil {
+			choice["finish_reason"] = reason
+			finished = true
+		}
+		if logprobs, ok := delta["logprobs"].(map[string]any); ok {
+			current, ok := choice["logprobs"].(map[string]any)
+			if !ok {
+				current = map[string]any{}
+				choice["logprobs"] = current
+			}
+			for _, key := range []string{"content", "refusal"} {
+				if tokens, ok := logprobs[key].([]any); ok {
+					previous, _ := current[key].([]any)
+					current[key] = append(previous, tokens...)
+				}
+			}
+		}
+	}
+	acc.raw["choices"] = choices
+
+	if finished {
+		if data, err := json.Marshal(acc.raw); err == nil {
+			acc.ChatCompletion.JSON.raw = string(data)
+		}
+	}
+}
+
 // Concatenates a ChatCompletionChunk onto a ChatCompletion. Returns false and
 // does nothing if a mismatch is detected.
 //
@@ -233,9 +396,8 @@ func (cc *ChatCompletion) accumulateDelta(chunk ChatCompletionChunk) bool {
 
 // Updates the internal response state and returns the previous state if
 // the state changed. This ensures that JustFinished events only fire once.
-func (prev *chatCompletionResponseState) update(chunk ChatCompletionChunk) (justFinished chatCompletionResponseState) {
-	delta := chunk.Choices[0].Delta
-	new := chatCompletionResponseState{}
+func (prev *chatCompletionResponseState) update(delta ChatCompletionChunkChoiceDelta) (justFinished chatCompletionResponseState, ok bool) {
+	new := chatCompletionResponseState{choice: prev.choice}
 	switch {
 	case delta.JSON.Content.Valid():
 		new.state = contentResponseState
@@ -251,7 +413,7 @@ func (prev *chatCompletionResponseState) update(chunk ChatCompletionChunk) (just
 	}
 
 	if *prev != new {
-		justFinished = *prev
+		justFinished, ok = *prev, true
 	}
 	*prev = new
 
@@ -450,6 +450,61 @@ func TestAccumulatorEmptyToolCallsArray(t *testing.T) {
 	acc.AddChunk(chunk)
 }
 
+func TestAccumulatorMultipleChoices(t *testing.T) {
+	acc := openai.ChatCompletionAccumulator{}
+	chunks := []string{
+		`{"id":"test","object":"chat.completion.chunk","created":1,"model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":"","refusal":null},"finish_reason":null},{"index":1,"delta":{"role":"assistant","content":null,"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]},"finish_reason":null}],"usage":null}`,
+		`{"id":"test","object":"chat.completion.chunk","created":1,"model":"gpt-4o","choices":[{"index":1,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]},"finish_reason":null}],"usage":null}`,
+		`{"id":"test","object":"chat.completion.chunk","created":1,"model":"gpt-4o","choices":[{"index":0,"delta":{"content":"It is"},"finish_reason":null}],"usage":null}`,
+		`{"id":"test","object":"chat.completion.chunk","created":1,"model":"gpt-4o","choices":[{"index":1,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Paris\"}"}}]},"finish_reason":null}],"usage":null}`,
+		`{"id":"test","object":"chat.completion.chunk","created":1,"model":"gpt-4o","choices":[{"index":0,"delta":{"content":" sunny."},"finish_reason":null}],"usage":null}`,
+		`{"id":"test","object":"chat.completion.chunk","created":1,"model":"gpt-4o","choices":[{"index":0,"delta":{},"finish_reason":"stop"},{"index":1,"delta":{},"finish_reason":"tool_calls"}],"usage":null}`,
+		`{"id":"test","object":"chat.completion.chunk","created":1,"model":"gpt-4o","choices":[],"usage":{"completion_tokens":12,"prompt_tokens":5,"total_tokens":17}}`,
+	}
+
+	var contents []openai.FinishedChatCompletionContent
+	var toolcalls []openai.FinishedChatCompletionToolCall
+	var added []openai.AddedChatCompletionToolCall
+	var arguments []openai.ChatCompletionToolCallArgumentsDelta
+	for _, raw := range chunks {
+		chunk := openai.ChatCompletionChunk{}
+		if err := chunk.UnmarshalJSON([]byte(raw)); err != nil {
+			t.Fatalf("Failed to unmarshal chunk: %v", err)
+		}
+		if !acc.AddChunk(chunk) {
+			t.Fatal("AddChunk returned false")
+		}
+		contents = append(contents, acc.JustFinishedContents()...)
+		toolcalls = append(toolcalls, acc.JustFinishedToolCalls()...)
+		added = append(added, acc.JustAddedToolCalls()...)
+		arguments = append(arguments, acc.JustDeltaToolCalls()...)
+	}
+
+	if len(contents) != 1 || contents[0].ChoiceIndex != 0 || contents[0].Content != "It is sunny." {
+		t.Errorf("Expected the content of the first choice to finish once, got %+v", contents)
+	}
+	if len(toolcalls) != 1 || toolcalls[0].ChoiceIndex != 1 || toolcalls[0].ID != "call_1" || toolcalls[0].Arguments != `{"city":"Paris"}` {
+		t.Errorf("Expected the tool call of the second choice to finish once, got %+v", toolcalls)
+	}
+	if len(added) != 1 || added[0].ChoiceIndex != 1 || len(arguments) != 2 || arguments[1].ChoiceIndex != 1 {
+		t.Errorf("Expected the tool call events of the second choice, got %+v and %+v", added, arguments)
+	}
+
+	completion := openai.ChatCompletion{}
+	if err := completion.UnmarshalJSON([]byte(acc.RawJSON())); err != nil {
+		t.Fatalf("Failed to unmarshal the accumulated JSON: %v", err)
+	}
+	if len(completion.Choices) != 2 || completion.Choices[0].Message.Content != "It is sunny." || completion.Choices[0].FinishReason != "stop" {
+		t.Fatalf("Unexpected accumulated JSON %s", acc.RawJSON())
+	}
+	if completion.Choices[1].Message.ToolCalls[0].Function.Arguments != `{"city":"Paris"}` || completion.Choices[1].Message.Role != "assistant" {
+		t.Errorf("Unexpected accumulated JSON %s", acc.RawJSON())
+	}
+	if completion.Object != "chat.completion" || completion.Usage.TotalTokens != 17 {
+		t.Errorf("Unexpected accumulated JSON %s", acc.RawJSON())
+	}
+}
+
 // manually created on 11/3/2024
 var mockResponseBody = `data: {"id":"chatcmpl-A3Tguz3LSXTHBTY2NAPBCSyfBltxF","object":"chat.completion.chunk","created":1725392480,"model":"gpt-4o-2024-05-13","system_fingerprint":"fp_157b3831f5","choices":[{"index":0,"delta":{"role":"assistant","content":"","refusal":null},"logprobs":{"content":[],"refusal":null},"finish_reason":null}],"usage":null}
 
@@ -0,0 +1,98 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+// silentStream serves a chunk, then pings for the given duration, then nothing.
+func silentStream(pings time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"id": "chatcmpl-1", "object": "chat.completion.chunk", "choices": [{"index": 0, "delta": {"content": "Hi"}}]}`+"\n\n")
+		w.(http.Flusher).Flush()
+		for end := time.Now().Add(pings); time.Now().Before(end); {
+			time.Sleep(10 * time.Millisecond)
+			fmt.Fprint(w, ": ping\n\n")
+			w.(http.Flusher).Flush()
+		}
+		<-r.Context().Done()
+	}
+}
+
+func readStream(ctx context.Context, client openai.Client, opts ...option.RequestOption) (int, time.Duration, error) {
+	start := time.Now()
+	stream := client.Chat.Completions.NewStreaming(ctx, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hi")},
+		Model:    openai.ChatModelGPT4o,
+	}, opts...)
+	defer stream.Close()
+	chunks := 0
+	for stream.Next() {
+		chunks++
+	}
+	return chunks, time.Since(start), stream.Err()
+}
+
+func TestStreamIdleTimeout(t *testing.T) {
+	srv := httptest.NewServer(silentStream(150 * time.Millisecond))
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+	ctx := context.Background()
+
+	// The pings keep the stream alive for longer than the idle timeout.
+	chunks, elapsed, err := readStream(ctx, client, option.WithStreamIdleTimeout(60*time.Millisecond))
+	var idle *openai.StreamIdleTimeoutError
+	if chunks != 1 || !errors.As(err, &idle) || idle.Timeout != 60*time.Millisecond {
+		t.Fatalf("expected an idle timeout after the chunk, got %d chunks and %v", chunks, err)
+	}
+	if !errors.Is(err, openai.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the idle timeout to match ErrTimeout only, got %v", err)
+	}
+	if elapsed < 200*time.Millisecond {
+		t.Errorf("expected the pings to keep the stream alive, the stream ended after %s", elapsed)
+	}
+
+	// The request timeout bounds the whole stream.
+	_, _, err = readStream(ctx, client, option.WithRequestTimeout(100*time.Millisecond))
+	var timeout *openai.TimeoutError
+	if !errors.As(err, &timeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request timeout, got %v", err)
+	}
+
+	// Cancelling the context ends the stream without waiting for the server.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, elapsed, err = readStream(cancelCtx, client)
+	if !errors.Is(err, context.Canceled) || elapsed > time.Second {
+		t.Errorf("expected the stream to end with the context, got %v after %s", err, elapsed)
+	}
+
+	// Downloads are not bound by the idle timeout.
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		fmt.Fprint(w, "file ")
+		w.(http.Flusher).Flush()
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, "contents")
+	}))
+	defer slow.Close()
+	client = openai.NewClient(option.WithBaseURL(slow.URL), option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+	res, err := client.Files.Content(ctx, "file-abc123", option.WithStreamIdleTimeout(30*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if data, err := io.ReadAll(res.Body); err != nil || string(data) != "file contents" {
+		t.Errorf("expected the download not to time out, got %q and %v", data, err)
+	}
+}
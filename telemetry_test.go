@@ -0,0 +1,137 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/openaitest"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+type testSpan struct {
+	name   string
+	parent *testSpan
+	attrs  map[string]slog.Value
+	errs   []error
+	ended  bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...slog.Attr) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+func (s *testSpan) RecordError(err error) { s.errs = append(s.errs, err) }
+func (s *testSpan) End()                  { s.ended = true }
+
+type spanKey struct{}
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, option.Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	parent, _ := ctx.Value(spanKey{}).(*testSpan)
+	span := &testSpan{name: name, parent: parent, attrs: map[string]slog.Value{}}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+type testMeter struct {
+	mu      sync.Mutex
+	records map[string][]float64
+}
+
+func (m *testMeter) Record(ctx context.Context, name string, value float64, attrs ...slog.Attr) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[name] = append(m.records[name], value)
+}
+
+func TestTelemetry(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	srv.Enqueue(openaitest.RouteChatCompletionNew, openaitest.RateLimited(time.Millisecond))
+
+	tracer := &testTracer{}
+	meter := &testMeter{records: map[string][]float64{}}
+	var logs bytes.Buffer
+	client := srv.Client(
+		option.WithTracer(tracer),
+		option.WithMeter(meter),
+		option.WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		option.WithTelemetryHeaders("Authorization", "Content-Type"),
+		option.WithTelemetryBodies(1024),
+	)
+
+	params := openai.ChatCompletionNewParams{
+		Model:    openai.ChatModelGPT4o,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hello")},
+	}
+	if _, err := client.Chat.Completions.New(context.Background(), params); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tracer.spans) != 3 {
+		t.Fatalf("expected a call span and 2 attempt spans, got %d", len(tracer.spans))
+	}
+	call := tracer.spans[0]
+	if call.name != "chat gpt-4o" || !call.ended || call.parent != nil {
+		t.Errorf("unexpected call span %q", call.name)
+	}
+	for _, attempt := range tracer.spans[1:] {
+		if attempt.parent != call || !attempt.ended {
+			t.Errorf("expected the attempt spans to be children of the call span")
+		}
+	}
+	if code := tracer.spans[1].attrs["http.response.status_code"].Int64(); code != 429 {
+		t.Errorf("expected the first attempt to be rate limited, got %d", code)
+	}
+	for key, want := range map[string]string{
+		"gen_ai.request.model":              "gpt-4o",
+		"gen_ai.operation.name":             "chat",
+		"http.request.resend_count":         "1",
+		"http.request.header.authorization": "REDACTED",
+		"gen_ai.response.finish_reasons":    "[stop]",
+	} {
+		if got := call.attrs[key].String(); got != want {
+			t.Errorf("expected %s to be %q, got %q", key, want, got)
+		}
+	}
+	if call.attrs["gen_ai.usage.output_tokens"].Int64() == 0 || !strings.Contains(call.attrs["http.response.body"].String(), openaitest.DefaultText) {
+		t.Errorf("expected the usage and the response body in the call span")
+	}
+	if len(meter.records[option.MetricOperationDuration]) != 1 || len(meter.records[option.MetricTokenUsage]) != 2 {
+		t.Errorf("unexpected metrics: %v", meter.records)
+	}
+	if strings.Contains(logs.String(), "sk-openaitest") || !strings.Contains(logs.String(), "openai: retrying request") {
+		t.Errorf("unexpected logs:\n%s", logs.String())
+	}
+
+	tracer.spans = nil
+	params.StreamOptions.IncludeUsage = openai.Bool(true)
+	stream := client.Chat.Completions.NewStreaming(context.Background(), params)
+	for stream.Next() {
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatal(err)
+	}
+	stream.Close()
+	call = tracer.spans[0]
+	if !call.ended || call.attrs["gen_ai.response.time_to_first_chunk"].Float64() <= 0 || call.attrs["gen_ai.usage.output_tokens"].Int64() == 0 {
+		t.Errorf("expected the stream to be summarized in the call span: %v", call.attrs)
+	}
+	if len(meter.records[option.MetricTimeToFirstChunk]) != 1 {
+		t.Errorf("expected the time to first chunk to be recorded")
+	}
+}
@@ -0,0 +1,275 @@
+package tokenizer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/tidwall/gjson"
+)
+
+// The tokens added by the chat format, from the accounting published by OpenAI.
+const (
+	tokensPerMessage = 3
+	tokensPerName    = 1
+	tokensPerReply   = 3
+)
+
+// The tokens of an image of unknown size, counted as a 1024x1024 image.
+const unknownImageTokens = 765
+
+// Counter counts the tokens of requests with an encoding, following the
+// accounting published by OpenAI for the messages, tools and images. The counts
+// are estimates: the API may count a few tokens differently, and the audio and
+// files of the messages are not counted.
+type Counter struct {
+	Encoding *Encoding
+	// ImageSize returns the size of an image of a message from its URL, to count
+	// its tokens. The sizes of PNG, JPEG and GIF data URLs are decoded when nil or
+	// when it returns false. The images of unknown size are counted as 1024x1024
+	// images.
+	ImageSize func(url string) (width int, height int, ok bool)
+}
+
+// ChatCompletion returns the number of input tokens of a chat completion: its
+// messages, the priming of the reply and its tools.
+func (c Counter) ChatCompletion(params openai.ChatCompletionNewParams) int {
+	return c.Messages(params.Messages) + c.Tools(params.Tools)
+}
+
+// Messages returns the number of tokens of messages, including the priming of
+// the reply.
+func (c Counter) Messages(messages []openai.ChatCompletionMessageParamUnion) int {
+	tokens := tokensPerReply
+	for _, message := range messages {
+		tokens += c.Message(message)
+	}
+	return tokens
+}
+
+// Message returns the number of tokens of a message.
+func (c Counter) Message(message openai.ChatCompletionMessageParamUnion) int {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return 0
+	}
+	m := gjson.ParseBytes(data)
+
+	tokens := tokensPerMessage + c.Encoding.Count(m.Get("role").String())
+	if name := m.Get("name"); name.Exists() {
+		tokens += tokensPerName + c.Encoding.Count(name.String())
+	}
+	if content := m.Get("content"); content.IsArray() {
+		for _, part := range content.Array() {
+			tokens += c.part(part)
+		}
+	} else {
+		tokens += c.Encoding.Count(content.String())
+	}
+	tokens += c.Encoding.Count(m.Get("refusal").String())
+	for _, call := range m.Get("tool_calls").Array() {
+		function := call.Get("function")
+		if !function.Exists() {
+			function = call.Get("custom")
+		}
+		tokens += c.Encoding.Count(function.Get("name").String())
+		tokens += c.Encoding.Count(function.Get("arguments").String())
+		tokens += c.Encoding.Count(function.Get("input").String())
+	}
+	if call := m.Get("function_call"); call.Exists() {
+		tokens += c.Encoding.Count(call.Get("name").String())
+		tokens += c.Encoding.Count(call.Get("arguments").String())
+	}
+	return tokens
+}
+
+func (c Counter) part(part gjson.Result) int {
+	switch part.Get("type").String() {
+	case "text":
+		return c.Encoding.Count(part.Get("text").String())
+	case "refusal":
+		return c.Encoding.Count(part.Get("refusal").String())
+	case "image_url":
+		url := part.Get("image_url.url").String()
+		detail := part.Get("image_url.detail").String()
+		if detail == "low" {
+			return ImageTokens(0, 0, detail)
+		}
+		width, height, ok := c.imageSize(url)
+		if !ok {
+			return unknownImageTokens
+		}
+		return ImageTokens(width, height, detail)
+	}
+	return 0
+}
+
+func (c Counter) imageSize(url string) (int, int, bool) {
+	if c.ImageSize != nil {
+		if width, height, ok := c.ImageSize(url); ok {
+			return width, height, true
+		}
+	}
+	header, data, ok := strings.Cut(url, ",")
+	if !strings.HasPrefix(header, "data:image/") || !ok {
+		return 0, 0, false
+	}
+	var decoded []byte
+	if strings.HasSuffix(header, ";base64") {
+		var err error
+		if decoded, err = base64.StdEncoding.DecodeString(data); err != nil {
+			return 0, 0, false
+		}
+	} else {
+		decoded = []byte(data)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(decoded))
+	if err != nil {
+		return 0, 0, false
+	}
+	return config.Width, config.Height, true
+}
+
+// ImageTokens returns the number of tokens of an image of a message, with the
+// detail of the image: 85 tokens with a low detail, and otherwise 85 tokens and
+// 170 per tile of 512px, once the image is scaled to fit in 2048x2048 and its
+// shortest side to 768px.
+func ImageTokens(width int, height int, detail string) int {
+	if detail == "low" {
+		return 85
+	}
+	w, h := float64(width), float64(height)
+	if longest := max(w, h); longest > 2048 {
+		w, h = w*2048/longest, h*2048/longest
+	}
+	if shortest := min(w, h); shortest > 768 {
+		w, h = w*768/shortest, h*768/shortest
+	}
+	tiles := ceilDiv(int(w+0.5), 512) * ceilDiv(int(h+0.5), 512)
+	return 85 + 170*tiles
+}
+
+func ceilDiv(a int, b int) int {
+	return (a + b - 1) / b
+}
+
+// Tools returns the number of tokens of the definitions of tools.
+func (c Counter) Tools(tools []openai.ChatCompletionToolUnionParam) int {
+	if len(tools) == 0 {
+		return 0
+	}
+	// The tokens added by the format of the tools, which differs between the
+	// encodings.
+	functionInit, propertyInit, propertyKey, enumInit, enumItem, functionEnd := 7, 3, 3, -3, 3, 12
+	if c.Encoding.Name() == Cl100kBase {
+		functionInit = 10
+	}
+
+	tokens := functionEnd
+	for _, tool := range tools {
+		data, err := json.Marshal(tool)
+		if err != nil {
+			continue
+		}
+		t := gjson.ParseBytes(data)
+		function := t.Get("function")
+		if !function.Exists() {
+			function = t.Get("custom")
+		}
+		tokens += functionInit
+		description := strings.TrimSuffix(function.Get("description").String(), ".")
+		tokens += c.Encoding.Count(function.Get("name").String() + ":" + description)
+
+		properties := function.Get("parameters.properties").Map()
+		if len(properties) == 0 {
+			continue
+		}
+		tokens += propertyInit
+		for name, property := range properties {
+			tokens += propertyKey
+			if enum := property.Get("enum"); enum.Exists() {
+				tokens += enumInit
+				for _, item := range enum.Array() {
+					tokens += enumItem + c.Encoding.Count(item.String())
+				}
+			}
+			description := strings.TrimSuffix(property.Get("description").String(), ".")
+			tokens += c.Encoding.Count(name + ":" + property.Get("type").String() + ":" + description)
+		}
+	}
+	return tokens
+}
+
+// Embedding returns the number of tokens of the input of an embedding request.
+func (c Counter) Embedding(params openai.EmbeddingNewParams) int {
+	input := params.Input
+	tokens := len(input.OfArrayOfTokens)
+	for _, array := range input.OfArrayOfTokenArrays {
+		tokens += len(array)
+	}
+	for _, text := range input.OfArrayOfStrings {
+		tokens += c.Encoding.Count(text)
+	}
+	if input.OfString.Valid() {
+		tokens += c.Encoding.Count(input.OfString.Value)
+	}
+	return tokens
+}
+
+// TruncateMessages returns the most recent messages whose tokens fit in budget,
+// counted with [Counter.Messages]. The system and developer messages are always
+// kept, and the messages keep their order. An assistant message calling tools is
+// kept or dropped with the tool messages answering it.
+func (c Counter) TruncateMessages(messages []openai.ChatCompletionMessageParamUnion, budget int) []openai.ChatCompletionMessageParamUnion {
+	// Group the messages which are kept or dropped together.
+	type group struct {
+		start, end int
+		pinned     bool
+		tokens     int
+	}
+	var groups []group
+	for i, message := range messages {
+		tokens := c.Message(message)
+		switch {
+		case message.OfTool != nil && len(groups) > 0 && !groups[len(groups)-1].pinned:
+			groups[len(groups)-1].end = i + 1
+			groups[len(groups)-1].tokens += tokens
+		default:
+			pinned := message.OfSystem != nil || message.OfDeveloper != nil
+			groups = append(groups, group{start: i, end: i + 1, pinned: pinned, tokens: tokens})
+		}
+	}
+
+	used := tokensPerReply
+	keep := make([]bool, len(groups))
+	for i, g := range groups {
+		if g.pinned {
+			used += g.tokens
+			keep[i] = true
+		}
+	}
+	for i := len(groups) - 1; i >= 0; i-- {
+		if keep[i] {
+			continue
+		}
+		if used+groups[i].tokens > budget {
+			break
+		}
+		used += groups[i].tokens
+		keep[i] = true
+	}
+
+	truncated := make([]openai.ChatCompletionMessageParamUnion, 0, len(messages))
+	for i, g := range groups {
+		if keep[i] {
+			truncated = append(truncated, messages[g.start:g.end]...)
+		}
+	}
+	return truncated
+}
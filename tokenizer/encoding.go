@@ -0,0 +1,222 @@
+// Package tokenizer counts tokens offline, with the byte pair encodings of the
+// OpenAI models, to budget the context window of requests before sending them.
+//
+// The vocabularies are not bundled with the library: load the .tiktoken file of
+// an encoding, such as o200k_base.tiktoken, with [LoadFile] or [Load].
+//
+//	enc, err := tokenizer.LoadFile(tokenizer.O200kBase, "testdata/o200k_base.tiktoken")
+//	counter := tokenizer.Counter{Encoding: enc}
+//	tokens := counter.ChatCompletion(params)
+package tokenizer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The names of the encodings of the OpenAI models.
+const (
+	O200kBase  = "o200k_base"
+	Cl100kBase = "cl100k_base"
+)
+
+// space is the class of the whitespace characters, which \s of RE2 limits to
+// ASCII.
+const space = `\t\n\v\f\r \x{85}\p{Z}`
+
+// patterns split the text into the pieces encoded separately. The `\s+(?!\S)`
+// alternative of the original patterns is not supported by RE2, and is applied
+// by [Encoding.split] instead.
+var patterns = map[string]string{
+	Cl100kBase: strings.Join([]string{
+		`'(?i:[sdmt]|ll|ve|re)`,
+		`[^\r\n\p{L}\p{N}]?\p{L}+`,
+		`\p{N}{1,3}`,
+		` ?[^` + space + `\p{L}\p{N}]+[\r\n]*`,
+		`[` + space + `]*[\r\n]+`,
+		`[` + space + `]+`,
+	}, "|"),
+	O200kBase: strings.Join([]string{
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?`,
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?`,
+		`\p{N}{1,3}`,
+		` ?[^` + space + `\p{L}\p{N}]+[\r\n/]*`,
+		`[` + space + `]*[\r\n]+`,
+		`[` + space + `]+`,
+	}, "|"),
+}
+
+// Encoding is a byte pair encoding, such as [O200kBase]. It is safe for
+// concurrent use.
+type Encoding struct {
+	name    string
+	ranks   map[string]int
+	decoder map[int]string
+	pattern *regexp.Regexp
+}
+
+// LoadFile loads the encoding name from a .tiktoken vocabulary file.
+func LoadFile(name string, path string) (*Encoding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(name, f)
+}
+
+// Load loads the encoding name from a vocabulary in the .tiktoken format: a line
+// per token, with the base64 of its bytes and its rank. The name selects how the
+// text is split before the encoding, and must be [O200kBase] or [Cl100kBase].
+func Load(name string, vocab io.Reader) (*Encoding, error) {
+	pattern, ok := patterns[name]
+	if !ok {
+		return nil, fmt.Errorf("tokenizer: unknown encoding %q", name)
+	}
+	enc := &Encoding{
+		name:    name,
+		ranks:   map[string]int{},
+		decoder: map[int]string{},
+		pattern: regexp.MustCompile(pattern),
+	}
+	scanner := bufio.NewScanner(vocab)
+	for line := 1; scanner.Scan(); line++ {
+		fields := bytes.Fields(scanner.Bytes())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("tokenizer: line %d of the vocabulary: expected a token and a rank", line)
+		}
+		token, err := base64.StdEncoding.DecodeString(string(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("tokenizer: line %d of the vocabulary: %w", line, err)
+		}
+		rank, err := strconv.Atoi(string(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("tokenizer: line %d of the vocabulary: %w", line, err)
+		}
+		enc.ranks[string(token)] = rank
+		enc.decoder[rank] = string(token)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for b := 0; b < 256; b++ {
+		if _, ok := enc.ranks[string([]byte{byte(b)})]; !ok {
+			return nil, fmt.Errorf("tokenizer: the vocabulary has no token for the byte %#x", b)
+		}
+	}
+	return enc, nil
+}
+
+// Name returns the name of the encoding, such as [O200kBase].
+func (e *Encoding) Name() string {
+	return e.name
+}
+
+// Encode returns the tokens of text. Special tokens, such as <|endoftext|>, are
+// encoded as ordinary text.
+func (e *Encoding) Encode(text string) []int {
+	var tokens []int
+	for _, piece := range e.split(text) {
+		tokens = e.encodePiece(piece, tokens)
+	}
+	return tokens
+}
+
+// Count returns the number of tokens of text.
+func (e *Encoding) Count(text string) int {
+	return len(e.Encode(text))
+}
+
+// Decode returns the text of tokens. The tokens missing from the vocabulary are
+// skipped.
+func (e *Encoding) Decode(tokens []int) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString(e.decoder[token])
+	}
+	return b.String()
+}
+
+// split splits text into the pieces matched by the pattern of the encoding. A
+// run of whitespace followed by a non-whitespace character leaves its last
+// character to the next piece, like the `\s+(?!\S)` alternative.
+func (e *Encoding) split(text string) []string {
+	var pieces []string
+	for pos := 0; pos < len(text); {
+		loc := e.pattern.FindStringIndex(text[pos:])
+		if loc == nil {
+			pieces = append(pieces, text[pos:])
+			break
+		}
+		if loc[0] > 0 {
+			pieces = append(pieces, text[pos:pos+loc[0]])
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		if end == start {
+			_, size := utf8.DecodeRuneInString(text[start:])
+			end = start + size
+		}
+		piece := text[start:end]
+		if end < len(text) && utf8.RuneCountInString(piece) > 1 && isSpace(piece) && !strings.HasSuffix(piece, "\n") && !strings.HasSuffix(piece, "\r") {
+			if next, _ := utf8.DecodeRuneInString(text[end:]); !unicode.IsSpace(next) {
+				_, size := utf8.DecodeLastRuneInString(piece)
+				end -= size
+				piece = text[start:end]
+			}
+		}
+		pieces = append(pieces, piece)
+		pos = end
+	}
+	return pieces
+}
+
+func isSpace(s string) bool {
+	for _, r := range s {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// encodePiece merges the bytes of a piece by the rank of their pairs, lowest
+// first, and appends the resulting tokens.
+func (e *Encoding) encodePiece(piece string, tokens []int) []int {
+	if rank, ok := e.ranks[piece]; ok {
+		return append(tokens, rank)
+	}
+	// The boundaries of the parts of the piece, merged two by two.
+	parts := make([]int, len(piece)+1)
+	for i := range parts {
+		parts[i] = i
+	}
+	for len(parts) > 2 {
+		minRank, minIndex := math.MaxInt, -1
+		for i := 0; i+2 < len(parts); i++ {
+			if rank, ok := e.ranks[piece[parts[i]:parts[i+2]]]; ok && rank < minRank {
+				minRank, minIndex = rank, i
+			}
+		}
+		if minIndex < 0 {
+			break
+		}
+		parts = append(parts[:minIndex+1], parts[minIndex+2:]...)
+	}
+	for i := 0; i+1 < len(parts); i++ {
+		tokens = append(tokens, e.ranks[piece[parts[i]:parts[i+1]]])
+	}
+	return tokens
+}
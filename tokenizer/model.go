@@ -0,0 +1,86 @@
+package tokenizer
+
+import (
+	"strings"
+
+	"github.com/Nordlys-Labs/openai-go/v3/shared"
+)
+
+// Limits are the token limits of a model.
+type Limits struct {
+	// The maximum number of tokens of the input and output of a request.
+	ContextWindow int64
+	// The maximum number of tokens of the output of a request.
+	MaxOutputTokens int64
+}
+
+// InputBudget returns the number of tokens left for the input once maxOutput
+// tokens are reserved for the output, or the maximum output of the model when
+// maxOutput is 0.
+func (l Limits) InputBudget(maxOutput int64) int64 {
+	if maxOutput <= 0 {
+		maxOutput = l.MaxOutputTokens
+	}
+	return max(l.ContextWindow-maxOutput, 0)
+}
+
+// models maps the model prefixes to their encoding and limits. The longest
+// matching prefix applies, so dated snapshots share the entry of their model.
+var models = map[string]struct {
+	encoding string
+	limits   Limits
+}{
+	shared.ChatModelGPT5_2:             {O200kBase, Limits{400_000, 128_000}},
+	shared.ChatModelGPT5_2ChatLatest:   {O200kBase, Limits{128_000, 16_384}},
+	shared.ChatModelGPT5_1:             {O200kBase, Limits{400_000, 128_000}},
+	shared.ChatModelGPT5_1ChatLatest:   {O200kBase, Limits{128_000, 16_384}},
+	shared.ChatModelGPT5:               {O200kBase, Limits{400_000, 128_000}},
+	shared.ChatModelGPT5ChatLatest:     {O200kBase, Limits{128_000, 16_384}},
+	shared.ChatModelGPT4_1:             {O200kBase, Limits{1_047_576, 32_768}},
+	shared.ChatModelO4Mini:             {O200kBase, Limits{200_000, 100_000}},
+	shared.ChatModelO3:                 {O200kBase, Limits{200_000, 100_000}},
+	shared.ChatModelO1:                 {O200kBase, Limits{200_000, 100_000}},
+	shared.ChatModelO1Preview:          {O200kBase, Limits{128_000, 32_768}},
+	shared.ChatModelO1Mini:             {O200kBase, Limits{128_000, 65_536}},
+	shared.ChatModelGPT4o:              {O200kBase, Limits{128_000, 16_384}},
+	shared.ChatModelGPT4o2024_05_13:    {O200kBase, Limits{128_000, 4_096}},
+	shared.ChatModelChatgpt4oLatest:    {O200kBase, Limits{128_000, 16_384}},
+	shared.ChatModelCodexMiniLatest:    {O200kBase, Limits{200_000, 100_000}},
+	shared.ChatModelGPT4Turbo:          {Cl100kBase, Limits{128_000, 4_096}},
+	shared.ChatModelGPT4_0125Preview:   {Cl100kBase, Limits{128_000, 4_096}},
+	shared.ChatModelGPT4_1106Preview:   {Cl100kBase, Limits{128_000, 4_096}},
+	shared.ChatModelGPT4VisionPreview:  {Cl100kBase, Limits{128_000, 4_096}},
+	shared.ChatModelGPT4:               {Cl100kBase, Limits{8_192, 8_192}},
+	shared.ChatModelGPT4_32k:           {Cl100kBase, Limits{32_768, 32_768}},
+	shared.ChatModelGPT3_5Turbo:        {Cl100kBase, Limits{16_385, 4_096}},
+	shared.ChatModelGPT3_5Turbo0301:    {Cl100kBase, Limits{4_096, 4_096}},
+	shared.ChatModelGPT3_5Turbo0613:    {Cl100kBase, Limits{4_096, 4_096}},
+	shared.ChatModelGPT3_5Turbo16k0613: {Cl100kBase, Limits{16_385, 4_096}},
+	"text-embedding-":                  {Cl100kBase, Limits{8_191, 0}},
+}
+
+// lookup returns the entry of the longest prefix of model.
+func lookup(model string) (encoding string, limits Limits, ok bool) {
+	best := -1
+	for prefix, entry := range models {
+		if len(prefix) > best && strings.HasPrefix(model, prefix) {
+			best, encoding, limits, ok = len(prefix), entry.encoding, entry.limits, true
+		}
+	}
+	return encoding, limits, ok
+}
+
+// EncodingForModel returns the name of the encoding of a model, such as
+// [O200kBase] for [shared.ChatModelGPT4o], or false for an unknown model.
+func EncodingForModel(model string) (string, bool) {
+	encoding, _, ok := lookup(model)
+	return encoding, ok
+}
+
+// LimitsForModel returns the token limits of a model, such as
+// [shared.ChatModelGPT4o], or false for an unknown model. The limits of the
+// embedding models only bound their input.
+func LimitsForModel(model string) (Limits, bool) {
+	_, limits, ok := lookup(model)
+	return limits, ok
+}
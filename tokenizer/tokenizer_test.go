@@ -0,0 +1,153 @@
+package tokenizer_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/shared"
+	"github.com/Nordlys-Labs/openai-go/v3/tokenizer"
+)
+
+// testVocab is a vocabulary of every byte and a few merges, in the .tiktoken
+// format.
+func testVocab(merges ...string) string {
+	var b strings.Builder
+	for i := 0; i < 256; i++ {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(i)}), i)
+	}
+	for i, merge := range merges {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(merge)), 256+i)
+	}
+	return b.String()
+}
+
+func load(t *testing.T, name string) *tokenizer.Encoding {
+	t.Helper()
+	enc, err := tokenizer.Load(name, strings.NewReader(testVocab(" b", "'t", "do", "don", "don't")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enc
+}
+
+func TestEncoding(t *testing.T) {
+	o200k, cl100k := load(t, tokenizer.O200kBase), load(t, tokenizer.Cl100kBase)
+
+	// A run of spaces leaves its last space to the next word.
+	if got := cl100k.Encode("a  b"); !slices.Equal(got, []int{'a', ' ', 256}) {
+		t.Errorf("unexpected tokens %v", got)
+	}
+	// The contractions are split from their word by cl100k_base only.
+	if got := cl100k.Encode("don't"); !slices.Equal(got, []int{259, 257}) {
+		t.Errorf("unexpected cl100k_base tokens %v", got)
+	}
+	if got := o200k.Encode("don't"); !slices.Equal(got, []int{260}) {
+		t.Errorf("unexpected o200k_base tokens %v", got)
+	}
+
+	text := "Héllo wörld, 1234567!\n\n  \tdon't  stop "
+	for _, enc := range []*tokenizer.Encoding{o200k, cl100k} {
+		if got := enc.Decode(enc.Encode(text)); got != text {
+			t.Errorf("%s: expected the text to round trip, got %q", enc.Name(), got)
+		}
+	}
+
+	if _, err := tokenizer.Load(tokenizer.O200kBase, strings.NewReader("YQ== 0\n")); err == nil {
+		t.Error("expected a vocabulary without every byte to be rejected")
+	}
+}
+
+func TestCounter(t *testing.T) {
+	counter := tokenizer.Counter{Encoding: load(t, tokenizer.O200kBase)}
+
+	// 3 tokens per message, "user" and "hi", and 3 tokens priming the reply.
+	if got := counter.Messages([]openai.ChatCompletionMessageParamUnion{openai.UserMessage("hi")}); got != 3+4+2+3 {
+		t.Errorf("unexpected message tokens %d", got)
+	}
+
+	tools := []openai.ChatCompletionToolUnionParam{openai.ChatCompletionFunctionTool(shared.FunctionDefinitionParam{
+		Name:        "get_weather",
+		Description: openai.String("Get weather."),
+		Parameters: shared.FunctionParameters{
+			"type":       "object",
+			"properties": map[string]any{"city": map[string]any{"type": "string", "description": "City."}},
+		},
+	})}
+	// The format of the tools, "get_weather:Get weather" and "city:string:City".
+	if got := counter.Tools(tools); got != 12+7+23+3+3+16 {
+		t.Errorf("unexpected tool tokens %d", got)
+	}
+
+	for _, test := range []struct {
+		width, height int
+		detail        string
+		want          int
+	}{
+		{4096, 4096, "low", 85},
+		{1024, 1024, "high", 765},
+		{2048, 4096, "auto", 1105},
+		{512, 512, "high", 255},
+	} {
+		if got := tokenizer.ImageTokens(test.width, test.height, test.detail); got != test.want {
+			t.Errorf("%dx%d %s: expected %d tokens, got %d", test.width, test.height, test.detail, test.want, got)
+		}
+	}
+	image := openai.UserMessage([]openai.ChatCompletionContentPartUnionParam{
+		openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{URL: "https://example.com/cat.png"}),
+	})
+	if got := counter.Message(image); got != 3+4+765 {
+		t.Errorf("expected an image of unknown size to count as 1024x1024, got %d", got)
+	}
+}
+
+func TestTruncateMessages(t *testing.T) {
+	counter := tokenizer.Counter{Encoding: load(t, tokenizer.O200kBase)}
+	call := openai.ChatCompletionAssistantMessageParam{
+		ToolCalls: []openai.ChatCompletionMessageToolCallUnionParam{{
+			OfFunction: &openai.ChatCompletionMessageFunctionToolCallParam{
+				ID:       "call_1",
+				Function: openai.ChatCompletionMessageFunctionToolCallFunctionParam{Name: "get_weather", Arguments: "{}"},
+			},
+		}},
+	}
+	messages := []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage("Be brief."),
+		openai.UserMessage(strings.Repeat("old ", 50)),
+		{OfAssistant: &call},
+		openai.ToolMessage("sunny", "call_1"),
+		openai.UserMessage("And tomorrow?"),
+	}
+
+	budget := counter.Messages([]openai.ChatCompletionMessageParamUnion{messages[0], messages[4]}) + counter.Message(messages[3])
+	truncated := counter.TruncateMessages(messages, budget)
+	if len(truncated) != 2 || truncated[0].OfSystem == nil || truncated[1].OfUser == nil {
+		t.Errorf("expected the system message and the last message, without a tool message alone, got %d messages", len(truncated))
+	}
+	if got := counter.TruncateMessages(messages, 1_000_000); len(got) != len(messages) {
+		t.Errorf("expected every message to fit, got %d", len(got))
+	}
+}
+
+func TestLimitsForModel(t *testing.T) {
+	for model, want := range map[string]string{
+		shared.ChatModelGPT4o2024_08_06: tokenizer.O200kBase,
+		shared.ChatModelGPT4_1Mini:      tokenizer.O200kBase,
+		shared.ChatModelGPT4_0613:       tokenizer.Cl100kBase,
+		"text-embedding-3-small":        tokenizer.Cl100kBase,
+	} {
+		if got, ok := tokenizer.EncodingForModel(model); !ok || got != want {
+			t.Errorf("%s: expected %s, got %s", model, want, got)
+		}
+	}
+	limits, ok := tokenizer.LimitsForModel(shared.ChatModelGPT4o2024_08_06)
+	if !ok || limits.ContextWindow != 128_000 || limits.InputBudget(0) != 128_000-16_384 {
+		t.Errorf("unexpected limits %+v", limits)
+	}
+	if _, ok := tokenizer.LimitsForModel("unknown-model"); ok {
+		t.Error("expected an unknown model to have no limits")
+	}
+}
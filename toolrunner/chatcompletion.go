@@ -0,0 +1,201 @@
+package toolrunner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ssestream"
+)
+
+// ChatCompletionResult is the outcome of a chat completion run.
+type ChatCompletionResult struct {
+	// The last chat completion, which holds the final answer of the model.
+	Completion *openai.ChatCompletion
+	// The conversation, starting with the messages of the params and followed
+	// by the assistant messages and tool results of every iteration, including
+	// the final answer.
+	Messages []openai.ChatCompletionMessageParamUnion
+	// The number of requests made to the model.
+	Iterations int
+}
+
+// RunChatCompletion creates chat completions until the model answers without
+// calling a tool. The tools of the registry are appended to params.Tools, and
+// the tool calls of every iteration are executed concurrently.
+//
+// Only the first choice of every chat completion is used.
+func (r *Runner) RunChatCompletion(ctx context.Context, svc *openai.ChatCompletionService, params openai.ChatCompletionNewParams, opts ...option.RequestOption) (*ChatCompletionResult, error) {
+	if err := r.check(); err != nil {
+		return nil, err
+	}
+	params.Tools = append(slices.Clip(params.Tools), r.Registry.ChatCompletionTools()...)
+	result := &ChatCompletionResult{Messages: slices.Clone(params.Messages)}
+
+	for result.Iterations < r.maxIterations() {
+		params.Messages = result.Messages
+		completion, err := svc.New(ctx, params, opts...)
+		if err != nil {
+			return result, err
+		}
+		result.Completion = completion
+		result.Iterations++
+
+		done, err := r.continueChatCompletion(r.newBatch(ctx), result)
+		if done || err != nil {
+			return result, err
+		}
+	}
+	return result, ErrMaxIterations
+}
+
+// continueChatCompletion appends the answer of result.Completion to the
+// conversation and runs its tool calls, reporting whether the run is done.
+func (r *Runner) continueChatCompletion(b *batch, result *ChatCompletionResult) (done bool, err error) {
+	if len(result.Completion.Choices) == 0 {
+		return true, fmt.Errorf("toolrunner: chat completion %q has no choices", result.Completion.ID)
+	}
+	message := result.Completion.Choices[0].Message
+	result.Messages = append(result.Messages, message.ToParam())
+	if len(message.ToolCalls) == 0 {
+		return true, nil
+	}
+
+	for _, call := range message.ToolCalls {
+		b.start(chatCompletionToolCall(call))
+	}
+	results, err := b.wait()
+	if err != nil {
+		return true, err
+	}
+	for _, res := range results {
+		result.Messages = append(result.Messages, openai.ToolMessage(res.Output, res.ID))
+	}
+	return false, nil
+}
+
+func chatCompletionToolCall(call openai.ChatCompletionMessageToolCallUnion) ToolCall {
+	if call.Type == "custom" {
+		return ToolCall{ID: call.ID, Name: call.Custom.Name, Arguments: call.Custom.Input}
+	}
+	return ToolCall{ID: call.ID, Name: call.Function.Name, Arguments: call.Function.Arguments}
+}
+
+// RunChatCompletionStreaming is the streaming variant of [Runner.RunChatCompletion].
+// Tool calls start as soon as they have been streamed completely, as reported by
+// [openai.ChatCompletionAccumulator.JustFinishedToolCall], while the rest of the
+// chat completion is still streaming.
+//
+//	stream := runner.RunChatCompletionStreaming(ctx, &client.Chat.Completions, params)
+//	defer stream.Close()
+//	for stream.Next() {
+//		chunk := stream.Current()
+//		if len(chunk.Choices) > 0 {
+//			fmt.Print(chunk.Choices[0].Delta.Content)
+//		}
+//	}
+//	if err := stream.Err(); err != nil {
+//		...
+//	}
+//	result := stream.Result()
+func (r *Runner) RunChatCompletionStreaming(ctx context.Context, svc *openai.ChatCompletionService, params openai.ChatCompletionNewParams, opts ...option.RequestOption) *ChatCompletionRunStream {
+	s := &ChatCompletionRunStream{runner: r, ctx: ctx, svc: svc, opts: opts}
+	if s.err = r.check(); s.err != nil {
+		return s
+	}
+	params.Tools = append(slices.Clip(params.Tools), r.Registry.ChatCompletionTools()...)
+	s.params = params
+	s.result.Messages = slices.Clone(params.Messages)
+	return s
+}
+
+// ChatCompletionRunStream streams the chunks of every chat completion of a run,
+// executing tool calls in between.
+type ChatCompletionRunStream struct {
+	runner *Runner
+	ctx    context.Context
+	svc    *openai.ChatCompletionService
+	params openai.ChatCompletionNewParams
+	opts   []option.RequestOption
+
+	stream *ssestream.Stream[openai.ChatCompletionChunk]
+	acc    openai.ChatCompletionAccumulator
+	batch  *batch
+	cur    openai.ChatCompletionChunk
+	result ChatCompletionResult
+	done   bool
+	err    error
+}
+
+// Next advances to the next chunk, starting the next chat completion once the
+// tool calls of the previous one are done. It returns false once the model
+// answered without calling a tool or an error occurred.
+func (s *ChatCompletionRunStream) Next() bool {
+	for !s.done && s.err == nil {
+		if s.stream == nil {
+			if s.result.Iterations >= s.runner.maxIterations() {
+				s.err = ErrMaxIterations
+				return false
+			}
+			s.params.Messages = s.result.Messages
+			s.stream = s.svc.NewStreaming(s.ctx, s.params, s.opts...)
+			s.acc = openai.ChatCompletionAccumulator{}
+			s.batch = s.runner.newBatch(s.ctx)
+			s.result.Iterations++
+		}
+
+		if s.stream.Next() {
+			s.cur = s.stream.Current()
+			if !s.acc.AddChunk(s.cur) {
+				s.err = fmt.Errorf("toolrunner: chunk %q does not belong to chat completion %q", s.cur.ID, s.acc.ID)
+				return false
+			}
+			// The tool calls of the first choice are run, like the ones of a
+			// chat completion which is not streamed.
+			for _, call := range s.acc.JustFinishedToolCalls() {
+				if call.ChoiceIndex == 0 {
+					s.batch.start(ToolCall{ID: call.ID, Name: call.Name, Arguments: call.Arguments})
+				}
+			}
+			return true
+		}
+
+		if s.err = s.stream.Err(); s.err != nil {
+			return false
+		}
+		s.stream.Close()
+		s.stream = nil
+
+		completion := s.acc.ChatCompletion
+		s.result.Completion = &completion
+		s.done, s.err = s.runner.continueChatCompletion(s.batch, &s.result)
+	}
+	return false
+}
+
+// Current returns the last chunk read by Next.
+func (s *ChatCompletionRunStream) Current() openai.ChatCompletionChunk {
+	return s.cur
+}
+
+// Err returns the error which stopped the stream, if any.
+func (s *ChatCompletionRunStream) Err() error {
+	return s.err
+}
+
+// Result returns the outcome of the run so far. Once Next returned false, it
+// holds the final chat completion accumulated from its chunks.
+func (s *ChatCompletionRunStream) Result() *ChatCompletionResult {
+	return &s.result
+}
+
+// Close closes the chat completion stream which is currently open.
+func (s *ChatCompletionRunStream) Close() error {
+	s.done = true
+	if s.stream == nil {
+		return nil
+	}
+	return s.stream.Close()
+}
@@ -0,0 +1,196 @@
+package toolrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/ssestream"
+	"github.com/Nordlys-Labs/openai-go/v3/responses"
+)
+
+// ResponseResult is the outcome of a response run.
+type ResponseResult struct {
+	// The last response, which holds the final answer of the model.
+	Response *responses.Response
+	// The number of requests made to the model.
+	Iterations int
+}
+
+// RunResponse creates responses until the model answers without calling a
+// tool. The tools of the registry are appended to params.Tools, and the tool
+// calls of every iteration are executed concurrently.
+//
+// The tool outputs are sent in a follow-up response which continues the
+// conversation of params, or the previous response when params has no
+// conversation. Responses must therefore be stored.
+func (r *Runner) RunResponse(ctx context.Context, svc *responses.ResponseService, params responses.ResponseNewParams, opts ...option.RequestOption) (*ResponseResult, error) {
+	params, err := r.responseParams(params)
+	if err != nil {
+		return nil, err
+	}
+	result := &ResponseResult{}
+
+	for result.Iterations < r.maxIterations() {
+		response, err := svc.New(ctx, params, opts...)
+		if err != nil {
+			return result, err
+		}
+		result.Response = response
+		result.Iterations++
+
+		done, err := r.continueResponse(r.newBatch(ctx), response, &params)
+		if done || err != nil {
+			return result, err
+		}
+	}
+	return result, ErrMaxIterations
+}
+
+func (r *Runner) responseParams(params responses.ResponseNewParams) (responses.ResponseNewParams, error) {
+	if err := r.check(); err != nil {
+		return params, err
+	}
+	if params.Store.Valid() && !params.Store.Value {
+		return params, errors.New("toolrunner: responses must be stored to send tool outputs, but store is false")
+	}
+	params.Tools = append(slices.Clip(params.Tools), r.Registry.ResponseTools()...)
+	return params, nil
+}
+
+// continueResponse runs the tool calls of response and prepares params for the
+// follow-up response, reporting whether the run is done.
+func (r *Runner) continueResponse(b *batch, response *responses.Response, params *responses.ResponseNewParams) (done bool, err error) {
+	for _, item := range response.Output {
+		if item.Type == "function_call" {
+			b.start(ToolCall{ID: item.CallID, Name: item.Name, Arguments: item.Arguments})
+		}
+	}
+	if len(b.results) == 0 {
+		return true, nil
+	}
+	results, err := b.wait()
+	if err != nil {
+		return true, err
+	}
+
+	input := make([]responses.ResponseInputItemUnionParam, len(results))
+	for i, res := range results {
+		input[i] = responses.ResponseInputItemParamOfFunctionCallOutput(res.ID, res.Output)
+	}
+	params.Input = responses.ResponseNewParamsInputUnion{OfInputItemList: input}
+	if param.IsOmitted(params.Conversation) {
+		params.PreviousResponseID = param.NewOpt(response.ID)
+	}
+	return false, nil
+}
+
+// RunResponseStreaming is the streaming variant of [Runner.RunResponse]. Tool
+// calls start as soon as their arguments are done, as reported by
+// [responses.ResponseAccumulator.JustFinishedToolCall], while the rest of the
+// response is still streaming.
+//
+//	stream := runner.RunResponseStreaming(ctx, &client.Responses, params)
+//	defer stream.Close()
+//	for stream.Next() {
+//		event := stream.Current()
+//		if event.Type == "response.output_text.delta" {
+//			fmt.Print(event.Delta)
+//		}
+//	}
+//	if err := stream.Err(); err != nil {
+//		...
+//	}
+//	result := stream.Result()
+func (r *Runner) RunResponseStreaming(ctx context.Context, svc *responses.ResponseService, params responses.ResponseNewParams, opts ...option.RequestOption) *ResponseRunStream {
+	s := &ResponseRunStream{runner: r, ctx: ctx, svc: svc, opts: opts}
+	s.params, s.err = r.responseParams(params)
+	return s
+}
+
+// ResponseRunStream streams the events of every response of a run, executing
+// tool calls in between.
+type ResponseRunStream struct {
+	runner *Runner
+	ctx    context.Context
+	svc    *responses.ResponseService
+	params responses.ResponseNewParams
+	opts   []option.RequestOption
+
+	stream *ssestream.Stream[responses.ResponseStreamEventUnion]
+	acc    *responses.ResponseAccumulator
+	batch  *batch
+	cur    responses.ResponseStreamEventUnion
+	result ResponseResult
+	done   bool
+	err    error
+}
+
+// Next advances to the next event, starting the next response once the tool
+// calls of the previous one are done. It returns false once the model answered
+// without calling a tool or an error occurred.
+func (s *ResponseRunStream) Next() bool {
+	for !s.done && s.err == nil {
+		if s.stream == nil {
+			if s.result.Iterations >= s.runner.maxIterations() {
+				s.err = ErrMaxIterations
+				return false
+			}
+			s.stream = s.svc.NewStreaming(s.ctx, s.params, s.opts...)
+			s.acc = responses.NewResponseAccumulator()
+			s.batch = s.runner.newBatch(s.ctx)
+			s.result.Iterations++
+		}
+
+		if s.stream.Next() {
+			s.cur = s.stream.Current()
+			if !s.acc.AddEvent(s.cur) {
+				s.err = fmt.Errorf("toolrunner: could not accumulate %s event", s.cur.Type)
+				return false
+			}
+			if call, ok := s.acc.JustFinishedToolCall(); ok {
+				s.batch.start(ToolCall{ID: call.CallID, Name: call.Name, Arguments: call.Arguments})
+			}
+			return true
+		}
+
+		if s.err = s.stream.Err(); s.err != nil {
+			return false
+		}
+		s.stream.Close()
+		s.stream = nil
+
+		response := s.acc.Response
+		s.result.Response = &response
+		s.done, s.err = s.runner.continueResponse(s.batch, &response, &s.params)
+	}
+	return false
+}
+
+// Current returns the last event read by Next.
+func (s *ResponseRunStream) Current() responses.ResponseStreamEventUnion {
+	return s.cur
+}
+
+// Err returns the error which stopped the stream, if any.
+func (s *ResponseRunStream) Err() error {
+	return s.err
+}
+
+// Result returns the outcome of the run so far. Once Next returned false, it
+// holds the final response.
+func (s *ResponseRunStream) Result() *ResponseResult {
+	return &s.result
+}
+
+// Close closes the response stream which is currently open.
+func (s *ResponseRunStream) Close() error {
+	s.done = true
+	if s.stream == nil {
+		return nil
+	}
+	return s.stream.Close()
+}
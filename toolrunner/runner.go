@@ -0,0 +1,142 @@
+package toolrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMaxIterations is the number of requests a [Runner] makes when
+// MaxIterations is not set.
+const DefaultMaxIterations = 10
+
+// ErrMaxIterations is returned when the model still calls tools after the
+// maximum number of iterations. The result of the last iteration is returned
+// alongside it.
+var ErrMaxIterations = errors.New("toolrunner: reached the maximum number of iterations")
+
+// Runner drives the tool calling loop with the tools of its registry.
+//
+// A Runner can be used concurrently once configured.
+type Runner struct {
+	Registry *Registry
+	// The maximum number of requests made to the model within a single run.
+	// Defaults to [DefaultMaxIterations].
+	MaxIterations int
+	// The maximum number of tool calls executed at the same time. Zero means
+	// that all the tool calls of an iteration run concurrently.
+	MaxConcurrency int
+	// The default timeout of a single tool call. Zero means no timeout.
+	ToolTimeout time.Duration
+	// OnToolResult, if set, is called with the result of every tool call, in
+	// the order the model requested them.
+	OnToolResult func(ToolResult)
+}
+
+func (r *Runner) maxIterations() int {
+	if r.MaxIterations > 0 {
+		return r.MaxIterations
+	}
+	return DefaultMaxIterations
+}
+
+func (r *Runner) check() error {
+	if r.Registry == nil {
+		return errors.New("toolrunner: the runner has no registry")
+	}
+	return nil
+}
+
+// call executes a single tool call with its timeout, turning panics into
+// errors.
+func (r *Runner) call(ctx context.Context, call ToolCall) (output string, err error) {
+	tool, ok := r.Registry.Lookup(call.Name)
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownTool, call.Name)
+	}
+
+	timeout := tool.Timeout
+	if timeout == 0 {
+		timeout = r.ToolTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("toolrunner: tool %q panicked: %v", call.Name, v)
+		}
+	}()
+	return tool.call(ctx, call.Arguments)
+}
+
+// batch executes the tool calls of one iteration. Calls can be started while
+// the response is still streaming.
+type batch struct {
+	runner  *Runner
+	ctx     context.Context
+	sem     chan struct{}
+	wg      sync.WaitGroup
+	started map[string]bool
+	results []*ToolResult
+}
+
+func (r *Runner) newBatch(ctx context.Context) *batch {
+	b := &batch{runner: r, ctx: ctx, started: map[string]bool{}}
+	if r.MaxConcurrency > 0 {
+		b.sem = make(chan struct{}, r.MaxConcurrency)
+	}
+	return b
+}
+
+// start executes the call in the background, unless a call with the same ID
+// was already started.
+func (b *batch) start(call ToolCall) {
+	if b.started[call.ID] {
+		return
+	}
+	b.started[call.ID] = true
+	result := &ToolResult{ToolCall: call}
+	b.results = append(b.results, result)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		if b.sem != nil {
+			select {
+			case b.sem <- struct{}{}:
+				defer func() { <-b.sem }()
+			case <-b.ctx.Done():
+				result.Err = b.ctx.Err()
+				return
+			}
+		}
+		result.Output, result.Err = b.runner.call(b.ctx, call)
+	}()
+}
+
+// wait blocks until all the started calls are done and returns their results
+// in the order they were started. It fails only when ctx is done, in which
+// case the results must not be sent to the model.
+func (b *batch) wait() ([]ToolResult, error) {
+	b.wg.Wait()
+	if err := b.ctx.Err(); err != nil {
+		return nil, err
+	}
+	results := make([]ToolResult, len(b.results))
+	for i, result := range b.results {
+		if result.Err != nil {
+			result.Output = fmt.Sprintf("Error: %s", result.Err)
+		}
+		results[i] = *result
+		if b.runner.OnToolResult != nil {
+			b.runner.OnToolResult(*result)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,179 @@
+// Package toolrunner drives the function calling loop of the Chat Completions
+// and Responses APIs: it sends a request, executes the function tools that the
+// model calls, sends their results back and repeats until the model answers
+// without calling a tool.
+//
+//	type WeatherArgs struct {
+//		Location string `json:"location" jsonschema_description:"The city and country"`
+//	}
+//
+//	weather := toolrunner.NewTool("get_weather", "Get the current weather",
+//		func(ctx context.Context, args WeatherArgs) (string, error) {
+//			return "Sunny, 25°C", nil
+//		},
+//	)
+//	registry, err := toolrunner.NewRegistry(weather)
+//	if err != nil {
+//		...
+//	}
+//	runner := &toolrunner.Runner{Registry: registry, MaxConcurrency: 4}
+//	result, err := runner.RunChatCompletion(ctx, &client.Chat.Completions, params)
+package toolrunner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/jsonschema"
+	"github.com/Nordlys-Labs/openai-go/v3/packages/param"
+	"github.com/Nordlys-Labs/openai-go/v3/responses"
+	"github.com/Nordlys-Labs/openai-go/v3/shared"
+)
+
+// Tool is a function tool which can be called by the model. Create one with
+// [NewTool].
+type Tool struct {
+	Name        string
+	Description string
+	// The strict JSON schema of the arguments, derived from the argument type
+	// by [NewTool].
+	Parameters map[string]any
+	// Timeout bounds a single call of the tool, overriding
+	// [Runner.ToolTimeout] when non-zero.
+	Timeout time.Duration
+
+	call func(ctx context.Context, arguments string) (string, error)
+	err  error
+}
+
+// NewTool creates a tool whose arguments are decoded into Args. The schema of
+// the arguments is derived from Args with [jsonschema.For], so Args must be a
+// struct.
+//
+// A string result is sent to the model as is, any other result is encoded as
+// JSON. Errors returned by the handler are reported to the model, so that it
+// can recover from them.
+func NewTool[Args any, Result any](name string, description string, handler func(ctx context.Context, args Args) (Result, error)) Tool {
+	tool := Tool{Name: name, Description: description}
+	tool.Parameters, tool.err = jsonschema.For[Args]()
+	tool.call = func(ctx context.Context, arguments string) (string, error) {
+		var args Args
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("invalid arguments: %w", err)
+		}
+		result, err := handler(ctx, args)
+		if err != nil {
+			return "", err
+		}
+		if s, ok := any(result).(string); ok {
+			return s, nil
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return "", fmt.Errorf("could not encode the result of type %s: %w", reflect.TypeOf(result), err)
+		}
+		return string(data), nil
+	}
+	return tool
+}
+
+// ToolCall is a call of a function tool requested by the model.
+type ToolCall struct {
+	// The ID of the tool call, which is the call ID for the Responses API.
+	ID        string
+	Name      string
+	Arguments string
+}
+
+// ToolResult is the outcome of a [ToolCall].
+type ToolResult struct {
+	ToolCall
+	// The output sent back to the model. When the call failed, it describes
+	// the error.
+	Output string
+	// The error returned by the tool, if any.
+	Err error
+}
+
+// ErrUnknownTool is reported in [ToolResult.Err] when the model calls a tool
+// which is not registered.
+var ErrUnknownTool = errors.New("toolrunner: unknown tool")
+
+// Registry holds the tools available to a [Runner].
+type Registry struct {
+	tools map[string]Tool
+	names []string
+}
+
+// NewRegistry creates a registry holding the given tools.
+func NewRegistry(tools ...Tool) (*Registry, error) {
+	r := &Registry{tools: map[string]Tool{}}
+	for _, tool := range tools {
+		if err := r.Register(tool); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds a tool to the registry. It fails when the schema of the tool
+// could not be derived or a tool with the same name is already registered.
+func (r *Registry) Register(tool Tool) error {
+	if tool.err != nil {
+		return fmt.Errorf("toolrunner: tool %q: %w", tool.Name, tool.err)
+	}
+	if tool.call == nil {
+		return fmt.Errorf("toolrunner: tool %q must be created with NewTool", tool.Name)
+	}
+	if _, ok := r.tools[tool.Name]; ok {
+		return fmt.Errorf("toolrunner: tool %q is already registered", tool.Name)
+	}
+	r.tools[tool.Name] = tool
+	r.names = append(r.names, tool.Name)
+	return nil
+}
+
+// Lookup returns the tool with the given name.
+func (r *Registry) Lookup(name string) (Tool, bool) {
+	tool, ok := r.tools[name]
+	return tool, ok
+}
+
+// ChatCompletionTools returns the definitions of the registered tools, for use
+// with [openai.ChatCompletionNewParams].
+func (r *Registry) ChatCompletionTools() []openai.ChatCompletionToolUnionParam {
+	tools := make([]openai.ChatCompletionToolUnionParam, 0, len(r.names))
+	for _, name := range r.names {
+		tool := r.tools[name]
+		function := shared.FunctionDefinitionParam{
+			Name:       tool.Name,
+			Parameters: tool.Parameters,
+			Strict:     openai.Bool(true),
+		}
+		if tool.Description != "" {
+			function.Description = openai.String(tool.Description)
+		}
+		tools = append(tools, openai.ChatCompletionFunctionTool(function))
+	}
+	return tools
+}
+
+// ResponseTools returns the definitions of the registered tools, for use with
+// [responses.ResponseNewParams].
+func (r *Registry) ResponseTools() []responses.ToolUnionParam {
+	tools := make([]responses.ToolUnionParam, 0, len(r.names))
+	for _, name := range r.names {
+		tool := r.tools[name]
+		function := responses.ToolParamOfFunction(tool.Name, tool.Parameters, true)
+		if tool.Description != "" {
+			function.OfFunction.Description = param.NewOpt(tool.Description)
+		}
+		tools = append(tools, function)
+	}
+	return tools
+}
@@ -0,0 +1,322 @@
+package toolrunner_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/responses"
+	"github.com/Nordlys-Labs/openai-go/v3/toolrunner"
+	"github.com/tidwall/gjson"
+)
+
+type weatherArgs struct {
+	Location string `json:"location"`
+}
+
+type weather struct {
+	Forecast string `json:"forecast"`
+}
+
+// newServer answers the requests in order with the given bodies, and records
+// the request bodies.
+func newServer(t *testing.T, bodies ...string) (*httptest.Server, *[]gjson.Result) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		requests []gjson.Result
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		i := len(requests)
+		requests = append(requests, gjson.ParseBytes(data))
+		mu.Unlock()
+		if i >= len(bodies) {
+			http.Error(w, `{"error":{"message":"unexpected request"}}`, http.StatusBadRequest)
+			return
+		}
+		if strings.HasPrefix(bodies[i], "data:") || strings.HasPrefix(bodies[i], "event:") {
+			w.Header().Set("Content-Type", "text/event-stream")
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.Write([]byte(bodies[i]))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func chatCompletion(message string, finishReason string) string {
+	return fmt.Sprintf(`{"id":"chatcmpl_1","object":"chat.completion","created":1,"model":"gpt-4o","choices":[{"index":0,"finish_reason":%q,"message":{"role":"assistant",%s}}]}`, finishReason, message)
+}
+
+func toolCall(id string, name string, arguments string) string {
+	return fmt.Sprintf(`{"id":%q,"type":"function","function":{"name":%q,"arguments":%q}}`, id, name, arguments)
+}
+
+func newRegistry(t *testing.T, tools ...toolrunner.Tool) *toolrunner.Registry {
+	t.Helper()
+	registry, err := toolrunner.NewRegistry(tools...)
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	return registry
+}
+
+func TestRunChatCompletion(t *testing.T) {
+	srv, requests := newServer(t,
+		chatCompletion(`"content":null,"tool_calls":[`+toolCall("call_1", "get_weather", `{"location":"Paris"}`)+`,`+toolCall("call_2", "get_weather", `{"location":"Rome"}`)+`,`+toolCall("call_3", "get_time", `{}`)+`]`, "tool_calls"),
+		chatCompletion(`"content":"Sunny in both cities."`, "stop"),
+	)
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	var running, maxRunning atomic.Int32
+	getWeather := toolrunner.NewTool("get_weather", "Get the weather", func(ctx context.Context, args weatherArgs) (weather, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return weather{Forecast: "Sunny in " + args.Location}, nil
+	})
+
+	var results []toolrunner.ToolResult
+	runner := &toolrunner.Runner{
+		Registry:       newRegistry(t, getWeather),
+		MaxConcurrency: 1,
+		OnToolResult:   func(result toolrunner.ToolResult) { results = append(results, result) },
+	}
+	result, err := runner.RunChatCompletion(context.Background(), &client.Chat.Completions, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Weather in Paris and Rome?")},
+		Model:    openai.ChatModelGPT4o,
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if result.Iterations != 2 || result.Completion.Choices[0].Message.Content != "Sunny in both cities." {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if len(result.Messages) != 6 {
+		t.Fatalf("expected 6 messages, got %d", len(result.Messages))
+	}
+	if maxRunning.Load() != 1 {
+		t.Fatalf("expected at most 1 concurrent tool call, got %d", maxRunning.Load())
+	}
+	if len(results) != 3 || results[2].Name != "get_time" || !errors.Is(results[2].Err, toolrunner.ErrUnknownTool) {
+		t.Fatalf("unexpected tool results %+v", results)
+	}
+
+	first := (*requests)[0]
+	if first.Get("tools.0.function.name").String() != "get_weather" || !first.Get("tools.0.function.strict").Bool() {
+		t.Fatalf("unexpected tools %s", first.Get("tools").Raw)
+	}
+	if first.Get("tools.0.function.parameters.properties.location.type").String() != "string" {
+		t.Fatalf("unexpected tool parameters %s", first.Get("tools.0.function.parameters").Raw)
+	}
+
+	messages := (*requests)[1].Get("messages").Array()
+	if len(messages) != 5 || messages[1].Get("tool_calls.#").Int() != 3 {
+		t.Fatalf("unexpected messages %s", (*requests)[1].Get("messages").Raw)
+	}
+	if messages[2].Get("tool_call_id").String() != "call_1" || messages[2].Get("content").String() != `{"forecast":"Sunny in Paris"}` {
+		t.Fatalf("unexpected tool message %s", messages[2].Raw)
+	}
+	if messages[4].Get("tool_call_id").String() != "call_3" || !strings.HasPrefix(messages[4].Get("content").String(), "Error: toolrunner: unknown tool") {
+		t.Fatalf("unexpected tool message %s", messages[4].Raw)
+	}
+}
+
+func TestRunChatCompletionLimits(t *testing.T) {
+	call := chatCompletion(`"content":null,"tool_calls":[`+toolCall("call_1", "slow", `{"location":"Paris"}`)+`]`, "tool_calls")
+	srv, requests := newServer(t, call, call)
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	slow := toolrunner.NewTool("slow", "", func(ctx context.Context, args weatherArgs) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	slow.Timeout = 10 * time.Millisecond
+
+	runner := &toolrunner.Runner{Registry: newRegistry(t, slow), MaxIterations: 2}
+	result, err := runner.RunChatCompletion(context.Background(), &client.Chat.Completions, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hi")},
+		Model:    openai.ChatModelGPT4o,
+	})
+	if !errors.Is(err, toolrunner.ErrMaxIterations) {
+		t.Fatalf("expected ErrMaxIterations, got %v", err)
+	}
+	if result.Iterations != 2 || len(*requests) != 2 {
+		t.Fatalf("expected 2 iterations, got %d", result.Iterations)
+	}
+	content := (*requests)[1].Get("messages.2.content").String()
+	if content != "Error: context deadline exceeded" {
+		t.Fatalf("expected the timeout to be reported to the model, got %q", content)
+	}
+}
+
+func TestNewRegistryErrors(t *testing.T) {
+	tool := toolrunner.NewTool("echo", "", func(ctx context.Context, args weatherArgs) (string, error) { return args.Location, nil })
+	if _, err := toolrunner.NewRegistry(tool, tool); err == nil {
+		t.Fatal("expected an error for duplicate tools")
+	}
+	invalid := toolrunner.NewTool("invalid", "", func(ctx context.Context, args map[string]any) (string, error) { return "", nil })
+	if _, err := toolrunner.NewRegistry(invalid); err == nil {
+		t.Fatal("expected an error for arguments which are not a struct")
+	}
+}
+
+func TestRunChatCompletionStreaming(t *testing.T) {
+	chunk := func(delta string, finishReason string) string {
+		return fmt.Sprintf("data: {\"id\":\"chatcmpl_1\",\"object\":\"chat.completion.chunk\",\"created\":1,\"model\":\"gpt-4o\",\"choices\":[{\"index\":0,\"delta\":%s,\"finish_reason\":%s}]}\n\n", delta, finishReason)
+	}
+	first := chunk(`{"role":"assistant","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}`, "null") +
+		chunk(`{"tool_calls":[{"index":0,"function":{"arguments":"{\"location\":\"Paris\"}"}}]}`, "null") +
+		chunk(`{"tool_calls":[{"index":1,"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{\"location\":\"Rome\"}"}}]}`, "null") +
+		chunk(`{}`, `"tool_calls"`) +
+		"data: [DONE]\n\n"
+	second := chunk(`{"role":"assistant","content":"Sunny"}`, "null") + chunk(`{}`, `"stop"`) + "data: [DONE]\n\n"
+	srv, requests := newServer(t, first, second)
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	started := make(chan string, 2)
+	getWeather := toolrunner.NewTool("get_weather", "", func(ctx context.Context, args weatherArgs) (string, error) {
+		started <- args.Location
+		return "Sunny in " + args.Location, nil
+	})
+	runner := &toolrunner.Runner{Registry: newRegistry(t, getWeather)}
+	stream := runner.RunChatCompletionStreaming(context.Background(), &client.Chat.Completions, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Weather?")},
+		Model:    openai.ChatModelGPT4o,
+	})
+	defer stream.Close()
+
+	chunks := 0
+	for stream.Next() {
+		chunks++
+		// The first tool call is done once the second one starts streaming.
+		if chunks == 3 {
+			select {
+			case location := <-started:
+				if location != "Paris" {
+					t.Fatalf("unexpected tool call for %s", location)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("expected the first tool call to start while streaming")
+			}
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if chunks != 6 {
+		t.Fatalf("expected 6 chunks, got %d", chunks)
+	}
+	result := stream.Result()
+	if result.Iterations != 2 || result.Completion.Choices[0].Message.Content != "Sunny" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	messages := (*requests)[1].Get("messages").Array()
+	if len(messages) != 4 || messages[3].Get("content").String() != "Sunny in Rome" {
+		t.Fatalf("unexpected messages %s", (*requests)[1].Get("messages").Raw)
+	}
+}
+
+func response(id string, output string) string {
+	return fmt.Sprintf(`{"id":%q,"object":"response","created_at":1,"model":"gpt-4o","status":"completed","output":[%s]}`, id, output)
+}
+
+func TestRunResponse(t *testing.T) {
+	srv, requests := newServer(t,
+		response("resp_1", `{"id":"fc_1","type":"function_call","call_id":"call_1","name":"get_weather","arguments":"{\"location\":\"Paris\"}","status":"completed"}`),
+		response("resp_2", `{"id":"msg_1","type":"message","role":"assistant","status":"completed","content":[{"type":"output_text","text":"Sunny","annotations":[]}]}`),
+	)
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	getWeather := toolrunner.NewTool("get_weather", "Get the weather", func(ctx context.Context, args weatherArgs) (string, error) {
+		return "Sunny in " + args.Location, nil
+	})
+	runner := &toolrunner.Runner{Registry: newRegistry(t, getWeather)}
+	result, err := runner.RunResponse(context.Background(), &client.Responses, responses.ResponseNewParams{
+		Input: responses.ResponseNewParamsInputUnion{OfString: openai.String("Weather in Paris?")},
+		Model: openai.ChatModelGPT4o,
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if result.Iterations != 2 || result.Response.OutputText() != "Sunny" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	if tool := (*requests)[0].Get("tools.0"); tool.Get("name").String() != "get_weather" || tool.Get("type").String() != "function" {
+		t.Fatalf("unexpected tool %s", tool.Raw)
+	}
+	second := (*requests)[1]
+	if second.Get("previous_response_id").String() != "resp_1" {
+		t.Fatalf("expected the follow-up to chain the previous response, got %s", second.Raw)
+	}
+	if output := second.Get("input.0"); output.Get("type").String() != "function_call_output" || output.Get("call_id").String() != "call_1" || output.Get("output").String() != "Sunny in Paris" {
+		t.Fatalf("unexpected input %s", second.Get("input").Raw)
+	}
+}
+
+func TestRunResponseStreaming(t *testing.T) {
+	var first, second strings.Builder
+	writeEvent := func(b *strings.Builder, typ string, data string) {
+		fmt.Fprintf(b, "event: %s\ndata: %s\n\n", typ, data)
+	}
+	call := `{"id":"fc_1","type":"function_call","call_id":"call_1","name":"get_weather","arguments":"{\"location\":\"Paris\"}","status":"completed"}`
+	writeEvent(&first, "response.created", `{"type":"response.created","sequence_number":0,"response":{"id":"resp_1","object":"response","status":"in_progress","output":[]}}`)
+	writeEvent(&first, "response.output_item.added", `{"type":"response.output_item.added","sequence_number":1,"output_index":0,"item":{"id":"fc_1","type":"function_call","call_id":"call_1","name":"get_weather","arguments":"","status":"in_progress"}}`)
+	writeEvent(&first, "response.function_call_arguments.done", `{"type":"response.function_call_arguments.done","sequence_number":2,"item_id":"fc_1","output_index":0,"arguments":"{\"location\":\"Paris\"}"}`)
+	writeEvent(&first, "response.completed", `{"type":"response.completed","sequence_number":3,"response":`+response("resp_1", call)+`}`)
+	writeEvent(&second, "response.output_text.delta", `{"type":"response.output_text.delta","sequence_number":0,"item_id":"msg_1","output_index":0,"content_index":0,"delta":"Sunny"}`)
+	writeEvent(&second, "response.completed", `{"type":"response.completed","sequence_number":1,"response":`+response("resp_2", `{"id":"msg_1","type":"message","role":"assistant","status":"completed","content":[{"type":"output_text","text":"Sunny","annotations":[]}]}`)+`}`)
+	srv, requests := newServer(t, first.String(), second.String())
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"))
+
+	var calls atomic.Int32
+	getWeather := toolrunner.NewTool("get_weather", "", func(ctx context.Context, args weatherArgs) (string, error) {
+		calls.Add(1)
+		return "Sunny in " + args.Location, nil
+	})
+	runner := &toolrunner.Runner{Registry: newRegistry(t, getWeather)}
+	stream := runner.RunResponseStreaming(context.Background(), &client.Responses, responses.ResponseNewParams{
+		Input: responses.ResponseNewParamsInputUnion{OfString: openai.String("Weather in Paris?")},
+		Model: openai.ChatModelGPT4o,
+	})
+	defer stream.Close()
+
+	var text strings.Builder
+	for stream.Next() {
+		if event := stream.Current(); event.Type == "response.output_text.delta" {
+			text.WriteString(event.Delta)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if text.String() != "Sunny" || calls.Load() != 1 {
+		t.Fatalf("unexpected text %q after %d calls", text.String(), calls.Load())
+	}
+	if result := stream.Result(); result.Iterations != 2 || result.Response.ID != "resp_2" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if got := (*requests)[1].Get("input.0.output").String(); got != "Sunny in Paris" {
+		t.Fatalf("unexpected tool output %q", got)
+	}
+}
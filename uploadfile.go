@@ -0,0 +1,227 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+const (
+	// UploadMaxPartSize is the maximum size of a Part of an Upload.
+	UploadMaxPartSize = 64 << 20
+	// UploadMaxBytes is the maximum size of an Upload.
+	UploadMaxBytes = 8 << 30
+
+	uploadDefaultConcurrency = 4
+	uploadDefaultPartRetries = 2
+)
+
+// UploadFile uploads size bytes read from reader as a File, using the Uploads API
+// so files larger than the limit of [FileService.New] can be sent.
+//
+// The bytes are split into Parts which are uploaded concurrently, and retried when
+// they fail. When a Part cannot be uploaded, the Upload is cancelled. The helper is
+// configured with [option.WithUploadFilename], [option.WithUploadPartSize],
+// [option.WithUploadConcurrency], [option.WithUploadPartRetries],
+// [option.WithUploadMD5] and [option.WithUploadProgress].
+//
+// The filename defaults to the name of reader, such as the name of an [os.File],
+// and an empty mimeType is guessed from the extension of the filename.
+func (r *UploadService) UploadFile(ctx context.Context, reader io.Reader, size int64, purpose FilePurpose, mimeType string, opts ...option.RequestOption) (*Upload, error) {
+	cfg, err := requestconfig.PreRequestOptions(slices.Concat(r.Options, opts)...)
+	if err != nil {
+		return nil, err
+	}
+	config := cfg.Upload
+
+	if config.Filename == "" {
+		if named, ok := reader.(interface{ Name() string }); ok {
+			config.Filename = filepath.Base(named.Name())
+		}
+	}
+	if config.Filename == "" {
+		return nil, errors.New("upload: missing filename, set one with option.WithUploadFilename")
+	}
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(config.Filename))
+	}
+	if mimeType == "" {
+		return nil, fmt.Errorf("upload: missing mime type for %s", config.Filename)
+	}
+	if size <= 0 || size > UploadMaxBytes {
+		return nil, fmt.Errorf("upload: size must be between 1 and %d bytes, got %d", UploadMaxBytes, size)
+	}
+	if config.PartSize <= 0 {
+		config.PartSize = UploadMaxPartSize
+	} else if config.PartSize > UploadMaxPartSize {
+		return nil, fmt.Errorf("upload: part size must be at most %d bytes, got %d", UploadMaxPartSize, config.PartSize)
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = uploadDefaultConcurrency
+	}
+	if config.PartRetries == 0 {
+		config.PartRetries = uploadDefaultPartRetries
+	}
+
+	upload, err := r.New(ctx, UploadNewParams{
+		Bytes:    size,
+		Filename: config.Filename,
+		MimeType: mimeType,
+		Purpose:  purpose,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := r.uploadParts(ctx, upload.ID, reader, size, config, opts)
+	if err == nil {
+		var res *Upload
+		res, err = r.Complete(ctx, upload.ID, params, opts...)
+		if err == nil {
+			return res, nil
+		}
+	}
+
+	// The Upload is cancelled even when ctx is done, so it does not linger until
+	// it expires.
+	if _, cancelErr := r.Cancel(context.WithoutCancel(ctx), upload.ID, opts...); cancelErr != nil {
+		err = errors.Join(err, fmt.Errorf("upload: cancelling %s: %w", upload.ID, cancelErr))
+	}
+	return nil, err
+}
+
+// UploadFilePath uploads the file at path with [UploadService.UploadFile].
+func (r *UploadService) UploadFilePath(ctx context.Context, path string, purpose FilePurpose, mimeType string, opts ...option.RequestOption) (*Upload, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return r.UploadFile(ctx, f, info.Size(), purpose, mimeType, opts...)
+}
+
+// uploadParts reads the Parts from reader and uploads them, holding at most
+// config.Concurrency Parts in memory.
+func (r *UploadService) uploadParts(ctx context.Context, uploadID string, reader io.Reader, size int64, config requestconfig.UploadConfig, opts []option.RequestOption) (UploadCompleteParams, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	totalParts := int((size + config.PartSize - 1) / config.PartSize)
+	partIDs := make([]string, totalParts)
+	var checksum hash.Hash
+	if config.MD5 {
+		checksum = md5.New()
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		progress = requestconfig.UploadProgress{UploadID: uploadID, TotalBytes: size, TotalParts: totalParts}
+		slots    = make(chan struct{}, config.Concurrency)
+	)
+parts:
+	for i := 0; i < totalParts; i++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break parts
+		}
+
+		chunk := make([]byte, min(config.PartSize, size-int64(i)*config.PartSize))
+		if _, err := io.ReadFull(reader, chunk); err != nil {
+			<-slots
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				err = fmt.Errorf("reader ended before %d bytes", size)
+			}
+			cancel(fmt.Errorf("upload: reading part %d: %w", i+1, err))
+			break
+		}
+		if checksum != nil {
+			checksum.Write(chunk)
+		}
+
+		wg.Add(1)
+		go func(i int, chunk []byte) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			part, err := r.uploadPart(ctx, uploadID, chunk, config.PartRetries, opts)
+			if err != nil {
+				cancel(fmt.Errorf("upload: part %d: %w", i+1, err))
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			partIDs[i] = part.ID
+			progress.BytesUploaded += int64(len(chunk))
+			progress.PartsUploaded++
+			if config.OnProgress != nil {
+				config.OnProgress(progress)
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return UploadCompleteParams{}, err
+	}
+	params := UploadCompleteParams{PartIDs: partIDs}
+	if checksum != nil {
+		params.Md5 = String(hex.EncodeToString(checksum.Sum(nil)))
+	}
+	return params, nil
+}
+
+// uploadPart uploads a Part, retrying up to retries times when it fails for a
+// reason that may be transient.
+func (r *UploadService) uploadPart(ctx context.Context, uploadID string, chunk []byte, retries int, opts []option.RequestOption) (*UploadPart, error) {
+	backoff := 500 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		part, err := r.Parts.New(ctx, uploadID, UploadPartNewParams{Data: bytes.NewReader(chunk)}, opts...)
+		if err == nil || attempt >= retries || !isRetryablePartError(ctx, err) {
+			return part, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+func isRetryablePartError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apierr *Error
+	if errors.As(err, &apierr) {
+		switch apierr.StatusCode {
+		case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+			return true
+		}
+		return apierr.StatusCode >= http.StatusInternalServerError
+	}
+	return errors.Is(err, ErrConnection) || errors.Is(err, ErrTimeout)
+}
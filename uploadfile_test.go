@@ -0,0 +1,131 @@
+package openai_test
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+// uploadServer fakes the uploads endpoints. The first attempt of every part
+// listed in fail responds with the given status code.
+type uploadServer struct {
+	mu        sync.Mutex
+	fail      map[string]int
+	parts     map[string]string
+	completed *openai.UploadCompleteParams
+	cancelled bool
+}
+
+func (s *uploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.URL.Path == "/uploads":
+		var params openai.UploadNewParams
+		json.NewDecoder(r.Body).Decode(&params)
+		fmt.Fprintf(w, `{"id":"upload_1","bytes":%d,"filename":%q,"status":"pending"}`, params.Bytes, params.Filename)
+
+	case r.URL.Path == "/uploads/upload_1/parts":
+		file, _, err := r.FormFile("data")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		if status, ok := s.fail[string(data)]; ok {
+			delete(s.fail, string(data))
+			w.WriteHeader(status)
+			io.WriteString(w, `{"error":{"message":"part failed"}}`)
+			return
+		}
+		id := "part_" + string(data)
+		s.parts[id] = string(data)
+		fmt.Fprintf(w, `{"id":%q,"object":"upload.part","upload_id":"upload_1"}`, id)
+
+	case r.URL.Path == "/uploads/upload_1/complete":
+		s.completed = &openai.UploadCompleteParams{}
+		json.NewDecoder(r.Body).Decode(s.completed)
+		io.WriteString(w, `{"id":"upload_1","status":"completed","file":{"id":"file_1"}}`)
+
+	case r.URL.Path == "/uploads/upload_1/cancel":
+		s.cancelled = true
+		io.WriteString(w, `{"id":"upload_1","status":"cancelled"}`)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	server := &uploadServer{fail: map[string]int{"efgh": http.StatusInternalServerError}, parts: map[string]string{}}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"), option.WithMaxRetries(0))
+
+	content := "abcdefghij"
+	var progress []option.UploadProgress
+	upload, err := client.Uploads.UploadFile(context.Background(), strings.NewReader(content), int64(len(content)), openai.FilePurposeUserData, "",
+		option.WithUploadFilename("notes.txt"),
+		option.WithUploadPartSize(4),
+		option.WithUploadConcurrency(2),
+		option.WithUploadMD5(),
+		option.WithUploadProgress(func(p option.UploadProgress) { progress = append(progress, p) }),
+	)
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if upload.Status != openai.UploadStatusCompleted || upload.File.ID != "file_1" {
+		t.Fatalf("unexpected upload %s", upload.RawJSON())
+	}
+
+	expected := []string{"part_abcd", "part_efgh", "part_ij"}
+	if strings.Join(server.completed.PartIDs, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected parts %v, got %v", expected, server.completed.PartIDs)
+	}
+	sum := md5.Sum([]byte(content))
+	if server.completed.Md5.Value != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected md5 %s", server.completed.Md5.Value)
+	}
+	if len(progress) != 3 || progress[2].BytesUploaded != 10 || progress[2].PartsUploaded != 3 || progress[2].TotalParts != 3 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+}
+
+func TestUploadFileCancelsOnFailure(t *testing.T) {
+	server := &uploadServer{fail: map[string]int{"efgh": http.StatusBadRequest}, parts: map[string]string{}}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+	client := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("My API Key"), option.WithMaxRetries(0))
+
+	_, err := client.Uploads.UploadFile(context.Background(), strings.NewReader("abcdefghij"), 10, openai.FilePurposeUserData, "text/plain",
+		option.WithUploadFilename("notes.txt"),
+		option.WithUploadPartSize(4),
+	)
+	if err == nil || !strings.Contains(err.Error(), "part 2") {
+		t.Fatalf("expected part 2 to fail, got %v", err)
+	}
+	if !server.cancelled || server.completed != nil {
+		t.Fatalf("expected the upload to be cancelled and not completed")
+	}
+
+	server.cancelled = false
+	_, err = client.Uploads.UploadFile(context.Background(), strings.NewReader("abc"), 10, openai.FilePurposeUserData, "text/plain",
+		option.WithUploadFilename("notes.txt"),
+	)
+	if err == nil || !strings.Contains(err.Error(), "reader ended") || !server.cancelled {
+		t.Fatalf("expected a short reader to cancel the upload, got %v", err)
+	}
+}
@@ -0,0 +1,302 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3/internal/requestconfig"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+)
+
+// DefaultMaxBodyBytes is the default size limit of the webhook bodies accepted by
+// a [Handler].
+const DefaultMaxBodyBytes = 1 << 20
+
+// Handler is an [http.Handler] receiving webhooks: it verifies their signature,
+// decodes their event, and calls the function registered for its type.
+//
+//	handler := client.Webhooks.NewHandler()
+//	handler.OnResponseCompleted(func(ctx context.Context, event webhooks.ResponseCompletedWebhookEvent) error {
+//		return process(ctx, event.Data.ID)
+//	})
+//	http.Handle("POST /webhooks/openai", handler)
+//
+// The handler answers 200 once the event is handled, or when no function is
+// registered for its type, so OpenAI does not deliver it again. It answers 400
+// when the signature or the event is invalid, 413 when the body is too large, and
+// 500 when the function fails or the webhook secret is missing, in which case
+// OpenAI retries the delivery.
+//
+// Register the functions before serving requests.
+type Handler struct {
+	// Tolerance is the maximum age of a webhook. Defaults to 5 minutes.
+	Tolerance time.Duration
+	// MaxBodyBytes limits the size of the bodies. Defaults to [DefaultMaxBodyBytes].
+	MaxBodyBytes int64
+	// Store makes the handler idempotent, by skipping the deliveries whose
+	// webhook-id header was already handled. Deliveries are not deduplicated when
+	// nil.
+	Store IdempotencyStore
+	// ErrorLog is called with the errors which make the handler answer with a
+	// failure status, for logging.
+	ErrorLog func(req *http.Request, err error)
+
+	service  WebhookService
+	handlers map[string]func(context.Context, UnwrapWebhookEventUnion) error
+	fallback func(context.Context, UnwrapWebhookEventUnion) error
+}
+
+// NewHandler returns a webhook [Handler] verifying the signatures with the webhook
+// secrets of the service, see [option.WithWebhookSecret] and
+// [option.WithWebhookSecrets].
+func (r *WebhookService) NewHandler(opts ...option.RequestOption) *Handler {
+	return &Handler{
+		service:  NewWebhookService(slices.Concat(r.Options, opts)...),
+		handlers: map[string]func(context.Context, UnwrapWebhookEventUnion) error{},
+	}
+}
+
+// On registers the function handling the events of a type, such as
+// "response.completed". It replaces the function registered for the type, if
+// any.
+func (h *Handler) On(eventType string, fn func(ctx context.Context, event UnwrapWebhookEventUnion) error) {
+	h.handlers[eventType] = fn
+}
+
+// OnUnhandled registers the function handling the events without a function
+// registered for their type, such as the types added after this version of the
+// SDK.
+func (h *Handler) OnUnhandled(fn func(ctx context.Context, event UnwrapWebhookEventUnion) error) {
+	h.fallback = fn
+}
+
+func on[E any](h *Handler, eventType string, as func(UnwrapWebhookEventUnion) E, fn func(context.Context, E) error) {
+	h.On(eventType, func(ctx context.Context, event UnwrapWebhookEventUnion) error {
+		return fn(ctx, as(event))
+	})
+}
+
+// OnBatchCancelled registers the function handling "batch.cancelled" events.
+func (h *Handler) OnBatchCancelled(fn func(ctx context.Context, event BatchCancelledWebhookEvent) error) {
+	on(h, "batch.cancelled", UnwrapWebhookEventUnion.AsBatchCancelled, fn)
+}
+
+// OnBatchCompleted registers the function handling "batch.completed" events.
+func (h *Handler) OnBatchCompleted(fn func(ctx context.Context, event BatchCompletedWebhookEvent) error) {
+	on(h, "batch.completed", UnwrapWebhookEventUnion.AsBatchCompleted, fn)
+}
+
+// OnBatchExpired registers the function handling "batch.expired" events.
+func (h *Handler) OnBatchExpired(fn func(ctx context.Context, event BatchExpiredWebhookEvent) error) {
+	on(h, "batch.expired", UnwrapWebhookEventUnion.AsBatchExpired, fn)
+}
+
+// OnBatchFailed registers the function handling "batch.failed" events.
+func (h *Handler) OnBatchFailed(fn func(ctx context.Context, event BatchFailedWebhookEvent) error) {
+	on(h, "batch.failed", UnwrapWebhookEventUnion.AsBatchFailed, fn)
+}
+
+// OnEvalRunCanceled registers the function handling "eval.run.canceled" events.
+func (h *Handler) OnEvalRunCanceled(fn func(ctx context.Context, event EvalRunCanceledWebhookEvent) error) {
+	on(h, "eval.run.canceled", UnwrapWebhookEventUnion.AsEvalRunCanceled, fn)
+}
+
+// OnEvalRunFailed registers the function handling "eval.run.failed" events.
+func (h *Handler) OnEvalRunFailed(fn func(ctx context.Context, event EvalRunFailedWebhookEvent) error) {
+	on(h, "eval.run.failed", UnwrapWebhookEventUnion.AsEvalRunFailed, fn)
+}
+
+// OnEvalRunSucceeded registers the function handling "eval.run.succeeded" events.
+func (h *Handler) OnEvalRunSucceeded(fn func(ctx context.Context, event EvalRunSucceededWebhookEvent) error) {
+	on(h, "eval.run.succeeded", UnwrapWebhookEventUnion.AsEvalRunSucceeded, fn)
+}
+
+// OnFineTuningJobCancelled registers the function handling
+// "fine_tuning.job.cancelled" events.
+func (h *Handler) OnFineTuningJobCancelled(fn func(ctx context.Context, event FineTuningJobCancelledWebhookEvent) error) {
+	on(h, "fine_tuning.job.cancelled", UnwrapWebhookEventUnion.AsFineTuningJobCancelled, fn)
+}
+
+// OnFineTuningJobFailed registers the function handling "fine_tuning.job.failed"
+// events.
+func (h *Handler) OnFineTuningJobFailed(fn func(ctx context.Context, event FineTuningJobFailedWebhookEvent) error) {
+	on(h, "fine_tuning.job.failed", UnwrapWebhookEventUnion.AsFineTuningJobFailed, fn)
+}
+
+// OnFineTuningJobSucceeded registers the function handling
+// "fine_tuning.job.succeeded" events.
+func (h *Handler) OnFineTuningJobSucceeded(fn func(ctx context.Context, event FineTuningJobSucceededWebhookEvent) error) {
+	on(h, "fine_tuning.job.succeeded", UnwrapWebhookEventUnion.AsFineTuningJobSucceeded, fn)
+}
+
+// OnRealtimeCallIncoming registers the function handling "realtime.call.incoming"
+// events.
+func (h *Handler) OnRealtimeCallIncoming(fn func(ctx context.Context, event RealtimeCallIncomingWebhookEvent) error) {
+	on(h, "realtime.call.incoming", UnwrapWebhookEventUnion.AsRealtimeCallIncoming, fn)
+}
+
+// OnResponseCancelled registers the function handling "response.cancelled" events.
+func (h *Handler) OnResponseCancelled(fn func(ctx context.Context, event ResponseCancelledWebhookEvent) error) {
+	on(h, "response.cancelled", UnwrapWebhookEventUnion.AsResponseCancelled, fn)
+}
+
+// OnResponseCompleted registers the function handling "response.completed" events.
+func (h *Handler) OnResponseCompleted(fn func(ctx context.Context, event ResponseCompletedWebhookEvent) error) {
+	on(h, "response.completed", UnwrapWebhookEventUnion.AsResponseCompleted, fn)
+}
+
+// OnResponseFailed registers the function handling "response.failed" events.
+func (h *Handler) OnResponseFailed(fn func(ctx context.Context, event ResponseFailedWebhookEvent) error) {
+	on(h, "response.failed", UnwrapWebhookEventUnion.AsResponseFailed, fn)
+}
+
+// OnResponseIncomplete registers the function handling "response.incomplete"
+// events.
+func (h *Handler) OnResponseIncomplete(fn func(ctx context.Context, event ResponseIncompleteWebhookEvent) error) {
+	on(h, "response.incomplete", UnwrapWebhookEventUnion.AsResponseIncomplete, fn)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	status, err := h.serve(req)
+	if err != nil && h.ErrorLog != nil {
+		h.ErrorLog(req, err)
+	}
+	if status == http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+func (h *Handler) serve(req *http.Request) (int, error) {
+	if req.Method != http.MethodPost {
+		return http.StatusMethodNotAllowed, fmt.Errorf("webhook: unexpected method %s", req.Method)
+	}
+	cfg, err := requestconfig.PreRequestOptions(h.service.Options...)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if len(secrets(cfg)) == 0 {
+		return http.StatusInternalServerError, errors.New("webhook: webhook secret must be configured on the client or the handler")
+	}
+
+	maxBytes := h.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBodyBytes
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBytes+1))
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("webhook: reading body: %w", err)
+	}
+	if int64(len(body)) > maxBytes {
+		return http.StatusRequestEntityTooLarge, fmt.Errorf("webhook: body larger than %d bytes", maxBytes)
+	}
+
+	tolerance := h.Tolerance
+	if tolerance <= 0 {
+		tolerance = 5 * time.Minute
+	}
+	if err := h.service.VerifySignatureWithTolerance(body, req.Header, tolerance); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("webhook: %w", err)
+	}
+	event := UnwrapWebhookEventUnion{}
+	if err := event.UnmarshalJSON(body); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("webhook: decoding event: %w", err)
+	}
+
+	fn := h.handlers[event.Type]
+	if fn == nil {
+		fn = h.fallback
+	}
+	if fn == nil {
+		return http.StatusOK, nil
+	}
+
+	ctx := req.Context()
+	webhookID := req.Header.Get("webhook-id")
+	if h.Store != nil {
+		claimed, err := h.Store.Claim(ctx, webhookID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("webhook: claiming %s: %w", webhookID, err)
+		}
+		if !claimed {
+			return http.StatusOK, nil
+		}
+	}
+	if err := fn(ctx, event); err != nil {
+		if h.Store != nil {
+			// Let the next delivery handle the event again.
+			err = errors.Join(err, h.Store.Release(context.WithoutCancel(ctx), webhookID))
+		}
+		return http.StatusInternalServerError, fmt.Errorf("webhook: handling %s event %s: %w", event.Type, event.ID, err)
+	}
+	return http.StatusOK, nil
+}
+
+// IdempotencyStore records the webhook IDs handled by a [Handler], so the
+// deliveries of an event after the first one are skipped. OpenAI retries failed
+// deliveries for up to 72 hours. An implementation shared by every instance of
+// a service, such as one backed by a database, is safe for concurrent use.
+type IdempotencyStore interface {
+	// Claim records a webhook ID before its event is handled. It reports false
+	// when the ID was already claimed.
+	Claim(ctx context.Context, webhookID string) (bool, error)
+	// Release forgets a webhook ID whose event failed to be handled, so it is
+	// handled again when delivered again.
+	Release(ctx context.Context, webhookID string) error
+}
+
+// MemoryStore is an [IdempotencyStore] keeping the webhook IDs in memory, which
+// only deduplicates the deliveries received by the same process.
+type MemoryStore struct {
+	// TTL is how long a webhook ID is kept. Defaults to 72 hours.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	claimed map[string]time.Time
+	pruned  time.Time
+}
+
+// NewMemoryStore returns an empty [MemoryStore].
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{claimed: map[string]time.Time{}}
+}
+
+func (s *MemoryStore) Claim(ctx context.Context, webhookID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	ttl := s.TTL
+	if ttl <= 0 {
+		ttl = 72 * time.Hour
+	}
+	if s.claimed == nil {
+		s.claimed = map[string]time.Time{}
+	}
+	if now.Sub(s.pruned) > ttl/100 {
+		for id, at := range s.claimed {
+			if now.Sub(at) > ttl {
+				delete(s.claimed, id)
+			}
+		}
+		s.pruned = now
+	}
+	if at, ok := s.claimed[webhookID]; ok && now.Sub(at) <= ttl {
+		return false, nil
+	}
+	s.claimed[webhookID] = now
+	return true, nil
+}
+
+func (s *MemoryStore) Release(ctx context.Context, webhookID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.claimed, webhookID)
+	return nil
+}
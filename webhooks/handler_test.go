@@ -0,0 +1,106 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/webhooks"
+)
+
+func signedRequest(t *testing.T, webhookID string, payload string) *http.Request {
+	t.Helper()
+	header, err := webhooks.Sign(testSecret, webhookID, time.Now(), []byte(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+	req.Header = header
+	return req
+}
+
+func serve(handler http.Handler, req *http.Request) int {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestHandler(t *testing.T) {
+	client := openai.NewClient(option.WithAPIKey("test-key"), option.WithWebhookSecret(testSecret))
+	handler := client.Webhooks.NewHandler()
+	handler.Store = webhooks.NewMemoryStore()
+
+	var completed []string
+	fail := true
+	handler.OnResponseCompleted(func(ctx context.Context, event webhooks.ResponseCompletedWebhookEvent) error {
+		if fail {
+			fail = false
+			return errors.New("database unavailable")
+		}
+		completed = append(completed, event.Data.ID)
+		return nil
+	})
+
+	// A failed event is handled again when delivered again, and only once.
+	for i, want := range []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK} {
+		if code := serve(handler, signedRequest(t, testWebhookID, testPayload)); code != want {
+			t.Errorf("delivery %d: expected %d, got %d", i, want, code)
+		}
+	}
+	if len(completed) != 1 || completed[0] != "resp_123" {
+		t.Errorf("expected the event to be handled once, got %v", completed)
+	}
+
+	// Events without a registered function are acknowledged.
+	batch := `{"id": "evt_1", "object": "event", "created_at": 1750861210, "type": "batch.failed", "data": {"id": "batch_123"}}`
+	if code := serve(handler, signedRequest(t, "wh_batch", batch)); code != http.StatusOK {
+		t.Errorf("expected an unhandled event to be acknowledged, got %d", code)
+	}
+	var unhandled string
+	handler.OnUnhandled(func(ctx context.Context, event webhooks.UnwrapWebhookEventUnion) error {
+		unhandled = event.Type
+		return nil
+	})
+	if code := serve(handler, signedRequest(t, "wh_batch_2", batch)); code != http.StatusOK || unhandled != "batch.failed" {
+		t.Errorf("expected the fallback to handle the event, got %d and %q", code, unhandled)
+	}
+}
+
+func TestHandlerRejects(t *testing.T) {
+	client := openai.NewClient(option.WithAPIKey("test-key"), option.WithWebhookSecret(testSecret))
+	handler := client.Webhooks.NewHandler()
+	handler.MaxBodyBytes = 512
+
+	tampered := signedRequest(t, testWebhookID, testPayload)
+	tampered.Body = http.NoBody
+	if code := serve(handler, tampered); code != http.StatusBadRequest {
+		t.Errorf("expected a tampered body to be rejected with 400, got %d", code)
+	}
+
+	// The fixed timestamp of the test payload is too old.
+	old := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(testPayload))
+	old.Header = createTestHeaders()
+	if code := serve(handler, old); code != http.StatusBadRequest {
+		t.Errorf("expected an old webhook to be rejected with 400, got %d", code)
+	}
+
+	large := `{"id": "evt_1", "type": "batch.failed", "data": {"id": "` + strings.Repeat("a", 1024) + `"}}`
+	if code := serve(handler, signedRequest(t, testWebhookID, large)); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected a large body to be rejected with 413, got %d", code)
+	}
+
+	if code := serve(handler, httptest.NewRequest(http.MethodGet, "/webhooks", nil)); code != http.StatusMethodNotAllowed {
+		t.Errorf("expected a GET to be rejected with 405, got %d", code)
+	}
+
+	noSecret := openai.NewClient(option.WithAPIKey("test-key"))
+	if code := serve(noSecret.Webhooks.NewHandler(), signedRequest(t, testWebhookID, testPayload)); code != http.StatusInternalServerError {
+		t.Errorf("expected a missing secret to fail with 500, got %d", code)
+	}
+}
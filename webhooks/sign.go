@@ -0,0 +1,187 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Sign returns the webhook-id, webhook-timestamp and webhook-signature headers of
+// a webhook delivering payload, signed with secret the way OpenAI signs webhooks.
+// It lets tests fabricate deliveries which pass [WebhookService.VerifySignature].
+//
+// The secret is base64 encoded when it starts with whsec_, like the secrets of
+// the OpenAI dashboard.
+func Sign(secret string, webhookID string, timestamp time.Time, payload []byte) (http.Header, error) {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	signature, err := sign(secret, webhookID, ts, payload)
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{}
+	header.Set("webhook-id", webhookID)
+	header.Set("webhook-timestamp", ts)
+	header.Set("webhook-signature", "v1,"+signature)
+	return header, nil
+}
+
+// NewDelivery returns a POST request to url delivering event, signed with secret
+// at the current time and with a random webhook ID, such as OpenAI sends. The
+// event is one of the events of the package, built for instance with
+// [NewResponseCompletedEvent], or its JSON.
+//
+//	req, err := webhooks.NewDelivery(secret, "/webhooks/openai", webhooks.NewBatchCompletedEvent("batch_123"))
+//	handler.ServeHTTP(httptest.NewRecorder(), req)
+func NewDelivery(secret string, url string, event any) (*http.Request, error) {
+	var payload []byte
+	switch event := event.(type) {
+	case []byte:
+		payload = event
+	case string:
+		payload = []byte(event)
+	case interface{ RawJSON() string }:
+		payload = []byte(event.RawJSON())
+	}
+	if len(payload) == 0 {
+		var err error
+		if payload, err = json.Marshal(event); err != nil {
+			return nil, fmt.Errorf("webhook: encoding event: %w", err)
+		}
+	}
+
+	header, err := Sign(secret, randomID("wh_"), time.Now(), payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// NewBatchCancelledEvent returns a batch.cancelled event for a batch, with a
+// random ID and created now.
+func NewBatchCancelledEvent(batchID string) BatchCancelledWebhookEvent {
+	return newEvent[BatchCancelledWebhookEvent]("batch.cancelled", map[string]any{"id": batchID})
+}
+
+// NewBatchCompletedEvent returns a batch.completed event for a batch, with a
+// random ID and created now.
+func NewBatchCompletedEvent(batchID string) BatchCompletedWebhookEvent {
+	return newEvent[BatchCompletedWebhookEvent]("batch.completed", map[string]any{"id": batchID})
+}
+
+// NewBatchExpiredEvent returns a batch.expired event for a batch, with a random
+// ID and created now.
+func NewBatchExpiredEvent(batchID string) BatchExpiredWebhookEvent {
+	return newEvent[BatchExpiredWebhookEvent]("batch.expired", map[string]any{"id": batchID})
+}
+
+// NewBatchFailedEvent returns a batch.failed event for a batch, with a random ID
+// and created now.
+func NewBatchFailedEvent(batchID string) BatchFailedWebhookEvent {
+	return newEvent[BatchFailedWebhookEvent]("batch.failed", map[string]any{"id": batchID})
+}
+
+// NewEvalRunCanceledEvent returns an eval.run.canceled event for an eval run,
+// with a random ID and created now.
+func NewEvalRunCanceledEvent(runID string) EvalRunCanceledWebhookEvent {
+	return newEvent[EvalRunCanceledWebhookEvent]("eval.run.canceled", map[string]any{"id": runID})
+}
+
+// NewEvalRunFailedEvent returns an eval.run.failed event for an eval run, with a
+// random ID and created now.
+func NewEvalRunFailedEvent(runID string) EvalRunFailedWebhookEvent {
+	return newEvent[EvalRunFailedWebhookEvent]("eval.run.failed", map[string]any{"id": runID})
+}
+
+// NewEvalRunSucceededEvent returns an eval.run.succeeded event for an eval run,
+// with a random ID and created now.
+func NewEvalRunSucceededEvent(runID string) EvalRunSucceededWebhookEvent {
+	return newEvent[EvalRunSucceededWebhookEvent]("eval.run.succeeded", map[string]any{"id": runID})
+}
+
+// NewFineTuningJobCancelledEvent returns a fine_tuning.job.cancelled event for a
+// fine-tuning job, with a random ID and created now.
+func NewFineTuningJobCancelledEvent(jobID string) FineTuningJobCancelledWebhookEvent {
+	return newEvent[FineTuningJobCancelledWebhookEvent]("fine_tuning.job.cancelled", map[string]any{"id": jobID})
+}
+
+// NewFineTuningJobFailedEvent returns a fine_tuning.job.failed event for a
+// fine-tuning job, with a random ID and created now.
+func NewFineTuningJobFailedEvent(jobID string) FineTuningJobFailedWebhookEvent {
+	return newEvent[FineTuningJobFailedWebhookEvent]("fine_tuning.job.failed", map[string]any{"id": jobID})
+}
+
+// NewFineTuningJobSucceededEvent returns a fine_tuning.job.succeeded event for a
+// fine-tuning job, with a random ID and created now.
+func NewFineTuningJobSucceededEvent(jobID string) FineTuningJobSucceededWebhookEvent {
+	return newEvent[FineTuningJobSucceededWebhookEvent]("fine_tuning.job.succeeded", map[string]any{"id": jobID})
+}
+
+// NewRealtimeCallIncomingEvent returns a realtime.call.incoming event for a call
+// with the headers of its SIP invite, with a random ID and created now.
+func NewRealtimeCallIncomingEvent(callID string, sipHeaders ...RealtimeCallIncomingWebhookEventDataSipHeader) RealtimeCallIncomingWebhookEvent {
+	headers := make([]map[string]any, 0, len(sipHeaders))
+	for _, header := range sipHeaders {
+		headers = append(headers, map[string]any{"name": header.Name, "value": header.Value})
+	}
+	return newEvent[RealtimeCallIncomingWebhookEvent]("realtime.call.incoming", map[string]any{"call_id": callID, "sip_headers": headers})
+}
+
+// NewResponseCancelledEvent returns a response.cancelled event for a background
+// response, with a random ID and created now.
+func NewResponseCancelledEvent(responseID string) ResponseCancelledWebhookEvent {
+	return newEvent[ResponseCancelledWebhookEvent]("response.cancelled", map[string]any{"id": responseID})
+}
+
+// NewResponseCompletedEvent returns a response.completed event for a background
+// response, with a random ID and created now.
+func NewResponseCompletedEvent(responseID string) ResponseCompletedWebhookEvent {
+	return newEvent[ResponseCompletedWebhookEvent]("response.completed", map[string]any{"id": responseID})
+}
+
+// NewResponseFailedEvent returns a response.failed event for a background
+// response, with a random ID and created now.
+func NewResponseFailedEvent(responseID string) ResponseFailedWebhookEvent {
+	return newEvent[ResponseFailedWebhookEvent]("response.failed", map[string]any{"id": responseID})
+}
+
+// NewResponseIncompleteEvent returns a response.incomplete event for a background
+// response, with a random ID and created now.
+func NewResponseIncompleteEvent(responseID string) ResponseIncompleteWebhookEvent {
+	return newEvent[ResponseIncompleteWebhookEvent]("response.incomplete", map[string]any{"id": responseID})
+}
+
+// newEvent decodes an event from its JSON, so that its raw JSON and the metadata
+// of its fields are set like for a received event.
+func newEvent[E any](typ string, data map[string]any) (event E) {
+	payload, err := json.Marshal(map[string]any{
+		"id":         randomID("evt_"),
+		"object":     "event",
+		"created_at": time.Now().Unix(),
+		"type":       typ,
+		"data":       data,
+	})
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		panic(err)
+	}
+	return event
+}
+
+func randomID(prefix string) string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return prefix + hex.EncodeToString(b)
+}
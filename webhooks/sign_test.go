@@ -0,0 +1,95 @@
+package webhooks_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nordlys-Labs/openai-go/v3"
+	"github.com/Nordlys-Labs/openai-go/v3/option"
+	"github.com/Nordlys-Labs/openai-go/v3/webhooks"
+)
+
+func TestSign(t *testing.T) {
+	header, err := webhooks.Sign(testSecret, testWebhookID, time.Unix(testTimestamp, 0), []byte(testPayload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := header.Get("webhook-signature"); got != testSignature {
+		t.Errorf("expected the signature of the test payload, got %q", got)
+	}
+
+	client := openai.NewClient(option.WithAPIKey("test-key"), option.WithWebhookSecret(testSecret))
+	event := webhooks.NewResponseCompletedEvent("resp_456")
+	header, err = webhooks.Sign(testSecret, "wh_456", time.Now(), []byte(event.RawJSON()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unwrapped, err := client.Webhooks.Unwrap([]byte(event.RawJSON()), header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	completed := unwrapped.AsResponseCompleted()
+	if completed.Data.ID != "resp_456" || completed.ID != event.ID || completed.Object != "event" || completed.CreatedAt == 0 {
+		t.Errorf("unexpected event %s", unwrapped.RawJSON())
+	}
+
+	call := webhooks.NewRealtimeCallIncomingEvent("rtc_123", webhooks.RealtimeCallIncomingWebhookEventDataSipHeader{Name: "From", Value: "sip:alice@example.com"})
+	if !call.Data.JSON.SipHeaders.Valid() || call.Data.SipHeaders[0].Value != "sip:alice@example.com" || call.Type != "realtime.call.incoming" {
+		t.Errorf("unexpected event %s", call.RawJSON())
+	}
+}
+
+func TestSecretRotation(t *testing.T) {
+	const newSecret = "whsec_bmV3LXNlY3JldC1mb3Itcm90YXRpb24tdGVzdHMtMTIzNDU2"
+	client := openai.NewClient(option.WithAPIKey("test-key"), option.WithWebhookSecrets(newSecret, testSecret))
+
+	for _, secret := range []string{newSecret, testSecret} {
+		header, err := webhooks.Sign(secret, testWebhookID, time.Now(), []byte(testPayload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.Webhooks.VerifySignature([]byte(testPayload), header); err != nil {
+			t.Errorf("expected a webhook signed with a rotated secret to be valid: %v", err)
+		}
+	}
+
+	header, err := webhooks.Sign("whsec_b3RoZXItc2VjcmV0", testWebhookID, time.Now(), []byte(testPayload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Webhooks.VerifySignature([]byte(testPayload), header); err == nil {
+		t.Error("expected a webhook signed with another secret to be invalid")
+	}
+	// A malformed secret does not prevent the others from being tried.
+	if err := client.Webhooks.VerifySignature([]byte(testPayload), header, option.WithWebhookSecrets("whsec_not base64!", "whsec_b3RoZXItc2VjcmV0")); err != nil {
+		t.Errorf("expected a malformed secret to be skipped: %v", err)
+	}
+	if err := client.Webhooks.VerifySignature([]byte(testPayload), header, option.WithWebhookSecrets("whsec_not base64!")); err == nil || !strings.Contains(err.Error(), "invalid webhook secret format") {
+		t.Errorf("expected an error for the malformed secret, got %v", err)
+	}
+	// The secrets can also be given to the method call.
+	noSecret := openai.NewClient(option.WithAPIKey("test-key"))
+	if err := noSecret.Webhooks.VerifySignature([]byte(testPayload), header, option.WithWebhookSecrets(testSecret, "whsec_b3RoZXItc2VjcmV0")); err != nil {
+		t.Errorf("expected the secrets of the method call to be used: %v", err)
+	}
+
+	handler := client.Webhooks.NewHandler()
+	var failed string
+	handler.OnBatchFailed(func(ctx context.Context, event webhooks.BatchFailedWebhookEvent) error {
+		failed = event.Data.ID
+		return nil
+	})
+	req, err := webhooks.NewDelivery(testSecret, "/webhooks", webhooks.NewBatchFailedEvent("batch_123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || failed != "batch_123" {
+		t.Errorf("expected the delivery to be handled, got %d and %q", rec.Code, failed)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -109,13 +110,13 @@ func (r *WebhookService) VerifySignatureWithTolerance(body []byte, headers http.
 // tolerance specifies the maximum age of the webhook.
 // now allows specifying the current time for testing purposes.
 func (r *WebhookService) VerifySignatureWithToleranceAndTime(body []byte, headers http.Header, tolerance time.Duration, now time.Time, opts ...option.RequestOption) error {
-	cfg, err := requestconfig.PreRequestOptions(r.Options...)
+	cfg, err := requestconfig.PreRequestOptions(slices.Concat(r.Options, opts)...)
 	if err != nil {
 		return err
 	}
-	webhookSecret := cfg.WebhookSecret
+	webhookSecrets := secrets(cfg)
 
-	if webhookSecret == "" {
+	if len(webhookSecrets) == 0 {
 		return errors.New("webhook secret must be provided either in the method call or configured on the client")
 	}
 
@@ -168,33 +169,65 @@ func (r *WebhookService) VerifySignatureWithToleranceAndTime(body []byte, header
 		}
 	}
 
+	// Accept if any signature matches the signature of any secret using
+	// timing-safe comparison. A malformed secret is skipped, so that it does
+	// not prevent the others from being tried while a secret is rotated.
+	var secretErr error
+	valid := 0
+	for _, webhookSecret := range webhookSecrets {
+		expectedSignature, err := sign(webhookSecret, webhookID, timestampHeader, body)
+		if err != nil {
+			secretErr = err
+			continue
+		}
+		valid++
+		for _, signature := range signatures {
+			if subtle.ConstantTimeCompare([]byte(expectedSignature), []byte(signature)) == 1 {
+				return nil
+			}
+		}
+	}
+	if valid == 0 {
+		return secretErr
+	}
+
+	return errors.New("webhook signature verification failed")
+}
+
+// secrets returns the secrets accepted to verify webhooks, see
+// [option.WithWebhookSecrets].
+func secrets(cfg requestconfig.RequestConfig) []string {
+	if len(cfg.WebhookSecrets) > 0 {
+		return slices.DeleteFunc(slices.Clone(cfg.WebhookSecrets), func(secret string) bool { return secret == "" })
+	}
+	if cfg.WebhookSecret != "" {
+		return []string{cfg.WebhookSecret}
+	}
+	return nil
+}
+
+// sign computes the base64 HMAC-SHA256 signature of a webhook with a secret,
+// which is base64 encoded when it starts with whsec_.
+func sign(secret string, webhookID string, timestamp string, body []byte) (string, error) {
 	// Decode the secret if it starts with whsec_
 	var decodedSecret []byte
-	if strings.HasPrefix(webhookSecret, "whsec_") {
-		decodedSecret, err = base64.StdEncoding.DecodeString(webhookSecret[6:])
+	if strings.HasPrefix(secret, "whsec_") {
+		var err error
+		decodedSecret, err = base64.StdEncoding.DecodeString(secret[6:])
 		if err != nil {
-			return fmt.Errorf("invalid webhook secret format: %v", err)
+			return "", fmt.Errorf("invalid webhook secret format: %v", err)
 		}
 	} else {
-		decodedSecret = []byte(webhookSecret)
+		decodedSecret = []byte(secret)
 	}
 
 	// Create the signed payload: {webhook_id}.{timestamp}.{payload}
-	signedPayload := fmt.Sprintf("%s.%s.%s", webhookID, timestampHeader, string(body))
+	signedPayload := fmt.Sprintf("%s.%s.%s", webhookID, timestamp, string(body))
 
 	// Compute HMAC-SHA256 signature
 	h := hmac.New(sha256.New, decodedSecret)
 	h.Write([]byte(signedPayload))
-	expectedSignature := base64.StdEncoding.EncodeToString(h.Sum(nil))
-
-	// Accept if any signature matches using timing-safe comparison
-	for _, signature := range signatures {
-		if subtle.ConstantTimeCompare([]byte(expectedSignature), []byte(signature)) == 1 {
-			return nil
-		}
-	}
-
-	return errors.New("webhook signature verification failed")
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
 }
 
 // Sent when a batch API request has been cancelled.